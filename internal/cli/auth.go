@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/graph"
 )
 
 func newLoginCmd() *cobra.Command {
@@ -18,12 +20,20 @@ discovers the organization name.
 Creates or updates the config file with the new drive section.
 
 The --browser flag opens your default browser for authentication, which can be
-useful when the device code flow is blocked by organizational policies.`,
+useful when the device code flow is blocked by organizational policies.
+
+--read-only asks only for read access (Files.Read.All): commands that change
+the drive are refused and sync runs download-only. --app-folder asks only for
+the app's own folder (Files.ReadWrite.AppFolder) and roots the drive there.
+A later plain login restores full access.`,
 		Annotations: map[string]string{skipConfigAnnotation: skipConfigValue},
 		RunE:        runLogin,
 	}
 
 	cmd.Flags().Bool("browser", false, "use browser-based auth (authorization code + PKCE) instead of device code")
+	cmd.Flags().Bool("read-only", false, "ask for read-only file access")
+	cmd.Flags().Bool("app-folder", false, "ask for access to the app folder only, and root the drive there")
+	cmd.MarkFlagsMutuallyExclusive("read-only", "app-folder")
 
 	return cmd
 }
@@ -57,7 +67,25 @@ func runLogin(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("reading --browser flag: %w", err)
 	}
 
-	return runLoginWithContext(cmd.Context(), mustCLIContext(cmd.Context()), useBrowser)
+	readOnly, err := cmd.Flags().GetBool("read-only")
+	if err != nil {
+		return fmt.Errorf("reading --read-only flag: %w", err)
+	}
+
+	appFolder, err := cmd.Flags().GetBool("app-folder")
+	if err != nil {
+		return fmt.Errorf("reading --app-folder flag: %w", err)
+	}
+
+	access := graph.LoginAccessFull
+	switch {
+	case readOnly:
+		access = graph.LoginAccessReadOnly
+	case appFolder:
+		access = graph.LoginAccessAppFolder
+	}
+
+	return runLoginWithContext(cmd.Context(), mustCLIContext(cmd.Context()), useBrowser, access)
 }
 
 // runLogout removes the authentication token for an account. Identifies the
@@ -13,12 +13,12 @@ import (
 	"github.com/tonimelisma/onedrive-go/internal/tokenfile"
 )
 
-func runLoginWithContext(ctx context.Context, cc *CLIContext, useBrowser bool) error {
+func runLoginWithContext(ctx context.Context, cc *CLIContext, useBrowser bool, access graph.LoginAccess) error {
 	logger := cc.Logger
-	logger.Info("login started", slog.Bool("browser", useBrowser))
+	logger.Info("login started", slog.Bool("browser", useBrowser), slog.Int("access", int(access)))
 
 	tempPath := pendingTokenPath()
-	ts, err := authenticateLogin(ctx, cc, useBrowser, tempPath)
+	ts, err := authenticateLogin(ctx, cc, useBrowser, access, tempPath)
 	if err != nil {
 		cleanupPendingToken(cc, tempPath, "login failure")
 		return fmt.Errorf("authenticate account: %w", err)
@@ -35,6 +35,12 @@ func runLoginWithContext(ctx context.Context, cc *CLIContext, useBrowser bool) e
 		return reconcileErr
 	}
 
+	loginAccess, err := resolveLoginAccess(ctx, ts, logger, cc.runtime(), access, canonicalID, primaryDriveID)
+	if err != nil {
+		cleanupPendingToken(cc, tempPath, "access resolution failure")
+		return err
+	}
+
 	finalTokenPath := config.DriveTokenPath(canonicalID)
 	if finalTokenPath == "" {
 		cleanupPendingToken(cc, tempPath, "path resolution failure")
@@ -51,7 +57,7 @@ func runLoginWithContext(ctx context.Context, cc *CLIContext, useBrowser bool) e
 		return moveErr
 	}
 
-	persistLoginMetadata(canonicalID, user, orgName, primaryDriveID, logger)
+	persistLoginMetadata(canonicalID, user, orgName, primaryDriveID, loginAccess, logger)
 
 	email := canonicalID.Email()
 	syncDir, added, err := config.EnsureDriveInConfig(cc.CfgPath, canonicalID, logger)
@@ -78,6 +84,9 @@ func runLoginWithContext(ctx context.Context, cc *CLIContext, useBrowser bool) e
 		if err := writef(cc.Output(), "Token refreshed for %s.\n", email); err != nil {
 			return err
 		}
+		if err := printLoginAccess(cc.Output(), loginAccess.Scope); err != nil {
+			return err
+		}
 		notifyDaemonIfRunning(ctx, cc)
 		return nil
 	}
@@ -85,6 +94,9 @@ func runLoginWithContext(ctx context.Context, cc *CLIContext, useBrowser bool) e
 	if err := printLoginSuccess(cc.Output(), canonicalID.DriveType(), email, orgName, canonicalID.String(), syncDir); err != nil {
 		return err
 	}
+	if err := printLoginAccess(cc.Output(), loginAccess.Scope); err != nil {
+		return err
+	}
 	notifyDaemonIfRunning(ctx, cc)
 	return nil
 }
@@ -94,6 +106,7 @@ func persistLoginMetadata(
 	user *graph.User,
 	orgName string,
 	primaryDriveID driveid.ID,
+	access config.LoginAccess,
 	logger *slog.Logger,
 ) {
 	if catalogErr := config.RecordLogin(
@@ -103,6 +116,7 @@ func persistLoginMetadata(
 		user.DisplayName,
 		orgName,
 		primaryDriveID,
+		access,
 	); catalogErr != nil {
 		logger.Warn("failed to update catalog after login", "error", catalogErr)
 	}
@@ -245,10 +259,11 @@ func authenticateLogin(
 	ctx context.Context,
 	cc *CLIContext,
 	useBrowser bool,
+	access graph.LoginAccess,
 	tempPath string,
 ) (graph.TokenSource, error) {
 	if useBrowser {
-		ts, err := graph.LoginWithBrowser(ctx, tempPath, func(openCtx context.Context, authURL string) error {
+		ts, err := graph.LoginWithBrowser(ctx, tempPath, access, func(openCtx context.Context, authURL string) error {
 			openErr := openBrowser(openCtx, authURL)
 			if openErr != nil {
 				writeWarningf(cc.Status(), "Open this URL in your browser:\n%s\n", authURL)
@@ -263,7 +278,7 @@ func authenticateLogin(
 		return ts, nil
 	}
 
-	ts, err := graph.Login(ctx, tempPath, func(da graph.DeviceAuth) {
+	ts, err := graph.Login(ctx, tempPath, access, func(da graph.DeviceAuth) {
 		writeWarningf(cc.Status(), "To sign in, visit: %s\n", da.VerificationURI)
 		writeWarningf(cc.Status(), "Enter code: %s\n", da.UserCode)
 	}, cc.Logger)
@@ -249,6 +249,73 @@ func discoverAccount(
 	return cid, user, orgName, primaryDriveID, nil
 }
 
+// resolveLoginAccess maps the access a login asked for to what the catalog
+// records. --app-folder looks up the primary drive's app folder, which then
+// becomes the drive root.
+func resolveLoginAccess(
+	ctx context.Context,
+	ts graph.TokenSource,
+	logger *slog.Logger,
+	runtime *driveops.SessionRuntime,
+	access graph.LoginAccess,
+	canonicalID driveid.CanonicalID,
+	primaryDriveID driveid.ID,
+) (config.LoginAccess, error) {
+	var result config.LoginAccess
+
+	switch access {
+	case graph.LoginAccessReadOnly:
+		result.Scope = config.LoginScopeReadOnly
+	case graph.LoginAccessAppFolder:
+		client, err := newGraphClientWithHTTP(runtime.GraphBaseURL, runtime.BootstrapMeta(), ts, logger)
+		if err != nil {
+			return config.LoginAccess{}, err
+		}
+
+		appRoot, err := client.AppRoot(ctx, primaryDriveID)
+		if err != nil {
+			return config.LoginAccess{}, fmt.Errorf("fetching app folder: %w", err)
+		}
+
+		logger.Info("discovered app folder", "item_id", appRoot.ID, "name", appRoot.Name)
+		result = config.LoginAccess{Scope: config.LoginScopeAppFolder, RootItemID: appRoot.ID}
+	case graph.LoginAccessFull:
+	}
+
+	if err := checkLoginRootChange(canonicalID, result.RootItemID); err != nil {
+		return config.LoginAccess{}, err
+	}
+
+	return result, nil
+}
+
+// checkLoginRootChange refuses to move a drive between its whole-drive root
+// and its app folder while sync state exists: the old baseline would read as
+// every file deleted remotely.
+func checkLoginRootChange(canonicalID driveid.CanonicalID, rootItemID string) error {
+	catalog, err := config.LoadCatalog()
+	if err != nil {
+		return fmt.Errorf("loading catalog: %w", err)
+	}
+
+	var previous string
+	if drive, found := catalog.DriveByCanonicalID(canonicalID); found {
+		previous = drive.RootItemID
+	}
+
+	if previous == rootItemID {
+		return nil
+	}
+
+	statePath := config.DriveStatePath(canonicalID)
+	if statePath == "" || !managedPathExists(statePath) {
+		return nil
+	}
+
+	return fmt.Errorf("drive %s has sync state for a different root; "+
+		"run 'onedrive-go logout --purge' before changing between full and app folder access", canonicalID)
+}
+
 // moveToken renames the pending token file to its final canonical path.
 // Creates the destination directory if needed.
 func moveToken(src, dst string) error {
@@ -274,6 +341,18 @@ func moveToken(src, dst string) error {
 	return nil
 }
 
+// printLoginAccess notes a restricted login after the login output.
+func printLoginAccess(w io.Writer, scope string) error {
+	switch scope {
+	case config.LoginScopeReadOnly:
+		return writeln(w, "Read-only access: commands that change the drive are refused and sync runs download-only.")
+	case config.LoginScopeAppFolder:
+		return writeln(w, "App folder access: the drive is rooted at its app folder.")
+	default:
+		return nil
+	}
+}
+
 // printLoginSuccess prints the user-facing login output. Format differs
 // for personal vs. business accounts per accounts.md section 9.
 func printLoginSuccess(w io.Writer, driveType, email, orgName, canonicalID, syncDir string) error {
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/pkg/quickxorhash"
+)
+
+const catTestContent = "first line\nsecond line\nthird line\n"
+
+func newCatTestContext(t *testing.T, content, hash string, stdout, stderr *bytes.Buffer, ranges *[]string) *CLIContext {
+	t.Helper()
+
+	return newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/dl" {
+				*ranges = append(*ranges, r.Header.Get("Range"))
+
+				var start, end int
+				if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end); err == nil {
+					w.WriteHeader(http.StatusPartialContent)
+					writeTestResponse(t, w, content[start:end+1])
+					return
+				}
+
+				writeTestResponse(t, w, content)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			writeTestResponsef(t, w, `{"id":"log","name":"log.txt","size":%d,
+				"file":{"hashes":{"quickXorHash":%q}},"parentReference":{"id":"root"},
+				"@microsoft.graph.downloadUrl":"http://%s/dl"}`, len(content), hash, r.Host)
+		}),
+		stdout,
+		stderr,
+	)
+}
+
+func catTestHash(content string) string {
+	h := quickxorhash.New()
+	_, _ = h.Write([]byte(content))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// Validates: R-1.15
+func TestRunCat_StreamsWholeFileAndVerifiesHash(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var ranges []string
+	cc := newCatTestContext(t, catTestContent, catTestHash(catTestContent), &stdout, &stderr, &ranges)
+
+	cmd := newCatCmd()
+	cmd.SetArgs([]string{"/log.txt"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, catTestContent, stdout.String())
+	assert.Equal(t, []string{""}, ranges)
+}
+
+// Validates: R-1.15.2
+func TestRunCat_FailsOnHashMismatch(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var ranges []string
+	cc := newCatTestContext(t, catTestContent, catTestHash("something else"), &stdout, &stderr, &ranges)
+
+	cmd := newCatCmd()
+	cmd.SetArgs([]string{"/log.txt"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "content hash mismatch")
+}
+
+// Validates: R-1.15.1
+func TestRunCat_RangeAndTail(t *testing.T) {
+	tests := []struct {
+		args      []string
+		wantRange string
+		want      string
+	}{
+		{[]string{"--range", "6-9"}, "bytes=6-9", "line"},
+		{[]string{"--range", "11-1000"}, "bytes=11-33", "second line\nthird line\n"},
+		{[]string{"--range", "23-"}, "bytes=23-", "third line\n"},
+		{[]string{"--tail", "11"}, "bytes=23-33", "third line\n"},
+		{[]string{"--range", "-11"}, "bytes=23-33", "third line\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			var ranges []string
+			cc := newCatTestContext(t, catTestContent, "bogus", &stdout, &stderr, &ranges)
+
+			cmd := newCatCmd()
+			cmd.SetArgs(append([]string{"/log.txt"}, tc.args...))
+			cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+			require.NoError(t, cmd.Execute())
+			assert.Equal(t, tc.want, stdout.String())
+			assert.Equal(t, []string{tc.wantRange}, ranges)
+		})
+	}
+}
+
+func TestParseCatRange_RejectsInvalidSpecs(t *testing.T) {
+	for _, spec := range []string{"abc", "5", "9-3", "-0", "x-4", "100-"} {
+		_, _, err := parseCatRange(spec, 50)
+		assert.Error(t, err, spec)
+	}
+
+	start, end, err := parseCatRange("10-", 50)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), start)
+	assert.Equal(t, int64(-1), end)
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// newCheckoutTestContext serves /Docs/plan.docx, checked out by Dana when
+// heldByDana is set, and records every checkout action POST with its body.
+func newCheckoutTestContext(t *testing.T, heldByDana bool, posts *[]string, stdout, stderr *bytes.Buffer) *CLIContext {
+	t.Helper()
+
+	publication := `{"level":"published"}`
+	if heldByDana {
+		publication = `{"level":"checkout","checkedOutBy":{"user":{"displayName":"Dana Lee"}}}`
+	}
+
+	return newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("business:user@contoso.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/drives/0000000drive-123/root:/Docs/plan.docx:":
+				writeTestResponse(t, w, `{"id":"plan","name":"plan.docx","file":{},
+					"createdDateTime":"2026-01-01T00:00:00Z","lastModifiedDateTime":"2026-01-02T00:00:00Z",
+					"publication":`+publication+`}`)
+			case r.Method == http.MethodPost:
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				*posts = append(*posts, r.URL.Path+" "+string(body))
+
+				if heldByDana {
+					w.WriteHeader(http.StatusLocked)
+					writeTestResponse(t, w, `{"error":{"code":"resourceLocked"}}`)
+
+					return
+				}
+
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				assert.Failf(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusNotImplemented)
+			}
+		}),
+		stdout,
+		stderr,
+	)
+}
+
+// Validates: R-1.22.1
+func TestCheckoutCommands_PostActions(t *testing.T) {
+	var posts []string
+	var stdout, stderr bytes.Buffer
+	cc := newCheckoutTestContext(t, false, &posts, &stdout, &stderr)
+
+	run := func(cmd *cobra.Command, args ...string) {
+		t.Helper()
+
+		cmd.SetArgs(args)
+		cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+		require.NoError(t, cmd.Execute())
+	}
+
+	run(newCheckoutCmd(), "/Docs/plan.docx")
+	run(newCheckinCmd(), "/Docs/plan.docx", "--comment", "Q3 figures")
+	run(newDiscardCheckoutCmd(), "/Docs/plan.docx")
+
+	assert.Equal(t, []string{
+		"/drives/0000000drive-123/items/plan/checkout ",
+		`/drives/0000000drive-123/items/plan/checkin {"comment":"Q3 figures"}`,
+		"/drives/0000000drive-123/items/plan/discardCheckout ",
+	}, posts)
+	assert.Equal(t, "Checked out /Docs/plan.docx\nChecked in /Docs/plan.docx\nDiscarded checkout of /Docs/plan.docx\n",
+		stderr.String())
+
+	cc.Flags.JSON = true
+	run(newCheckoutCmd(), "/Docs/plan.docx")
+
+	var out checkoutJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, checkoutJSONOutput{Path: "/Docs/plan.docx", ID: "plan", Action: checkoutActionCheckout}, out)
+}
+
+// Validates: R-1.22.1, R-1.22.2
+func TestCheckoutCommand_HeldByAnotherUserNamesHolder(t *testing.T) {
+	var posts []string
+	var stdout, stderr bytes.Buffer
+	cc := newCheckoutTestContext(t, true, &posts, &stdout, &stderr)
+
+	cmd := newCheckoutCmd()
+	cmd.SetArgs([]string{"/Docs/plan.docx"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	err := cmd.Execute()
+	require.ErrorIs(t, err, graph.ErrLocked)
+	assert.Contains(t, err.Error(), `"/Docs/plan.docx" is checked out by Dana Lee`)
+}
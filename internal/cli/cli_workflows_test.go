@@ -3,6 +3,7 @@ package cli
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -41,6 +42,8 @@ type mockRecycleBinSession struct {
 	listErr            error
 	restoreItem        *graph.Item
 	restoreErr         error
+	restoreResults     []graph.BatchItemResult
+	restoredIDs        []string
 	permanentDeleteErr error
 	deleteErr          error
 	deletedIDs         []string
@@ -54,6 +57,11 @@ func (m *mockRecycleBinSession) RestoreItem(context.Context, string) (*graph.Ite
 	return m.restoreItem, m.restoreErr
 }
 
+func (m *mockRecycleBinSession) RestoreItemsBatch(_ context.Context, itemIDs []string) ([]graph.BatchItemResult, error) {
+	m.restoredIDs = append(m.restoredIDs, itemIDs...)
+	return m.restoreResults, nil
+}
+
 func (m *mockRecycleBinSession) PermanentDeleteItem(_ context.Context, itemID string) error {
 	if m.permanentDeleteErr == nil {
 		m.deletedIDs = append(m.deletedIDs, itemID)
@@ -371,6 +379,33 @@ func TestRecycleBinList_PersonalAccountMessage(t *testing.T) {
 	assert.Contains(t, err.Error(), "Personal OneDrive accounts")
 }
 
+// Validates: R-1.9.5
+func TestRecycleBinRestore_SeveralIDsReportEachOutcome(t *testing.T) {
+	setTestDriveHome(t)
+
+	var out bytes.Buffer
+	mockSession := &mockRecycleBinSession{
+		restoreResults: []graph.BatchItemResult{
+			{Item: &graph.Item{ID: "item-1", Name: "a.txt"}},
+			{Err: fmt.Errorf("restore item %q: %w", "item-2", graph.ErrConflict)},
+			{Item: &graph.Item{ID: "item-3", Name: "c.txt"}},
+		},
+	}
+
+	cc := newCommandContext(&out, filepath.Join(t.TempDir(), "config.toml"))
+	sessionFactory := func(context.Context) (recycleBinSession, error) {
+		return mockSession, nil
+	}
+
+	err := runRecycleBinRestoreWithFactory(t.Context(), cc, []string{"item-1", "item-2", "item-3"}, sessionFactory)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 3 restores failed")
+	assert.Contains(t, err.Error(), `cannot restore "item-2"`)
+	assert.Equal(t, []string{"item-1", "item-2", "item-3"}, mockSession.restoredIDs, "all IDs go out in one batch call")
+	assert.Contains(t, out.String(), `Restored "a.txt"`)
+	assert.Contains(t, out.String(), `Restored "c.txt"`)
+}
+
 // Validates: R-1.9
 func TestRecycleBinEmpty_FallsBackToDelete(t *testing.T) {
 	setTestDriveHome(t)
@@ -0,0 +1,333 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/config"
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/fsroot"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+	"github.com/tonimelisma/onedrive-go/internal/sharedref"
+)
+
+// Completion runs on every Tab press, so remote lookups get a short budget
+// and answers are cached on disk: folder listings briefly, shared-item
+// discovery (one search per account) for longer.
+const (
+	completionTimeout         = 3 * time.Second
+	completionListingTTL      = 30 * time.Second
+	completionSharedTTL       = 5 * time.Minute
+	completionCacheMaxEntries = 64
+	completionCacheName       = "completion-cache.json"
+	completionCacheVersion    = 1
+)
+
+// noSharedArg marks a command that takes no shared: selector.
+const noSharedArg = -1
+
+// The cache lists remote names, so it is owner-only like the other
+// per-user files.
+const (
+	completionCacheFilePerms = 0o600
+	completionCacheDirPerms  = 0o700
+)
+
+// remoteCompletion describes which positional arguments of a command are
+// remote paths.
+type remoteCompletion struct {
+	localArgs     int  // leading arguments that are local paths (put)
+	maxArgs       int  // 0 means any number
+	localFallback bool // arguments after the first may be local (get)
+	sharedArg     int  // argument that accepts a shared: selector, or -1
+}
+
+// completeRemotePaths returns a cobra ValidArgsFunction that completes remote
+// paths of the command's drive. Completion never fails visibly: a drive that
+// cannot be resolved or a listing that times out simply offers nothing.
+func completeRemotePaths(rc remoteCompletion) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		pos := len(args)
+		if pos < rc.localArgs {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		if rc.maxArgs > 0 && pos >= rc.maxArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		directive := cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+		if rc.localFallback && pos > 0 {
+			directive = cobra.ShellCompDirectiveNoSpace
+		}
+
+		cc := completionCLIContext(cmd)
+		if cc == nil {
+			return nil, directive
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+		defer cancel()
+
+		if pos == rc.sharedArg {
+			if strings.HasPrefix(toComplete, sharedref.Prefix) {
+				return completeSharedSelectors(ctx, cc, toComplete), directive
+			}
+
+			if toComplete != "" && strings.HasPrefix(sharedref.Prefix, toComplete) {
+				return append(completeRemoteChildren(ctx, cc, toComplete), sharedref.Prefix), directive
+			}
+		}
+
+		return completeRemoteChildren(ctx, cc, toComplete), directive
+	}
+}
+
+// completionCLIContext returns the command's CLIContext. Cobra does not run
+// PersistentPreRunE before a completion function, so the same initialization
+// is run here; nil means the drive could not be resolved.
+func completionCLIContext(cmd *cobra.Command) *CLIContext {
+	if cc := cliContextFrom(cmd.Context()); cc != nil {
+		return cc
+	}
+
+	root := cmd.Root()
+	if root.PersistentPreRunE == nil {
+		return nil
+	}
+
+	if err := root.PersistentPreRunE(cmd, nil); err != nil {
+		cobra.CompDebugln(fmt.Sprintf("completion: %v", err), false)
+	}
+
+	return cliContextFrom(cmd.Context())
+}
+
+// completeRemoteChildren lists the folder named by everything up to the last
+// "/" of toComplete and offers the children that start with the rest.
+// Folders end in "/" so completion can continue into them.
+func completeRemoteChildren(ctx context.Context, cc *CLIContext, toComplete string) []string {
+	if cc.Cfg == nil {
+		return nil
+	}
+
+	dir, prefix := splitCompletionWord(toComplete)
+
+	parent := driveops.CleanRemotePath(dir)
+	key := cc.Cfg.CanonicalID.String() + "\x00" + parent
+
+	names, ok := loadCompletionCache().lookup(key, completionListingTTL, time.Now())
+	if !ok {
+		session, err := cc.sessionForDrive(ctx, cc.Cfg)
+		if err != nil {
+			cobra.CompDebugln(fmt.Sprintf("completion: %v", err), false)
+			return nil
+		}
+
+		items, err := session.ListChildren(ctx, parent)
+		if err != nil {
+			cobra.CompDebugln(fmt.Sprintf("completion: listing %q: %v", parent, err), false)
+			return nil
+		}
+
+		names = completionNames(items)
+		storeCompletionCache(key, names, time.Now())
+	}
+
+	return matchCompletionNames(dir, prefix, names)
+}
+
+// splitCompletionWord splits a partly typed path into the folder part,
+// up to and including the last "/", and the name prefix after it.
+func splitCompletionWord(word string) (dir, prefix string) {
+	if i := strings.LastIndex(word, "/"); i >= 0 {
+		return word[:i+1], word[i+1:]
+	}
+
+	return "", word
+}
+
+// completionNames returns the names of a folder listing as completion
+// offers them, with folders ending in "/".
+func completionNames(items []graph.Item) []string {
+	names := make([]string, 0, len(items))
+	for i := range items {
+		name := items[i].Name
+		if items[i].IsFolder {
+			name += "/"
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// matchCompletionNames returns dir+name for every name starting with prefix.
+func matchCompletionNames(dir, prefix string, names []string) []string {
+	var comps []string
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			comps = append(comps, dir+name)
+		}
+	}
+
+	return comps
+}
+
+// completeSharedSelectors offers the shared: selectors of discovered shared
+// items, described by the item name.
+func completeSharedSelectors(ctx context.Context, cc *CLIContext, toComplete string) []string {
+	key := sharedref.Prefix + "\x00" + cc.Flags.Account
+
+	entries, ok := loadCompletionCache().lookup(key, completionSharedTTL, time.Now())
+	if !ok {
+		snapshot, err := loadAccountViewSnapshotWithBestEffortIdentityRefresh(ctx, cc)
+		if err != nil {
+			cobra.CompDebugln(fmt.Sprintf("completion: %v", err), false)
+			return nil
+		}
+
+		discovery := discoverSharedTargets(ctx, cc, filterAccountViews(snapshot.Accounts, cc.Flags.Account))
+		entries = make([]string, 0, len(discovery.Targets))
+		for i := range discovery.Targets {
+			entries = append(entries, cobra.CompletionWithDesc(discovery.Targets[i].Selector, discovery.Targets[i].Name))
+		}
+
+		storeCompletionCache(key, entries, time.Now())
+	}
+
+	var comps []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry, toComplete) {
+			comps = append(comps, entry)
+		}
+	}
+
+	return comps
+}
+
+// completeDriveSelectors completes --drive from the catalog: every canonical
+// ID, described by its display name, and every display name, described by
+// its canonical ID.
+func completeDriveSelectors(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	catalog, err := config.LoadCatalog()
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("completion: %v", err), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var comps []string
+	for _, key := range catalog.SortedDriveKeys() {
+		drive := catalog.Drives[key]
+
+		if strings.HasPrefix(drive.CanonicalID, toComplete) {
+			comps = append(comps, cobra.CompletionWithDesc(drive.CanonicalID, drive.DisplayName))
+		}
+
+		if drive.DisplayName != "" && strings.HasPrefix(drive.DisplayName, toComplete) {
+			comps = append(comps, cobra.CompletionWithDesc(drive.DisplayName, drive.CanonicalID))
+		}
+	}
+
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionCache is the on-disk cache of completion answers, keyed by drive
+// and folder (or by account for shared items).
+type completionCache struct {
+	Version int                             `json:"version"`
+	Entries map[string]completionCacheEntry `json:"entries"`
+}
+
+type completionCacheEntry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Values    []string  `json:"values"`
+}
+
+// loadCompletionCache reads the cache, or returns an empty one when it is
+// missing, unreadable or from another version: a lost cache only costs a
+// lookup.
+func loadCompletionCache() *completionCache {
+	cache := &completionCache{Version: completionCacheVersion, Entries: map[string]completionCacheEntry{}}
+
+	root, err := fsroot.Open(config.DefaultCacheDir())
+	if err != nil {
+		return cache
+	}
+
+	data, err := root.ReadFile(completionCacheName)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			cobra.CompDebugln(fmt.Sprintf("completion: reading cache: %v", err), false)
+		}
+
+		return cache
+	}
+
+	var saved completionCache
+	if json.Unmarshal(data, &saved) == nil && saved.Version == completionCacheVersion && saved.Entries != nil {
+		cache.Entries = saved.Entries
+	}
+
+	return cache
+}
+
+// lookup returns the values cached under key when they are younger than ttl.
+func (c *completionCache) lookup(key string, ttl time.Duration, now time.Time) ([]string, bool) {
+	entry, ok := c.Entries[key]
+	if !ok || now.Sub(entry.FetchedAt) > ttl {
+		return nil, false
+	}
+
+	return entry.Values, true
+}
+
+// storeCompletionCache saves values under key, dropping the oldest entries
+// beyond completionCacheMaxEntries. Failures are only logged to the
+// completion debug file.
+func storeCompletionCache(key string, values []string, now time.Time) {
+	cache := loadCompletionCache()
+	cache.Entries[key] = completionCacheEntry{FetchedAt: now, Values: values}
+
+	if excess := len(cache.Entries) - completionCacheMaxEntries; excess > 0 {
+		keys := make([]string, 0, len(cache.Entries))
+		for k := range cache.Entries {
+			keys = append(keys, k)
+		}
+
+		slices.SortFunc(keys, func(a, b string) int {
+			return cache.Entries[a].FetchedAt.Compare(cache.Entries[b].FetchedAt)
+		})
+
+		for _, k := range keys[:excess] {
+			delete(cache.Entries, k)
+		}
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("completion: encoding cache: %v", err), false)
+		return
+	}
+
+	root, err := fsroot.Open(config.DefaultCacheDir())
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("completion: opening cache root: %v", err), false)
+		return
+	}
+
+	if err := root.AtomicWrite(
+		completionCacheName, data, completionCacheFilePerms, completionCacheDirPerms, ".completion-*.tmp",
+	); err != nil {
+		cobra.CompDebugln(fmt.Sprintf("completion: writing cache: %v", err), false)
+	}
+}
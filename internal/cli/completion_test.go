@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/config"
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/sharedref"
+)
+
+// Validates: R-1.25.1
+func TestCompleteRemotePaths_ListsChildrenAndCachesListing(t *testing.T) {
+	var listings atomic.Int32
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/drives/0000000drive-123/root:/Docs:/children", r.URL.Path)
+			listings.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			writeTestResponse(t, w, `{"value":[
+				{"id":"r","name":"Reports","folder":{"childCount":1}},
+				{"id":"d","name":"Report.docx","size":3,"file":{}},
+				{"id":"n","name":"notes.txt","size":3,"file":{}}
+			]}`)
+		}), &stdout, &stderr)
+
+	cmd := newRmCmd()
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	for range 2 {
+		comps, directive := cmd.ValidArgsFunction(cmd, nil, "/Docs/Rep")
+		assert.Equal(t, []string{"/Docs/Reports/", "/Docs/Report.docx"}, comps)
+		assert.Equal(t, cobra.ShellCompDirectiveNoSpace|cobra.ShellCompDirectiveNoFileComp, directive)
+	}
+
+	assert.Equal(t, int32(1), listings.Load(), "the second completion is served from the cache")
+}
+
+// Validates: R-1.25.1
+func TestCompleteRemotePaths_LocalArgumentsUseFileCompletion(t *testing.T) {
+	put := newPutCmd()
+	comps, directive := put.ValidArgsFunction(put, nil, "")
+	assert.Empty(t, comps)
+	assert.Equal(t, cobra.ShellCompDirectiveDefault, directive)
+
+	stat := newStatCmd()
+	comps, directive = stat.ValidArgsFunction(stat, []string{"/a"}, "")
+	assert.Empty(t, comps)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+// Validates: R-1.25.3
+func TestCompleteRemotePaths_SharedSelectorsFromCache(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			assert.Fail(t, "cached shared targets must not be rediscovered")
+		}), &stdout, &stderr)
+
+	storeCompletionCache(sharedref.Prefix+"\x00", []string{
+		cobra.CompletionWithDesc("shared:user@example.com:b!drive:item-1", "Budget.xlsx"),
+		cobra.CompletionWithDesc("shared:other@example.com:b!drive:item-2", "Plans"),
+	}, time.Now())
+
+	cmd := newGetCmd()
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	comps, _ := cmd.ValidArgsFunction(cmd, nil, "shared:user")
+	assert.Equal(t, []string{cobra.CompletionWithDesc("shared:user@example.com:b!drive:item-1", "Budget.xlsx")}, comps)
+}
+
+// Validates: R-1.25.2
+func TestCompleteDriveSelectors_FromCatalog(t *testing.T) {
+	setTestDriveHome(t)
+
+	require.NoError(t, config.UpdateCatalog(func(catalog *config.Catalog) error {
+		catalog.UpsertDrive(&config.CatalogDrive{
+			CanonicalID: "personal:user@example.com", DriveType: "personal", DisplayName: "Home",
+		})
+		catalog.UpsertDrive(&config.CatalogDrive{
+			CanonicalID: "business:work@example.com", DriveType: "business", DisplayName: "Work",
+		})
+		return nil
+	}))
+
+	comps, directive := completeDriveSelectors(nil, nil, "")
+	assert.Equal(t, []string{
+		cobra.CompletionWithDesc("business:work@example.com", "Work"),
+		cobra.CompletionWithDesc("Work", "business:work@example.com"),
+		cobra.CompletionWithDesc("personal:user@example.com", "Home"),
+		cobra.CompletionWithDesc("Home", "personal:user@example.com"),
+	}, comps)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	comps, _ = completeDriveSelectors(nil, nil, "Ho")
+	assert.Equal(t, []string{cobra.CompletionWithDesc("Home", "personal:user@example.com")}, comps)
+}
+
+// Validates: R-1.25.1
+func TestMainWithWriters_CompletionWorksWithoutConfiguredDrive(t *testing.T) {
+	setTestDriveHome(t)
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 0, mainWithWriters([]string{"completion", "bash"}, &stdout, &stderr), stderr.String())
+	assert.Contains(t, stdout.String(), "bash completion")
+
+	stdout.Reset()
+	require.Equal(t, 0, mainWithWriters([]string{cobra.ShellCompRequestCmd, "ls", ""}, &stdout, &stderr), stderr.String())
+	assert.Equal(t, fmt.Sprintf(":%d\n", cobra.ShellCompDirectiveNoSpace|cobra.ShellCompDirectiveNoFileComp), stdout.String())
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// conflictPolicy is the --on-conflict choice for put, mv and cp: what to do
+// when the destination name is already taken.
+type conflictPolicy string
+
+const (
+	conflictFail    conflictPolicy = "fail"
+	conflictReplace conflictPolicy = "replace"
+	conflictRename  conflictPolicy = "rename"
+	conflictSkip    conflictPolicy = "skip"
+)
+
+const onConflictFlag = "on-conflict"
+
+func addConflictFlag(cmd *cobra.Command, def conflictPolicy) {
+	cmd.Flags().String(onConflictFlag, string(def),
+		"when the destination name is taken: fail, replace, rename (the service picks \"name 1.ext\"), or skip")
+}
+
+// readConflictPolicy reads --on-conflict. On commands that also have --force,
+// --force is shorthand for --on-conflict=replace and may not contradict an
+// explicit --on-conflict.
+func readConflictPolicy(cmd *cobra.Command) (conflictPolicy, error) {
+	raw, err := cmd.Flags().GetString(onConflictFlag)
+	if err != nil {
+		return "", fmt.Errorf("reading --%s flag: %w", onConflictFlag, err)
+	}
+
+	policy := conflictPolicy(strings.ToLower(raw))
+	switch policy {
+	case conflictFail, conflictReplace, conflictRename, conflictSkip:
+	default:
+		return "", fmt.Errorf("invalid --%s %q: want fail, replace, rename, or skip", onConflictFlag, raw)
+	}
+
+	if cmd.Flags().Lookup("force") == nil {
+		return policy, nil
+	}
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return "", fmt.Errorf("reading --force flag: %w", err)
+	}
+
+	if !force {
+		return policy, nil
+	}
+
+	if cmd.Flags().Changed(onConflictFlag) && policy != conflictReplace {
+		return "", fmt.Errorf("--force conflicts with --%s=%s", onConflictFlag, policy)
+	}
+
+	return conflictReplace, nil
+}
+
+// behavior is the Graph conflict behavior that carries out the policy. Skip
+// asks the service to fail so a name taken after any local check is still
+// left alone rather than overwritten.
+func (p conflictPolicy) behavior() graph.ConflictBehavior {
+	if p == conflictSkip {
+		return graph.ConflictFail
+	}
+
+	return graph.ConflictBehavior(p)
+}
+
+// skipsConflict reports whether err is the service refusing a taken name
+// under --on-conflict=skip.
+func (p conflictPolicy) skipsConflict(err error) bool {
+	return p == conflictSkip && errors.Is(err, graph.ErrConflict)
+}
+
+// renamedPath returns remotePath with its last element replaced by the name
+// the service actually used, leaving remotePath as given when nothing changed.
+func renamedPath(remotePath, finalName string) string {
+	parent, name := driveops.SplitParentAndName(remotePath)
+	if finalName == "" || finalName == name {
+		return remotePath
+	}
+
+	return joinRemotePath(parent, finalName)
+}
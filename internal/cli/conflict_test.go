@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+const conflictQueryKey = "@microsoft.graph.conflictBehavior"
+
+// Validates: R-1.3.10, R-1.7.3, R-1.8.2
+func TestReadConflictPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		newCmd  func() *cobra.Command
+		args    []string
+		want    conflictPolicy
+		wantErr string
+	}{
+		{name: "put defaults to replace", newCmd: newPutCmd, want: conflictReplace},
+		{name: "mv defaults to fail", newCmd: newMvCmd, want: conflictFail},
+		{name: "explicit rename", newCmd: newCpCmd, args: []string{"--on-conflict=rename"}, want: conflictRename},
+		{name: "case-insensitive", newCmd: newMvCmd, args: []string{"--on-conflict=SKIP"}, want: conflictSkip},
+		{name: "force means replace", newCmd: newCpCmd, args: []string{"--force"}, want: conflictReplace},
+		{name: "force agrees with replace", newCmd: newMvCmd, args: []string{"-f", "--on-conflict=replace"}, want: conflictReplace},
+		{name: "force contradicts skip", newCmd: newMvCmd, args: []string{"-f", "--on-conflict=skip"}, wantErr: "--force conflicts"},
+		{name: "unknown policy", newCmd: newPutCmd, args: []string{"--on-conflict=merge"}, wantErr: "want fail, replace"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := tt.newCmd()
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			got, err := readConflictPolicy(cmd)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenamedPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "/Docs/a.txt", renamedPath("/Docs/a.txt", "a.txt"), "unchanged names keep the path as given")
+	assert.Equal(t, "/Docs/a.txt", renamedPath("/Docs/a.txt", ""))
+	assert.Equal(t, "Docs/a 1.txt", renamedPath("/Docs/a.txt", "a 1.txt"))
+	assert.Equal(t, "a 1.txt", renamedPath("a.txt", "a 1.txt"))
+}
+
+// putConflictTestServer creates the Docs folder and answers every upload of
+// the named file with a conflict, and any other upload with a renamed item.
+type putConflictTestServer struct {
+	mu        sync.Mutex
+	conflicts string
+	behaviors []string
+}
+
+func (s *putConflictTestServer) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/children"):
+			w.WriteHeader(http.StatusCreated)
+			writeTestResponse(t, w, `{"id":"docs","name":"Docs","folder":{}}`)
+		case r.Method == http.MethodPut:
+			s.behaviors = append(s.behaviors, r.URL.Query().Get(conflictQueryKey))
+
+			if strings.Contains(r.URL.Path, s.conflicts) {
+				w.WriteHeader(http.StatusConflict)
+				writeTestResponse(t, w, `{"error":{"code":"nameAlreadyExists"}}`)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			writeTestResponse(t, w, `{"id":"new","name":"new 1.txt"}`)
+		case r.Method == http.MethodPatch:
+			writeTestResponse(t, w, `{"id":"new","name":"new 1.txt"}`)
+		default:
+			writeTestResponse(t, w, `{"id":"root","name":"root","folder":{},"root":{}}`)
+		}
+	}
+}
+
+// Validates: R-1.3.10
+func TestRunPut_OnConflictRenameReportsFinalName(t *testing.T) {
+	local := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(local, "new.txt"), []byte("new!"), 0o600))
+
+	srv := &putConflictTestServer{conflicts: "no-such-file"}
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), srv.handler(t), &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newPutCmd()
+	cmd.SetArgs([]string{"--on-conflict=rename", local, "/Docs"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, []string{"rename"}, srv.behaviors)
+
+	var out putFolderJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out.Files, 1)
+	assert.Equal(t, "Docs/new 1.txt", out.Files[0].Path)
+}
+
+// Validates: R-1.3.10
+func TestRunPut_OnConflictSkipLeavesTakenNames(t *testing.T) {
+	local := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(local, "taken.txt"), []byte("old?"), 0o600))
+
+	srv := &putConflictTestServer{conflicts: "taken.txt"}
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), srv.handler(t), &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newPutCmd()
+	cmd.SetArgs([]string{"--on-conflict=skip", local, "/Docs"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, []string{"fail"}, srv.behaviors, "skip asks the service to refuse a taken name")
+
+	var out putFolderJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Empty(t, out.Files)
+	assert.Empty(t, out.Errors)
+	assert.Equal(t, []skippedJSONOutput{{Path: "Docs/taken.txt", Reason: skipReasonExists}}, out.Skipped)
+}
+
+func TestRunPut_OnConflictRejectsTar(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"),
+		http.NotFoundHandler(), &stdout, &stderr)
+
+	cmd := newPutCmd()
+	cmd.SetArgs([]string{"--tar", "--on-conflict=skip", "-", "/Projects"})
+	cmd.SetIn(strings.NewReader(""))
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--on-conflict does not apply to --tar")
+}
+
+// mvConflictTestServer resolves a.txt as the source, Archive as a folder that
+// already holds a.txt, and records any mutation.
+type mvConflictTestServer struct {
+	mu        sync.Mutex
+	mutations []string
+}
+
+func (s *mvConflictTestServer) recorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.mutations...)
+}
+
+func (s *mvConflictTestServer) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method != http.MethodGet {
+			s.mu.Lock()
+			s.mutations = append(s.mutations, r.Method+" "+r.URL.Query().Get(conflictQueryKey))
+			s.mu.Unlock()
+			writeTestResponse(t, w, `{"id":"src","name":"a 1.txt","parentReference":{"id":"archive"}}`)
+
+			return
+		}
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "Archive/a 1.txt:"):
+			writeTestResponse(t, w, `{"id":"src","name":"a 1.txt","parentReference":{"id":"archive"}}`)
+		case strings.HasSuffix(r.URL.Path, "Archive/a.txt:"):
+			writeTestResponse(t, w, `{"id":"taken","name":"a.txt","parentReference":{"id":"archive"}}`)
+		case strings.HasSuffix(r.URL.Path, "Archive:"):
+			writeTestResponse(t, w, `{"id":"archive","name":"Archive","folder":{},"parentReference":{"id":"root"}}`)
+		case strings.HasSuffix(r.URL.Path, "a.txt:"):
+			writeTestResponse(t, w, `{"id":"src","name":"a.txt","parentReference":{"id":"root"}}`)
+		default:
+			writeTestResponse(t, w, `{"id":"root","name":"root","folder":{},"root":{}}`)
+		}
+	}
+}
+
+// Validates: R-1.7.3
+func TestRunMv_OnConflictSkipLeavesBothItems(t *testing.T) {
+	srv := &mvConflictTestServer{}
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"),
+		srv.handler(t), &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newMvCmd()
+	cmd.SetArgs([]string{"--on-conflict=skip", "/a.txt", "/Archive"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	assert.Empty(t, srv.recorded())
+
+	var out mvJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, mvJSONOutput{Source: "/a.txt", Destination: "Archive/a.txt", ID: "taken", Skipped: skipReasonExists}, out)
+}
+
+// Validates: R-1.7.3
+func TestRunMv_OnConflictRenameReportsFinalName(t *testing.T) {
+	srv := &mvConflictTestServer{}
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"),
+		srv.handler(t), &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newMvCmd()
+	cmd.SetArgs([]string{"--on-conflict=rename", "/a.txt", "/Archive"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, []string{"PATCH rename"}, srv.recorded())
+
+	var out mvJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, "Archive/a 1.txt", out.Destination)
+}
+
+// Validates: R-1.8.2
+func TestCopiedName_LooksUpRenamedCopy(t *testing.T) {
+	t.Parallel()
+
+	var lookups atomic.Int32
+
+	session := makeTestSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups.Add(1)
+		assert.True(t, strings.HasSuffix(r.URL.Path, "/items/copy-id"))
+		writeTestResponse(t, w, `{"id":"copy-id","name":"a 1.txt"}`)
+	}))
+
+	name, err := copiedName(t.Context(), session, "copy-id", "a.txt", conflictRename)
+	require.NoError(t, err)
+	assert.Equal(t, "a 1.txt", name)
+
+	name, err = copiedName(t.Context(), session, "copy-id", "a.txt", conflictFail)
+	require.NoError(t, err)
+	assert.Equal(t, "a.txt", name)
+	assert.Equal(t, int32(1), lookups.Load(), "only rename needs to look the copy up")
+}
@@ -2,9 +2,7 @@ package cli
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -14,18 +12,28 @@ import (
 )
 
 func newCpCmd() *cobra.Command {
-	var force bool
-
 	cmd := &cobra.Command{
-		Use:   "cp <source> <dest>",
-		Short: "Copy a file or folder (server-side)",
-		Args:  cobra.ExactArgs(2),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCp(cmd, args, force)
-		},
+		Use:   "cp <source>... <dest>",
+		Short: "Copy files and folders (server-side)",
+		Long: `Copy a file or folder on the server and wait for the copy to finish.
+
+--on-conflict decides what happens when the destination name is taken: fail
+(the default) reports an error, replace overwrites it (same as --force), skip
+leaves the existing item alone, and rename lets the service pick a free name
+such as "report 1.pdf". The reported destination is the name actually used.
+
+Several sources, or a glob in the last component of a source, copy into dest,
+which must then be an existing folder. Every source is resolved before the
+first copy starts, and --dry-run only lists the copies.`,
+		Args:              cobra.MinimumNArgs(2),
+		Annotations:       map[string]string{mutatesDriveAnnotation: mutatesDriveValue},
+		RunE:              runCp,
+		ValidArgsFunction: completeRemotePaths(remoteCompletion{sharedArg: noSharedArg}),
 	}
 
-	cmd.Flags().BoolVarP(&force, "force", "f", false, "overwrite existing file at destination")
+	cmd.Flags().BoolP("force", "f", false, "overwrite existing file at destination (same as --on-conflict=replace)")
+	cmd.Flags().Bool("dry-run", false, "show what would be copied without copying")
+	addConflictFlag(cmd, conflictFail)
 
 	return cmd
 }
@@ -35,6 +43,7 @@ type cpJSONOutput struct {
 	Source      string `json:"source"`
 	Destination string `json:"destination"`
 	ID          string `json:"id"`
+	Skipped     string `json:"skipped,omitempty"`
 }
 
 // copyPollInterval is the polling interval for async copy status.
@@ -52,85 +61,149 @@ func checkSelfCopy(sourceID string, dest destInfo) error {
 	return nil
 }
 
-func runCp(cmd *cobra.Command, args []string, force bool) error {
-	sourcePath := args[0]
-	destPath := args[1]
+func runCp(cmd *cobra.Command, args []string) error {
+	destPath := args[len(args)-1]
 	ctx := cmd.Context()
 	cc := mustCLIContext(ctx)
 
-	session, err := cc.Session(ctx)
+	onConflict, err := readConflictPolicy(cmd)
 	if err != nil {
 		return err
 	}
 
-	cc.Logger.Debug("cp", "source", sourcePath, "dest", destPath)
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("read --dry-run flag: %w", err)
+	}
 
-	sourceItem, err := session.ResolveItem(ctx, sourcePath)
+	session, err := cc.Session(ctx)
 	if err != nil {
-		return fmt.Errorf("resolving source %q: %w", sourcePath, err)
+		return err
 	}
 
-	dest, err := resolveDest(ctx, session, destPath, sourceItem.Name, force)
+	cc.Logger.Debug("cp", "sources", args[:len(args)-1], "dest", destPath, "on_conflict", onConflict, "dry_run", dryRun)
+
+	sources, err := resolveTransferSources(ctx, session, args[:len(args)-1], destPath)
 	if err != nil {
 		return err
 	}
 
-	// Bail early if copying a file over itself — prevents both data loss
-	// (delete then fail) and silent duplicates (API auto-rename).
-	if selfErr := checkSelfCopy(sourceItem.ID, dest); selfErr != nil {
-		return selfErr
+	if dryRun {
+		return planTransferSources(ctx, cc, session, sources, destPath, onConflict, "copy")
+	}
+
+	outs := make([]cpJSONOutput, 0, len(sources.items))
+
+	for i := range sources.items {
+		out, err := copyOne(ctx, cc, session, &sources.items[i], destPath, onConflict)
+		if err != nil {
+			return err
+		}
+
+		outs = append(outs, reportCopy(cc, out))
+	}
+
+	if cc.Flags.JSON {
+		return printSourcesJSON(cc.Output(), outs, sources.batch, "copy")
 	}
 
-	// If --force resolved to an existing file, delete it before copying.
-	// NOTE: This is a TOCTOU race — another client could recreate the file
-	// between delete and copy. Server-side copy has no atomic overwrite.
-	if dest.existingID != "" {
+	return nil
+}
+
+// copyOne copies one resolved source to destPath and waits for the copy.
+func copyOne(
+	ctx context.Context,
+	cc *CLIContext,
+	session *driveops.MountSession,
+	source *transferSource,
+	destPath string,
+	onConflict conflictPolicy,
+) (cpJSONOutput, error) {
+	sourcePath, sourceItem := source.path, source.item
+
+	dest, err := resolveDest(ctx, session, destPath, sourceItem.Name, onConflict)
+	if err != nil {
+		return cpJSONOutput{}, err
+	}
+
+	if onConflict == conflictSkip && dest.existingID != "" {
+		return cpJSONOutput{
+			Source:      sourcePath,
+			Destination: finalDestPath(destPath, dest, dest.newName),
+			ID:          dest.existingID,
+			Skipped:     skipReasonExists,
+		}, nil
+	}
+
+	if onConflict == conflictReplace && dest.existingID != "" {
+		// Bail early if copying a file over itself — prevents data loss
+		// (delete then fail).
+		if selfErr := checkSelfCopy(sourceItem.ID, dest); selfErr != nil {
+			return cpJSONOutput{}, selfErr
+		}
+
+		// If --force resolved to an existing file, delete it before copying.
+		// NOTE: This is a TOCTOU race — another client could recreate the file
+		// between delete and copy. Server-side copy has no atomic overwrite.
 		if delErr := session.DeleteResolvedPath(ctx, destPath, dest.existingID); delErr != nil {
-			return fmt.Errorf("deleting existing %q: %w", destPath, delErr)
+			return cpJSONOutput{}, fmt.Errorf("deleting existing %q: %w", destPath, delErr)
 		}
 	}
 
-	copyResult, err := session.CopyItem(ctx, sourceItem.ID, dest.parentID, dest.newName)
+	copyResult, err := session.CopyItem(ctx, sourceItem.ID, dest.parentID, dest.newName, onConflict.behavior())
 	if err != nil {
-		return fmt.Errorf("copying %q: %w", sourcePath, err)
+		return cpJSONOutput{}, fmt.Errorf("copying %q: %w", sourcePath, err)
 	}
 
 	resourceID, err := awaitCopy(ctx, cc, session.Meta, copyResult.MonitorURL)
 	if err != nil {
-		return err
+		return cpJSONOutput{}, err
 	}
 
-	displayDest := destPath
-	if dest.destIsDir {
-		clean := driveops.CleanRemotePath(destPath)
-		if clean != "" {
-			displayDest = clean + "/" + dest.newName
-		}
+	finalName, err := copiedName(ctx, session, resourceID, dest.newName, onConflict)
+	if err != nil {
+		return cpJSONOutput{}, err
 	}
 
-	if cc.Flags.JSON {
-		return printCpJSON(cc.Output(), cpJSONOutput{
-			Source:      sourcePath,
-			Destination: displayDest,
-			ID:          resourceID,
-		})
+	return cpJSONOutput{
+		Source:      sourcePath,
+		Destination: finalDestPath(destPath, dest, finalName),
+		ID:          resourceID,
+	}, nil
+}
+
+// copiedName returns the name the finished copy landed under. Only rename
+// can make it differ from the requested name, and the async copy monitor
+// reports just the new item's ID, so only rename pays for the extra lookup.
+func copiedName(
+	ctx context.Context, session *driveops.MountSession, resourceID, requested string, onConflict conflictPolicy,
+) (string, error) {
+	if onConflict != conflictRename || resourceID == "" {
+		return requested, nil
 	}
 
-	cc.Statusf("Copied %s → %s\n", sourcePath, displayDest)
+	item, err := session.Meta.GetItem(ctx, session.DriveID, resourceID)
+	if err != nil {
+		return "", fmt.Errorf("reading copied item %s: %w", resourceID, err)
+	}
 
-	return nil
+	return item.Name, nil
 }
 
-// printCpJSON writes the cp command's JSON output to w.
-func printCpJSON(w io.Writer, out cpJSONOutput) error {
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
+// reportCopy writes the status line for one finished or skipped copy and
+// returns its JSON record; the caller prints JSON once for all sources.
+func reportCopy(cc *CLIContext, out cpJSONOutput) cpJSONOutput {
+	if cc.Flags.JSON {
+		return out
+	}
 
-	if err := enc.Encode(out); err != nil {
-		return fmt.Errorf("encode copy output: %w", err)
+	if out.Skipped != "" {
+		cc.Statusf("Skipped %s (%s exists)\n", out.Source, out.Destination)
+	} else {
+		cc.Statusf("Copied %s → %s\n", out.Source, out.Destination)
 	}
 
-	return nil
+	return out
 }
 
 // awaitCopy polls the monitor URL until the copy completes, fails, or times out.
@@ -101,11 +101,11 @@ func TestPrintCpJSON(t *testing.T) {
 	t.Parallel()
 
 	var buf bytes.Buffer
-	err := printCpJSON(&buf, cpJSONOutput{
+	err := printSourcesJSON(&buf, []cpJSONOutput{{
 		Source:      "/docs/report.pdf",
 		Destination: "/backup/report.pdf",
 		ID:          "item-789",
-	})
+	}}, false, "copy")
 	require.NoError(t, err)
 
 	var decoded cpJSONOutput
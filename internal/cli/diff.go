@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/localpath"
+	syncengine "github.com/tonimelisma/onedrive-go/internal/sync"
+)
+
+func newDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <local-dir> <remote-path>",
+		Short: "Show how a local folder differs from a remote folder",
+		Long: `Compare a local folder with a remote folder without changing either side,
+and list every entry that exists only locally, only remotely, or whose
+content differs. A folder found on one side only is listed once, without
+its contents.
+
+Names are matched the way sync matches them: NFC-normalized and without
+regard to case. Local names that OneDrive would reject are listed as
+invalid, because sync skips them instead of uploading.
+
+Files of equal size are compared by modification time to the second.
+--checksum compares QuickXorHash instead, which is how sync decides whether
+a pair it has never synced already matches.`,
+		Args: cobra.ExactArgs(2),
+		RunE: runDiff,
+	}
+
+	cmd.Flags().Bool("checksum", false, "compare files of equal size by QuickXorHash instead of modification time")
+
+	return cmd
+}
+
+// Statuses of a diff entry.
+const (
+	diffOnlyLocal  = "only_local"
+	diffOnlyRemote = "only_remote"
+	diffDiffers    = "differs"
+	diffInvalid    = "invalid"
+)
+
+// Reasons a differs entry was reported.
+const (
+	diffReasonType   = "type"
+	diffReasonSize   = "size"
+	diffReasonMtime  = "mtime"
+	diffReasonHash   = "hash"
+	diffReasonNoHash = "no_hash"
+)
+
+// diffJSONOutput is the JSON output schema for the diff command.
+type diffJSONOutput struct {
+	Local     string          `json:"local"`
+	Remote    string          `json:"remote"`
+	Checksum  bool            `json:"checksum"`
+	Entries   []diffJSONEntry `json:"entries"`
+	Identical int             `json:"identical"`
+}
+
+// diffJSONEntry is one difference, with its path relative to both roots.
+type diffJSONEntry struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	checksum, err := cmd.Flags().GetBool("checksum")
+	if err != nil {
+		return fmt.Errorf("reading --checksum flag: %w", err)
+	}
+
+	localRoot := args[0]
+	remoteRoot := driveops.CleanRemotePath(args[1])
+
+	// A missing local folder would otherwise show every remote entry as
+	// only_remote.
+	info, err := localpath.Stat(localRoot)
+	if err != nil {
+		return fmt.Errorf("stating local folder: %w", err)
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a folder", localRoot)
+	}
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	cc.Logger.Debug("diff", "local", localRoot, "remote", remoteRoot, "checksum", checksum)
+
+	local, err := localMirrorTree(localRoot)
+	if err != nil {
+		return err
+	}
+
+	_, remoteEntries, err := session.EnumerateFolder(ctx, remoteRoot)
+	if err != nil {
+		return fmt.Errorf("enumerating %q: %w", "/"+remoteRoot, err)
+	}
+
+	entries, identical, err := diffTrees(local, remoteMirrorTree(remoteEntries), remoteRoot, checksum)
+	if err != nil {
+		return err
+	}
+
+	out := diffJSONOutput{
+		Local:     localRoot,
+		Remote:    "/" + remoteRoot,
+		Checksum:  checksum,
+		Entries:   entries,
+		Identical: identical,
+	}
+
+	if cc.Flags.JSON {
+		return printDiffJSON(cc.Output(), out)
+	}
+
+	if err := printDiffTable(cc.Output(), entries); err != nil {
+		return err
+	}
+
+	cc.Statusf("%d differences, %d identical files\n", len(entries), identical)
+
+	return nil
+}
+
+// diffTrees lists the differences between local and remote, sorted by path,
+// and counts the files that match. Nothing below a folder that is listed
+// (only on one side, invalid, or of the wrong type) is listed again.
+func diffTrees(local, remote mirrorTree, remoteRoot string, checksum bool) ([]diffJSONEntry, int, error) {
+	entries := make([]diffJSONEntry, 0)
+	listed := make(map[string]bool)
+	identical := 0
+
+	for _, key := range local.sortedKeys() {
+		if hasAncestorIn(listed, key) {
+			continue
+		}
+
+		l := local[key]
+
+		if detail := invalidLocalName(l.path, remoteRoot); detail != "" {
+			entries = append(entries, diffJSONEntry{Path: l.path, Status: diffInvalid, Detail: detail})
+			listed[key] = l.isDir
+
+			continue
+		}
+
+		r := remote[key]
+		if r == nil {
+			entries = append(entries, diffJSONEntry{Path: l.path, Status: diffOnlyLocal})
+			listed[key] = l.isDir
+
+			continue
+		}
+
+		reason, detail, err := compareDiffEntries(l, r, checksum)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		switch {
+		case reason != "":
+			entries = append(entries, diffJSONEntry{Path: l.path, Status: diffDiffers, Reason: reason, Detail: detail})
+			listed[key] = reason == diffReasonType
+		case !l.isDir:
+			identical++
+		}
+	}
+
+	for _, key := range remote.sortedKeys() {
+		if local[key] != nil || hasAncestorIn(listed, key) {
+			continue
+		}
+
+		r := remote[key]
+		entries = append(entries, diffJSONEntry{Path: r.path, Status: diffOnlyRemote})
+		listed[key] = r.isDir
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return mirrorKey(entries[i].Path) < mirrorKey(entries[j].Path)
+	})
+
+	return entries, identical, nil
+}
+
+// invalidLocalName returns why sync would skip the local entry at rel, or ""
+// when OneDrive accepts its name and full path.
+func invalidLocalName(rel, remoteRoot string) string {
+	if reason, detail := syncengine.ValidateOneDriveName(norm.NFC.String(path.Base(rel))); reason != "" {
+		return detail
+	}
+
+	if full := joinRemotePath(remoteRoot, rel); len(full) > syncengine.MaxOneDrivePathLength {
+		return fmt.Sprintf("path length %d exceeds %d-character limit", len(full), syncengine.MaxOneDrivePathLength)
+	}
+
+	return ""
+}
+
+// compareDiffEntries returns why l and r differ, or "" when they match.
+// Folders match folders. Files of different sizes always differ; equal sizes
+// are settled by modification time, or by QuickXorHash under --checksum.
+func compareDiffEntries(l, r *mirrorEntry, checksum bool) (reason, detail string, err error) {
+	switch {
+	case l.isDir != r.isDir:
+		return diffReasonType, fmt.Sprintf("%s locally, %s remotely", entryKind(l), entryKind(r)), nil
+	case l.isDir:
+		return "", "", nil
+	case l.side.size != r.side.size:
+		return diffReasonSize, fmt.Sprintf("%d bytes locally, %d bytes remotely", l.side.size, r.side.size), nil
+	case !checksum:
+		return compareDiffMtimes(l.side.mtime, r.side.mtime)
+	}
+
+	localHash, err := l.side.hash()
+	if err != nil {
+		return "", "", err
+	}
+
+	remoteHash, err := r.side.hash()
+	if err != nil {
+		return "", "", err
+	}
+
+	switch {
+	case remoteHash == "":
+		return diffReasonNoHash, "remote file has no QuickXorHash", nil
+	case localHash != remoteHash:
+		return diffReasonHash, "QuickXorHash differs", nil
+	default:
+		return "", "", nil
+	}
+}
+
+// compareDiffMtimes compares to the second, because OneDrive keeps no finer
+// precision. An unknown remote time cannot show a difference.
+func compareDiffMtimes(local, remote time.Time) (reason, detail string, err error) {
+	local, remote = local.UTC().Truncate(time.Second), remote.UTC().Truncate(time.Second)
+	if remote.IsZero() || local.Equal(remote) {
+		return "", "", nil
+	}
+
+	return diffReasonMtime, fmt.Sprintf("modified %s locally, %s remotely",
+		formatExactTime(local), formatExactTime(remote)), nil
+}
+
+func entryKind(e *mirrorEntry) string {
+	if e.isDir {
+		return "folder"
+	}
+
+	return "file"
+}
+
+func printDiffTable(w io.Writer, entries []diffJSONEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, []string{e.Status, e.Path, e.Detail})
+	}
+
+	return printTable(w, []string{"STATUS", "PATH", "DETAIL"}, rows)
+}
+
+// printDiffJSON writes the diff command's JSON output to w.
+func printDiffJSON(w io.Writer, out diffJSONOutput) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encode diff output: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+func diffFileAt(p string, size int64, mtime time.Time) *mirrorEntry {
+	e := mirrorFile(p, size, "")
+	e.side.mtime = mtime
+
+	return e
+}
+
+// Validates: R-1.17, R-1.17.1
+func TestDiffTrees(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	local := mirrorTreeOf(
+		diffFileAt("same.txt", 4, at.Add(300*time.Millisecond)),
+		diffFileAt("Cafe\u0301.txt", 4, at),
+		diffFileAt("grown.txt", 9, at),
+		diffFileAt("touched.txt", 4, at.Add(time.Minute)),
+		mirrorDir("New"),
+		mirrorFile("New/inside.txt", 1, ""),
+		mirrorFile("bad:name.txt", 1, ""),
+		mirrorDir("was-file"),
+	)
+	remote := mirrorTreeOf(
+		diffFileAt("SAME.txt", 4, at),
+		diffFileAt("Caf\u00e9.txt", 4, at),
+		diffFileAt("grown.txt", 4, at),
+		diffFileAt("touched.txt", 4, at),
+		mirrorFile("was-file", 1, ""),
+		mirrorDir("Old"),
+		mirrorFile("Old/inside.txt", 1, ""),
+	)
+
+	entries, identical, err := diffTrees(local, remote, "Docs", false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, identical, "NFD and case variants match, and mtimes compare to the second")
+
+	got := make([]string, 0, len(entries))
+	for _, e := range entries {
+		got = append(got, e.Status+" "+e.Path+" "+e.Reason)
+	}
+
+	assert.Equal(t, []string{
+		"invalid bad:name.txt ",
+		"differs grown.txt size",
+		"only_local New ",
+		"only_remote Old ",
+		"differs touched.txt mtime",
+		"differs was-file type",
+	}, got)
+}
+
+// Validates: R-1.17.2
+func TestCompareDiffEntries_Checksum(t *testing.T) {
+	t.Parallel()
+
+	l := mirrorFile("a.txt", 4, "h1")
+	l.side.mtime = time.Now()
+
+	reason, _, err := compareDiffEntries(l, mirrorFile("a.txt", 4, "h1"), true)
+	require.NoError(t, err)
+	assert.Empty(t, reason, "equal hashes match regardless of mtime")
+
+	reason, _, err = compareDiffEntries(l, mirrorFile("a.txt", 4, "h2"), true)
+	require.NoError(t, err)
+	assert.Equal(t, diffReasonHash, reason)
+
+	reason, _, err = compareDiffEntries(l, mirrorFile("a.txt", 4, ""), true)
+	require.NoError(t, err)
+	assert.Equal(t, diffReasonNoHash, reason)
+}
+
+// Validates: R-1.17, R-1.17.2
+func TestRunDiff_ChecksumJSON(t *testing.T) {
+	local := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(local, "same.txt"), []byte("same"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "edited.txt"), []byte("new!"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "local.txt"), []byte("x"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			assert.Equal(t, http.MethodGet, r.Method, "diff never mutates")
+
+			if strings.HasSuffix(r.URL.Path, "/items/site/delta") {
+				writeTestResponsef(t, w, `{"value":[
+					{"id":"site","name":"Site","folder":{},"parentReference":{"id":"root"}},
+					{"id":"s","name":"same.txt","size":4,"file":{"hashes":{"quickXorHash":%q}},"parentReference":{"id":"site"}},
+					{"id":"e","name":"edited.txt","size":4,"file":{"hashes":{"quickXorHash":%q}},"parentReference":{"id":"site"}},
+					{"id":"r","name":"remote.txt","size":1,"file":{},"parentReference":{"id":"site"}}
+				],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`, tarTestHash("same"), tarTestHash("old!"))
+
+				return
+			}
+
+			writeTestResponse(t, w, `{"id":"site","name":"Site","folder":{},"parentReference":{"id":"root","path":"/drive/root:"}}`)
+		}), &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newDiffCmd()
+	cmd.SetArgs([]string{"--checksum", local, "/Site"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	var out diffJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, []diffJSONEntry{
+		{Path: "edited.txt", Status: diffDiffers, Reason: diffReasonHash, Detail: "QuickXorHash differs"},
+		{Path: "local.txt", Status: diffOnlyLocal},
+		{Path: "remote.txt", Status: diffOnlyRemote},
+	}, out.Entries)
+	assert.Equal(t, 1, out.Identical)
+	assert.True(t, out.Checksum)
+}
+
+func TestRunDiff_RejectsMissingLocalFolder(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"),
+		http.NotFoundHandler(), &stdout, &stderr)
+
+	cmd := newDiffCmd()
+	cmd.SetArgs([]string{filepath.Join(t.TempDir(), "missing"), "/Site"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stating local folder")
+}
@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+func newDuCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "du [remote-path]",
+		Short: "Show folder sizes and drive quota usage",
+		Long: `Summarize how much space each folder below a remote path uses, computed
+from one delta enumeration of the subtree, followed by the drive quota
+(used, total, remaining, and the share held by the recycle bin).
+
+--depth limits which folders are listed (totals always include everything
+below), --top keeps only the N largest folders, and --versions also counts
+the space held by older file versions (one extra request per file).`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runDu,
+	}
+
+	cmd.Flags().Int("depth", 1, "list folders up to this depth below the path (-1 = no limit)")
+	cmd.Flags().Int("top", 0, "only list the N largest folders (0 = all, sorted by path)")
+	cmd.Flags().Bool("versions", false, "include space used by version history (slow on large trees)")
+
+	return cmd
+}
+
+// duJSONOutput is the JSON output schema for du.
+type duJSONOutput struct {
+	Path        string        `json:"path"`
+	Size        int64         `json:"size"`
+	VersionSize int64         `json:"version_size,omitempty"`
+	Files       int           `json:"files"`
+	Folders     int           `json:"folders"`
+	Entries     []duJSONEntry `json:"entries"`
+	Quota       *duJSONQuota  `json:"quota,omitempty"`
+}
+
+type duJSONEntry struct {
+	Path        string `json:"path"`
+	Size        int64  `json:"size"`
+	VersionSize int64  `json:"version_size,omitempty"`
+	Files       int    `json:"files"`
+	Folders     int    `json:"folders"`
+}
+
+type duJSONQuota struct {
+	Used      int64  `json:"used"`
+	Total     int64  `json:"total"`
+	Deleted   int64  `json:"deleted"`
+	Remaining int64  `json:"remaining"`
+	State     string `json:"state,omitempty"`
+}
+
+type duReport struct {
+	rootPath string
+	total    driveops.FolderUsage
+	rows     []driveops.FolderUsage
+	versions bool
+	drive    *graph.Drive
+}
+
+func runDu(cmd *cobra.Command, args []string) error {
+	depth, top, versions, err := duFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	rootPath := ""
+	if len(args) == 1 {
+		rootPath = driveops.CleanRemotePath(args[0])
+	}
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	cc.Logger.Debug("du", "path", rootPath, "depth", depth, "top", top, "versions", versions)
+
+	_, entries, err := session.EnumerateFolder(ctx, rootPath)
+	if err != nil {
+		return fmt.Errorf("enumerating %q: %w", "/"+rootPath, err)
+	}
+
+	var versionSizes map[string]int64
+	if versions {
+		if versionSizes, err = session.VersionUsage(ctx, entries); err != nil {
+			return err
+		}
+	}
+
+	usage := driveops.SummarizeUsage(entries, depth, versionSizes)
+	report := duReport{rootPath: rootPath, total: usage[0], rows: topUsage(usage[1:], top), versions: versions}
+
+	// Quota is best-effort: shared drives often do not expose it to the caller.
+	if report.drive, err = session.Drive(ctx); err != nil {
+		cc.Logger.Warn("du: drive quota unavailable", "error", err)
+		report.drive = nil
+	}
+
+	if cc.Flags.JSON {
+		return printDuJSON(cc.Output(), &report)
+	}
+
+	return printDuTable(cc.Output(), &report)
+}
+
+func duFlags(cmd *cobra.Command) (depth, top int, versions bool, err error) {
+	if depth, err = cmd.Flags().GetInt("depth"); err != nil {
+		return 0, 0, false, fmt.Errorf("read --depth flag: %w", err)
+	}
+
+	if top, err = cmd.Flags().GetInt("top"); err != nil {
+		return 0, 0, false, fmt.Errorf("read --top flag: %w", err)
+	}
+
+	if versions, err = cmd.Flags().GetBool("versions"); err != nil {
+		return 0, 0, false, fmt.Errorf("read --versions flag: %w", err)
+	}
+
+	if top < 0 {
+		return 0, 0, false, fmt.Errorf("--top must not be negative")
+	}
+
+	return depth, top, versions, nil
+}
+
+// topUsage keeps the n largest folders, largest first. n == 0 keeps every
+// folder in path order.
+func topUsage(rows []driveops.FolderUsage, n int) []driveops.FolderUsage {
+	if n == 0 {
+		return rows
+	}
+
+	sorted := slices.Clone(rows)
+	slices.SortStableFunc(sorted, func(a, b driveops.FolderUsage) int {
+		return cmp.Compare(b.Size+b.VersionSize, a.Size+a.VersionSize)
+	})
+
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+
+	return sorted
+}
+
+// duPath joins a usage path (relative, "" for the root) onto the du root.
+func duPath(rootPath, usagePath string) string {
+	if usagePath == "" {
+		return rootPath
+	}
+
+	return joinRemotePath(rootPath, usagePath)
+}
+
+func printDuTable(w io.Writer, report *duReport) error {
+	headers := []string{"SIZE", "FILES", "PATH"}
+	if report.versions {
+		headers = []string{"SIZE", "VERSIONS", "FILES", "PATH"}
+	}
+
+	rows := make([][]string, 0, len(report.rows)+1)
+	for _, usage := range append(slices.Clone(report.rows), report.total) {
+		row := []string{formatSize(usage.Size), strconv.Itoa(usage.Files), "/" + duPath(report.rootPath, usage.Path)}
+		if report.versions {
+			row = slices.Insert(row, 1, formatSize(usage.VersionSize))
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err := printTable(w, headers, rows); err != nil {
+		return err
+	}
+
+	if report.drive == nil {
+		return nil
+	}
+
+	d := report.drive
+	if d.QuotaTotal == 0 {
+		return writef(w, "\nDrive quota: %s used\n", formatSize(d.QuotaUsed))
+	}
+
+	if err := writef(w, "\nDrive quota: %s used of %s, %s remaining (%s in recycle bin)\n",
+		formatSize(d.QuotaUsed), formatSize(d.QuotaTotal), formatSize(d.QuotaRemaining), formatSize(d.QuotaDeleted)); err != nil {
+		return err
+	}
+
+	if d.QuotaState != "" && d.QuotaState != "normal" {
+		return writef(w, "Quota state: %s\n", d.QuotaState)
+	}
+
+	return nil
+}
+
+func printDuJSON(w io.Writer, report *duReport) error {
+	out := duJSONOutput{
+		Path:        report.rootPath,
+		Size:        report.total.Size,
+		VersionSize: report.total.VersionSize,
+		Files:       report.total.Files,
+		Folders:     report.total.Folders,
+		Entries:     make([]duJSONEntry, 0, len(report.rows)),
+	}
+
+	for _, usage := range report.rows {
+		out.Entries = append(out.Entries, duJSONEntry{
+			Path:        duPath(report.rootPath, usage.Path),
+			Size:        usage.Size,
+			VersionSize: usage.VersionSize,
+			Files:       usage.Files,
+			Folders:     usage.Folders,
+		})
+	}
+
+	if report.drive != nil {
+		out.Quota = &duJSONQuota{
+			Used:      report.drive.QuotaUsed,
+			Total:     report.drive.QuotaTotal,
+			Deleted:   report.drive.QuotaDeleted,
+			Remaining: report.drive.QuotaRemaining,
+			State:     report.drive.QuotaState,
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encode du output: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+)
+
+func newDuTestContext(t *testing.T, stdout, stderr *bytes.Buffer, quotaStatus int) *CLIContext {
+	t.Helper()
+
+	return newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch r.URL.Path {
+			case "/drives/0000000drive-123/root:/Docs:":
+				writeTestResponse(t, w, `{"id":"docs","name":"Docs","folder":{},"parentReference":{"id":"root","path":"/drive/root:"}}`)
+			case "/drives/0000000drive-123/items/docs/delta":
+				writeTestResponse(t, w, `{"value":[
+					{"id":"docs","name":"Docs","folder":{},"parentReference":{"id":"root"}},
+					{"id":"small","name":"Small","folder":{},"parentReference":{"id":"docs"}},
+					{"id":"s1","name":"s.txt","size":10,"file":{},"parentReference":{"id":"small"}},
+					{"id":"big","name":"Big","folder":{},"parentReference":{"id":"docs"}},
+					{"id":"b1","name":"b.bin","size":5000,"file":{},"parentReference":{"id":"big"}},
+					{"id":"f1","name":"loose.txt","size":1,"file":{},"parentReference":{"id":"docs"}}
+				],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`)
+			case "/drives/0000000drive-123":
+				w.WriteHeader(quotaStatus)
+				writeTestResponse(t, w, `{"id":"0000000drive-123","driveType":"personal",
+					"quota":{"used":9000,"total":100000,"deleted":2000,"remaining":91000,"state":"normal"}}`)
+			default:
+				assert.Failf(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+		stdout,
+		stderr,
+	)
+}
+
+// Validates: R-1.13.1
+func TestTopUsage_KeepsLargestFolders(t *testing.T) {
+	t.Parallel()
+
+	rows := []driveops.FolderUsage{
+		{Path: "a", Size: 10},
+		{Path: "b", Size: 5, VersionSize: 20},
+		{Path: "c", Size: 15},
+	}
+
+	top := topUsage(rows, 2)
+	require.Len(t, top, 2)
+	assert.Equal(t, "b", top[0].Path, "version bytes count toward the ranking")
+	assert.Equal(t, "c", top[1].Path)
+	assert.Equal(t, rows, topUsage(rows, 0))
+}
+
+// Validates: R-1.13
+func TestRunDu_PrintsFolderSizesAndQuota(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newDuTestContext(t, &stdout, &stderr, http.StatusOK)
+
+	cmd := newDuCmd()
+	cmd.SetArgs([]string{"/Docs"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+	out := stdout.String()
+	assert.Contains(t, out, "/Docs/Big")
+	assert.Contains(t, out, "/Docs/Small")
+	assert.Contains(t, out, "4.9 KB")
+	assert.Contains(t, out, "Drive quota: 8.8 KB used of 97.7 KB")
+	assert.Contains(t, out, "2.0 KB in recycle bin")
+}
+
+// Validates: R-1.13.1
+func TestRunDu_JSONTopAndQuotaBreakdown(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newDuTestContext(t, &stdout, &stderr, http.StatusOK)
+	cc.Flags.JSON = true
+
+	cmd := newDuCmd()
+	cmd.SetArgs([]string{"/Docs", "--top", "1"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+
+	var out duJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, "Docs", out.Path)
+	assert.Equal(t, int64(5011), out.Size)
+	assert.Equal(t, 3, out.Files)
+	assert.Equal(t, 2, out.Folders)
+	require.Len(t, out.Entries, 1)
+	assert.Equal(t, "Docs/Big", out.Entries[0].Path)
+	require.NotNil(t, out.Quota)
+	assert.Equal(t, int64(2000), out.Quota.Deleted)
+	assert.Equal(t, int64(91000), out.Quota.Remaining)
+	assert.Equal(t, "normal", out.Quota.State)
+}
+
+// Validates: R-1.13
+func TestRunDu_QuotaFailureIsNotFatal(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newDuTestContext(t, &stdout, &stderr, http.StatusForbidden)
+	cc.Flags.JSON = true
+
+	cmd := newDuCmd()
+	cmd.SetArgs([]string{"/Docs"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+
+	var out duJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Nil(t, out.Quota)
+	assert.Len(t, out.Entries, 2)
+}
@@ -0,0 +1,393 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/config"
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+)
+
+func newDupesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dupes [remote-path]",
+		Short: "Find duplicate files by content hash",
+		Long: `Group files with the same size and content hash and report the space wasted
+by extra copies. Nothing is downloaded: hashes come from item metadata.
+
+--all-drives searches every configured drive instead of the selected one
+(a remote path cannot be combined with it). --keep oldest|newest|shortest-path
+moves every copy except the one selected by the rule to the recycle bin;
+combine it with --dry-run to preview. Empty files are never reported.`,
+		Args:        cobra.MaximumNArgs(1),
+		RunE:        runDupes,
+		Annotations: map[string]string{mutatesDriveAnnotation: "keep", allDrivesAnnotation: "all-drives"},
+	}
+
+	cmd.Flags().String("min-size", "", "ignore files smaller than this size (e.g. 1M, 10MB)")
+	cmd.Flags().Bool("all-drives", false, "search every configured drive")
+	cmd.Flags().String("keep", "", "recycle all copies but one: oldest, newest, or shortest-path")
+	cmd.Flags().Bool("dry-run", false, "with --keep, show what would be deleted without deleting")
+
+	return cmd
+}
+
+// dupesJSONOutput is the JSON output schema for dupes.
+type dupesJSONOutput struct {
+	Groups      []dupesJSONGroup `json:"groups"`
+	WastedBytes int64            `json:"wasted_bytes"`
+}
+
+type dupesJSONGroup struct {
+	Hash        string          `json:"hash"`
+	Size        int64           `json:"size"`
+	WastedBytes int64           `json:"wasted_bytes"`
+	Copies      []dupesJSONCopy `json:"copies"`
+}
+
+type dupesJSONCopy struct {
+	Drive      string `json:"drive"`
+	Path       string `json:"path"`
+	ID         string `json:"id"`
+	ModifiedAt string `json:"modified_at"`
+	Action     string `json:"action,omitempty"`
+}
+
+const dupesActionKept = "kept"
+
+type dupesOptions struct {
+	rootPath  string
+	minSize   int64
+	allDrives bool
+	keep      driveops.KeepRule
+	dryRun    bool
+}
+
+// dupeDrive is one drive dupes searches: its config, checked before any
+// copy on it is recycled, and its session.
+type dupeDrive struct {
+	cfg     *config.ResolvedDrive
+	session *driveops.MountSession
+}
+
+// dupesReport carries the groups plus the per-copy action taken, indexed
+// like groups[i].Copies[j].
+type dupesReport struct {
+	multiDrive bool
+	groups     []driveops.DuplicateGroup
+	actions    [][]string
+}
+
+func runDupes(cmd *cobra.Command, args []string) error {
+	opts, err := dupesOptionsFromFlags(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	cc.Logger.Debug("dupes", "path", opts.rootPath, "all_drives", opts.allDrives, "keep", opts.keep, "dry_run", opts.dryRun)
+
+	drives, candidates, err := collectDupeCandidates(ctx, cc, &opts)
+	if err != nil {
+		return err
+	}
+
+	report := dupesReport{
+		multiDrive: len(drives) > 1,
+		groups:     driveops.FindDuplicates(candidates, opts.minSize),
+	}
+
+	if opts.keep != "" {
+		if err := recycleDuplicates(ctx, cc, drives, &report, &opts); err != nil {
+			return err
+		}
+	}
+
+	if cc.Flags.JSON {
+		return printDupesJSON(cc.Output(), &report)
+	}
+
+	return printDupesText(cc.Output(), &report)
+}
+
+func dupesOptionsFromFlags(cmd *cobra.Command, args []string) (dupesOptions, error) {
+	var opts dupesOptions
+	if len(args) == 1 {
+		opts.rootPath = driveops.CleanRemotePath(args[0])
+	}
+
+	minSize, err := cmd.Flags().GetString("min-size")
+	if err != nil {
+		return opts, fmt.Errorf("read --min-size flag: %w", err)
+	}
+
+	if minSize != "" {
+		sign, size, parseErr := parseFindSize(minSize)
+		if parseErr != nil || sign != findCmpEqual {
+			return opts, fmt.Errorf("invalid --min-size %q", minSize)
+		}
+
+		opts.minSize = size
+	}
+
+	if opts.allDrives, err = cmd.Flags().GetBool("all-drives"); err != nil {
+		return opts, fmt.Errorf("read --all-drives flag: %w", err)
+	}
+
+	if opts.allDrives && opts.rootPath != "" {
+		return opts, fmt.Errorf("a remote path cannot be combined with --all-drives")
+	}
+
+	keep, err := cmd.Flags().GetString("keep")
+	if err != nil {
+		return opts, fmt.Errorf("read --keep flag: %w", err)
+	}
+
+	if keep != "" {
+		if opts.keep, err = driveops.ParseKeepRule(keep); err != nil {
+			return opts, fmt.Errorf("invalid --keep: %w", err)
+		}
+	}
+
+	if opts.dryRun, err = cmd.Flags().GetBool("dry-run"); err != nil {
+		return opts, fmt.Errorf("read --dry-run flag: %w", err)
+	}
+
+	if opts.dryRun && opts.keep == "" {
+		return opts, fmt.Errorf("--dry-run only applies to --keep")
+	}
+
+	return opts, nil
+}
+
+// collectDupeCandidates enumerates the selected drive (or every configured
+// drive) and labels each entry with its drive's canonical ID.
+func collectDupeCandidates(
+	ctx context.Context,
+	cc *CLIContext,
+	opts *dupesOptions,
+) (map[string]dupeDrive, []driveops.DuplicateCandidate, error) {
+	var drives map[string]dupeDrive
+
+	if opts.allDrives {
+		configured, err := configuredDriveSessions(ctx, cc)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		drives = configured
+	} else {
+		primary, err := cc.Session(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		drives = map[string]dupeDrive{cc.Cfg.CanonicalID.String(): {cfg: cc.Cfg, session: primary}}
+	}
+
+	var candidates []driveops.DuplicateCandidate
+
+	for label, drive := range drives {
+		_, entries, err := drive.session.EnumerateFolder(ctx, opts.rootPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("enumerating %s: %w", label, err)
+		}
+
+		for i := range entries {
+			candidates = append(candidates, driveops.DuplicateCandidate{
+				Drive: label,
+				Entry: driveops.RemoteTreeEntry{Path: joinRemotePath(opts.rootPath, entries[i].Path), Item: entries[i].Item},
+			})
+		}
+	}
+
+	return drives, candidates, nil
+}
+
+// configuredDriveSessions opens a session on every configured drive. No
+// single drive is resolved for --all-drives, so none needs --drive.
+func configuredDriveSessions(ctx context.Context, cc *CLIContext) (map[string]dupeDrive, error) {
+	rawCfg, err := config.LoadOrDefault(cc.CfgPath, cc.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	resolved, err := config.ResolveDrives(rawCfg, nil, true, cc.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("resolve drives: %w", err)
+	}
+
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("no drives configured — run 'onedrive-go drive add' to add a drive")
+	}
+
+	drives := make(map[string]dupeDrive, len(resolved))
+
+	for _, rd := range resolved {
+		label := rd.CanonicalID.String()
+
+		session, err := cc.sessionForDrive(ctx, rd)
+		if err != nil {
+			return nil, fmt.Errorf("drive %s: %w", label, err)
+		}
+
+		drives[label] = dupeDrive{cfg: rd, session: session}
+	}
+
+	return drives, nil
+}
+
+// recycleDuplicates moves every copy except the keeper of each group to the
+// recycle bin, or only records what would happen under --dry-run. A copy on
+// a drive signed in read-only refuses the whole run before anything is
+// recycled.
+func recycleDuplicates(
+	ctx context.Context,
+	cc *CLIContext,
+	drives map[string]dupeDrive,
+	report *dupesReport,
+	opts *dupesOptions,
+) error {
+	if !opts.dryRun {
+		for i := range report.groups {
+			group := &report.groups[i]
+			keeper := group.Keeper(opts.keep)
+
+			for j := range group.Copies {
+				if j == keeper {
+					continue
+				}
+
+				if err := requireWritableDrive(drives[group.Copies[j].Drive].cfg, "dupes --keep"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	report.actions = make([][]string, len(report.groups))
+
+	for i := range report.groups {
+		group := &report.groups[i]
+		keeper := group.Keeper(opts.keep)
+		report.actions[i] = make([]string, len(group.Copies))
+		report.actions[i][keeper] = dupesActionKept
+
+		for j := range group.Copies {
+			if j == keeper {
+				continue
+			}
+
+			copyItem := &group.Copies[j]
+			label := dupeDisplayPath(report.multiDrive, copyItem)
+
+			if opts.dryRun {
+				report.actions[i][j] = findActionWouldDelete
+				cc.Statusf("Would delete %s\n", label)
+
+				continue
+			}
+
+			err := drives[copyItem.Drive].session.DeleteResolvedPath(ctx, copyItem.Entry.Path, copyItem.Entry.Item.ID)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %w", label, err)
+			}
+
+			report.actions[i][j] = findActionDeleted
+			cc.Statusf("Deleted %s (moved to recycle bin)\n", label)
+		}
+	}
+
+	return nil
+}
+
+func dupeDisplayPath(multiDrive bool, c *driveops.DuplicateCandidate) string {
+	if multiDrive {
+		return c.Drive + ":/" + c.Entry.Path
+	}
+
+	return "/" + c.Entry.Path
+}
+
+func (r *dupesReport) action(group, copyIdx int) string {
+	if r.actions == nil {
+		return ""
+	}
+
+	return r.actions[group][copyIdx]
+}
+
+func printDupesText(w io.Writer, report *dupesReport) error {
+	if len(report.groups) == 0 {
+		return writeln(w, "No duplicates found.")
+	}
+
+	var wasted int64
+
+	for i := range report.groups {
+		group := &report.groups[i]
+		wasted += group.WastedBytes()
+
+		if err := writef(w, "%d copies of %s (%s wasted)\n",
+			len(group.Copies), formatSize(group.Size), formatSize(group.WastedBytes())); err != nil {
+			return err
+		}
+
+		for j := range group.Copies {
+			line := "  " + dupeDisplayPath(report.multiDrive, &group.Copies[j])
+			if action := report.action(i, j); action != "" {
+				line += "  [" + action + "]"
+			}
+
+			if err := writeln(w, line); err != nil {
+				return err
+			}
+		}
+	}
+
+	return writef(w, "\n%d duplicate groups, %s wasted\n", len(report.groups), formatSize(wasted))
+}
+
+func printDupesJSON(w io.Writer, report *dupesReport) error {
+	out := dupesJSONOutput{Groups: make([]dupesJSONGroup, 0, len(report.groups))}
+
+	for i := range report.groups {
+		group := &report.groups[i]
+		out.WastedBytes += group.WastedBytes()
+
+		jsonGroup := dupesJSONGroup{
+			Hash:        group.Hash,
+			Size:        group.Size,
+			WastedBytes: group.WastedBytes(),
+			Copies:      make([]dupesJSONCopy, 0, len(group.Copies)),
+		}
+
+		for j := range group.Copies {
+			item := &group.Copies[j].Entry.Item
+			jsonGroup.Copies = append(jsonGroup.Copies, dupesJSONCopy{
+				Drive:      group.Copies[j].Drive,
+				Path:       group.Copies[j].Entry.Path,
+				ID:         item.ID,
+				ModifiedAt: formatAPITime(item.ModifiedAt),
+				Action:     report.action(i, j),
+			})
+		}
+
+		out.Groups = append(out.Groups, jsonGroup)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encode dupes output: %w", err)
+	}
+
+	return nil
+}
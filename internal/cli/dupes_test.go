@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/config"
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+func newDupesTestContext(t *testing.T, stdout, stderr *bytes.Buffer, deleted *[]string) *CLIContext {
+	t.Helper()
+
+	const folderJSON = `{"id":"docs","name":"Docs","folder":{},"parentReference":{"id":"root","path":"/drive/root:"}}`
+
+	return newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch {
+			case r.Method == http.MethodDelete:
+				*deleted = append(*deleted, r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+			case r.URL.Path == "/drives/0000000drive-123/items/docs/delta":
+				writeTestResponse(t, w, `{"value":[
+					{"id":"docs","name":"Docs","folder":{},"parentReference":{"id":"root"}},
+					{"id":"old","name":"report.pdf","size":2048,"createdDateTime":"2023-01-01T00:00:00Z",
+					 "file":{"hashes":{"quickXorHash":"same=="}},"parentReference":{"id":"docs"}},
+					{"id":"arch","name":"Archive","folder":{},"parentReference":{"id":"docs"}},
+					{"id":"new","name":"report (1).pdf","size":2048,"createdDateTime":"2024-01-01T00:00:00Z",
+					 "file":{"hashes":{"quickXorHash":"same=="}},"parentReference":{"id":"arch"}},
+					{"id":"tiny1","name":"a.txt","size":3,"file":{"hashes":{"quickXorHash":"tiny=="}},"parentReference":{"id":"docs"}},
+					{"id":"tiny2","name":"b.txt","size":3,"file":{"hashes":{"quickXorHash":"tiny=="}},"parentReference":{"id":"docs"}}
+				],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`)
+			default:
+				// Path resolution and post-delete parent probes land on Docs.
+				writeTestResponse(t, w, folderJSON)
+			}
+		}),
+		stdout,
+		stderr,
+	)
+}
+
+// Validates: R-1.14
+func TestRunDupes_ReportsGroupsAndWastedBytes(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var deleted []string
+	cc := newDupesTestContext(t, &stdout, &stderr, &deleted)
+
+	cmd := newDupesCmd()
+	cmd.SetArgs([]string{"/Docs"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+	out := stdout.String()
+	assert.Contains(t, out, "2 copies of 2.0 KB (2.0 KB wasted)")
+	assert.Contains(t, out, "  /Docs/Archive/report (1).pdf")
+	assert.Contains(t, out, "  /Docs/a.txt")
+	assert.Contains(t, out, "2 duplicate groups")
+	assert.Empty(t, deleted)
+}
+
+// Validates: R-1.14
+func TestRunDupes_MinSizeSkipsSmallFiles(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var deleted []string
+	cc := newDupesTestContext(t, &stdout, &stderr, &deleted)
+	cc.Flags.JSON = true
+
+	cmd := newDupesCmd()
+	cmd.SetArgs([]string{"/Docs", "--min-size", "1K"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+
+	var out dupesJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out.Groups, 1)
+	assert.Equal(t, "quickXorHash:same==", out.Groups[0].Hash)
+	assert.Equal(t, int64(2048), out.WastedBytes)
+}
+
+// Validates: R-1.14.1
+func TestRunDupes_KeepOldestDryRunDoesNotDelete(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var deleted []string
+	cc := newDupesTestContext(t, &stdout, &stderr, &deleted)
+	cc.Flags.JSON = true
+
+	cmd := newDupesCmd()
+	cmd.SetArgs([]string{"/Docs", "--min-size", "1K", "--keep", "oldest", "--dry-run"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+	assert.Empty(t, deleted)
+
+	var out dupesJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out.Groups, 1)
+	require.Len(t, out.Groups[0].Copies, 2)
+	assert.Equal(t, "Docs/Archive/report (1).pdf", out.Groups[0].Copies[0].Path)
+	assert.Equal(t, findActionWouldDelete, out.Groups[0].Copies[0].Action)
+	assert.Equal(t, dupesActionKept, out.Groups[0].Copies[1].Action)
+}
+
+// Validates: R-1.14.1
+func TestRunDupes_KeepNewestRecyclesOthers(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var deleted []string
+	cc := newDupesTestContext(t, &stdout, &stderr, &deleted)
+
+	cmd := newDupesCmd()
+	cmd.SetArgs([]string{"/Docs", "--min-size", "1K", "--keep", "newest"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"/drives/0000000drive-123/items/old"}, deleted)
+	assert.Contains(t, stdout.String(), "/Docs/report.pdf  [deleted]")
+}
+
+// Validates: R-1.14
+func TestRunDupes_AllDrivesNeedsNoDriveSelector(t *testing.T) {
+	setTestDriveHome(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		drive := "home"
+		if strings.Contains(r.URL.Path, "work") {
+			drive = "work"
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/delta") {
+			writeTestResponsef(t, w, `{"value":[
+				{"id":"%[1]s-report","name":"report.pdf","size":2048,
+				 "file":{"hashes":{"quickXorHash":"same=="}},"parentReference":{"id":"root"}}
+			],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`, drive)
+
+			return
+		}
+
+		writeTestResponse(t, w, `{"id":"root","name":"root","folder":{},"root":{}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfgPath := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(`
+["personal:alice@example.com"]
+display_name = "Home"
+
+["business:alice@contoso.com"]
+display_name = "Work"
+`), 0o600))
+	seedCatalogDrive(t, driveid.MustCanonicalID("personal:alice@example.com"), func(d *config.CatalogDrive) {
+		d.RemoteDriveID = "home"
+	})
+	seedCatalogDrive(t, driveid.MustCanonicalID("business:alice@contoso.com"), func(d *config.CatalogDrive) {
+		d.RemoteDriveID = "work"
+	})
+
+	var stdout, stderr bytes.Buffer
+	root := newRootCmdWithWriters(&stdout, &stderr)
+	dupes, _, err := root.Find([]string{"dupes"})
+	require.NoError(t, err)
+	dupes.RunE = func(cmd *cobra.Command, args []string) error {
+		cc := mustCLIContext(cmd.Context())
+		cc.GraphBaseURL = srv.URL
+		cc.Runtime.TokenSourceFn = func(context.Context, string, *slog.Logger) (graph.TokenSource, error) {
+			return staticTokenSource{}, nil
+		}
+
+		return runDupes(cmd, args)
+	}
+	root.SetArgs([]string{"--config", cfgPath, "--json", "dupes", "--all-drives"})
+
+	require.NoError(t, root.Execute())
+
+	var out dupesJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out.Groups, 1)
+	require.Len(t, out.Groups[0].Copies, 2)
+	assert.ElementsMatch(t,
+		[]string{"personal:alice@example.com", "business:alice@contoso.com"},
+		[]string{out.Groups[0].Copies[0].Drive, out.Groups[0].Copies[1].Drive})
+}
+
+func TestRunDupes_RejectsInvalidFlagCombinations(t *testing.T) {
+	tests := [][]string{
+		{"--dry-run"},
+		{"--keep", "largest"},
+		{"/Docs", "--all-drives"},
+		{"--min-size", "+1K"},
+	}
+
+	for _, args := range tests {
+		var stdout, stderr bytes.Buffer
+		var deleted []string
+		cc := newDupesTestContext(t, &stdout, &stderr, &deleted)
+
+		cmd := newDupesCmd()
+		cmd.SetArgs(args)
+		cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+		require.Error(t, cmd.Execute(), args)
+	}
+}
+
+// Validates: R-1.14.1, R-3.1.10
+func TestRecycleDuplicates_RefusesCopiesOnReadOnlyDrives(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var deleted []string
+	cc := newDupesTestContext(t, &stdout, &stderr, &deleted)
+
+	session, err := cc.Session(t.Context())
+	require.NoError(t, err)
+
+	reader := &config.ResolvedDrive{CanonicalID: driveid.MustCanonicalID("personal:reader@example.com"), ReadOnly: true}
+	drives := map[string]dupeDrive{
+		cc.Cfg.CanonicalID.String(): {cfg: cc.Cfg, session: session},
+		reader.CanonicalID.String(): {cfg: reader},
+	}
+
+	copyOn := func(drive, path string) driveops.DuplicateCandidate {
+		return driveops.DuplicateCandidate{
+			Drive: drive,
+			Entry: driveops.RemoteTreeEntry{Path: path, Item: graph.Item{ID: path, Name: path}},
+		}
+	}
+
+	report := dupesReport{multiDrive: true, groups: []driveops.DuplicateGroup{
+		{Size: 1, Copies: []driveops.DuplicateCandidate{
+			copyOn(cc.Cfg.CanonicalID.String(), "x"), copyOn(cc.Cfg.CanonicalID.String(), "Docs/x"),
+		}},
+		{Size: 1, Copies: []driveops.DuplicateCandidate{
+			copyOn(cc.Cfg.CanonicalID.String(), "y"), copyOn(reader.CanonicalID.String(), "Docs/y"),
+		}},
+	}}
+	opts := dupesOptions{allDrives: true, keep: driveops.KeepShortestPath}
+
+	err = recycleDuplicates(t.Context(), cc, drives, &report, &opts)
+	require.ErrorContains(t, err, "personal:reader@example.com is signed in read-only")
+	assert.Empty(t, deleted, "nothing is recycled when any copy to recycle is on a read-only drive")
+
+	opts.dryRun = true
+	require.NoError(t, recycleDuplicates(t.Context(), cc, drives, &report, &opts))
+	assert.Equal(t, findActionWouldDelete, report.action(1, 1))
+}
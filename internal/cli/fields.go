@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+func newFieldsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fields",
+		Short: "Manage SharePoint document library column values",
+		Long: `Read and write the custom columns (list item fields) that SharePoint document
+libraries attach to files. Use stat --fields to show them.`,
+	}
+
+	cmd.AddCommand(newFieldsSetCmd())
+
+	return cmd
+}
+
+func newFieldsSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <path> <column>=<value>...",
+		Short: "Set column values on a file or folder",
+		Long: `Set one or more document library column values. Columns are matched by
+internal name or display name. Values are parsed by column type: numbers and
+currency as numbers, yes/no columns as true/false (or yes/no), date columns
+as RFC 3339 or YYYY-MM-DD[ HH:MM[:SS]] in local time, and choice columns
+must name one of their choices. An empty value clears the column.`,
+		Args:        cobra.MinimumNArgs(2),
+		Annotations: map[string]string{mutatesDriveAnnotation: mutatesDriveValue},
+		RunE:        runFieldsSet,
+	}
+}
+
+// fieldAssignment is one <column>=<value> argument.
+type fieldAssignment struct {
+	Column string
+	Value  string
+}
+
+// fieldsJSONOutput is the JSON output schema for fields set.
+type fieldsJSONOutput struct {
+	Path   string         `json:"path"`
+	ID     string         `json:"id"`
+	Fields map[string]any `json:"fields"`
+}
+
+func runFieldsSet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+	remotePath := args[0]
+
+	assignments, err := parseFieldAssignments(args[1:])
+	if err != nil {
+		return err
+	}
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	cc.Logger.Debug("fields set", "path", remotePath, "count", len(assignments))
+
+	item, err := session.ResolveItem(ctx, remotePath)
+	if err != nil {
+		return fmt.Errorf("resolving %q: %w", remotePath, err)
+	}
+
+	columns, err := session.ListDriveColumns(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload, err := buildFieldsPayload(columns, assignments)
+	if err != nil {
+		return err
+	}
+
+	updated, err := session.UpdateListItemFields(ctx, item.ID, payload)
+	if err != nil {
+		return err
+	}
+
+	// Report only the columns this call set; the response carries every field.
+	changed := make(map[string]any, len(payload))
+	for name := range payload {
+		changed[name] = updated[name]
+	}
+
+	if cc.Flags.JSON {
+		return printFieldsJSON(cc.Output(), fieldsJSONOutput{Path: remotePath, ID: item.ID, Fields: changed})
+	}
+
+	cc.Statusf("Updated %d field(s) on %s\n", len(changed), remotePath)
+
+	return printFieldsText(cc.Output(), changed)
+}
+
+// parseFieldAssignments splits <column>=<value> arguments at the first "=".
+func parseFieldAssignments(args []string) ([]fieldAssignment, error) {
+	assignments := make([]fieldAssignment, 0, len(args))
+	for _, arg := range args {
+		column, value, ok := strings.Cut(arg, "=")
+		column = strings.TrimSpace(column)
+		if !ok || column == "" {
+			return nil, fmt.Errorf("invalid field assignment %q: want <column>=<value>", arg)
+		}
+
+		assignments = append(assignments, fieldAssignment{Column: column, Value: value})
+	}
+
+	return assignments, nil
+}
+
+// buildFieldsPayload maps assignments onto library columns and converts each
+// value to the JSON type its column expects. The payload is keyed by internal
+// column name.
+func buildFieldsPayload(columns []graph.ColumnDefinition, assignments []fieldAssignment) (map[string]any, error) {
+	payload := make(map[string]any, len(assignments))
+	for _, a := range assignments {
+		col, ok := findColumn(columns, a.Column)
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", a.Column)
+		}
+
+		if col.ReadOnly {
+			return nil, fmt.Errorf("column %q is read-only", a.Column)
+		}
+
+		if a.Value == "" {
+			payload[col.Name] = nil
+			continue
+		}
+
+		value, err := convertFieldValue(col, a.Value)
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %w", a.Column, err)
+		}
+
+		payload[col.Name] = value
+	}
+
+	return payload, nil
+}
+
+// findColumn matches an internal name exactly, then an internal or display
+// name case-insensitively.
+func findColumn(columns []graph.ColumnDefinition, name string) (graph.ColumnDefinition, bool) {
+	for _, col := range columns {
+		if col.Name == name {
+			return col, true
+		}
+	}
+
+	for _, col := range columns {
+		if strings.EqualFold(col.Name, name) || strings.EqualFold(col.DisplayName, name) {
+			return col, true
+		}
+	}
+
+	return graph.ColumnDefinition{}, false
+}
+
+func convertFieldValue(col graph.ColumnDefinition, raw string) (any, error) {
+	switch col.Type {
+	case graph.ColumnTypeNumber, graph.ColumnTypeCurrency:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+
+		return n, nil
+	case graph.ColumnTypeBoolean:
+		switch strings.ToLower(raw) {
+		case "true", "yes", "1":
+			return true, nil
+		case "false", "no", "0":
+			return false, nil
+		default:
+			return nil, fmt.Errorf("%q is not yes/no", raw)
+		}
+	case graph.ColumnTypeDateTime:
+		t, ok := parseUserTime(raw)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a date: want RFC 3339 or YYYY-MM-DD[ HH:MM[:SS]]", raw)
+		}
+
+		return t.UTC().Format(time.RFC3339), nil
+	case graph.ColumnTypeChoice:
+		if len(col.Choices) == 0 {
+			return raw, nil
+		}
+
+		for _, choice := range col.Choices {
+			if strings.EqualFold(choice, raw) {
+				return choice, nil
+			}
+		}
+
+		return nil, fmt.Errorf("%q is not one of %s", raw, strings.Join(col.Choices, ", "))
+	default:
+		return raw, nil
+	}
+}
+
+// formatFieldValue renders a list item field value for text output.
+func formatFieldValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+
+		return string(data)
+	}
+}
+
+// printFieldsText writes one "  name: value" line per field, sorted by name.
+func printFieldsText(w io.Writer, fields map[string]any) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if err := writef(w, "  %s: %s\n", name, formatFieldValue(fields[name])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printFieldsJSON(w io.Writer, out fieldsJSONOutput) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encode fields output: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// newFieldsTestContext serves /Docs/plan.docx in a library with text,
+// number, yes/no, date, choice and read-only columns, and records the body of
+// every list item fields PATCH.
+func newFieldsTestContext(t *testing.T, patches *[]map[string]any, stdout, stderr *bytes.Buffer) *CLIContext {
+	t.Helper()
+
+	return newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("business:user@contoso.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/drives/0000000drive-123/root:/Docs/plan.docx:":
+				writeTestResponse(t, w, `{"id":"plan","name":"plan.docx","file":{},
+					"createdDateTime":"2026-01-01T00:00:00Z","lastModifiedDateTime":"2026-01-02T00:00:00Z"}`)
+			case r.Method == http.MethodGet && r.URL.Path == "/drives/0000000drive-123/list/columns":
+				writeTestResponse(t, w, `{"value":[
+					{"name":"ProjectCode","displayName":"Project code","text":{}},
+					{"name":"Budget","displayName":"Budget","number":{}},
+					{"name":"Approved","displayName":"Approved","boolean":{}},
+					{"name":"DueDate","displayName":"Due date","dateTime":{}},
+					{"name":"Status","displayName":"Status","choice":{"choices":["Draft","Final"]}},
+					{"name":"Modified","displayName":"Modified","readOnly":true,"dateTime":{}}]}`)
+			case r.Method == http.MethodGet && r.URL.Path == "/drives/0000000drive-123/items/plan/listItem/fields":
+				writeTestResponse(t, w, `{"@odata.etag":"\"2\"","ProjectCode":"PX-7","Budget":1250,"Status":"Draft"}`)
+			case r.Method == http.MethodPatch && r.URL.Path == "/drives/0000000drive-123/items/plan/listItem/fields":
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+
+				var patch map[string]any
+				assert.NoError(t, json.Unmarshal(body, &patch))
+				*patches = append(*patches, patch)
+
+				writeTestResponse(t, w, string(body))
+			default:
+				assert.Failf(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusNotImplemented)
+			}
+		}),
+		stdout,
+		stderr,
+	)
+}
+
+// Validates: R-1.23.2
+func TestRunFieldsSet_ParsesValuesByColumnType(t *testing.T) {
+	var patches []map[string]any
+	var stdout, stderr bytes.Buffer
+	cc := newFieldsTestContext(t, &patches, &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newFieldsSetCmd()
+	cmd.SetArgs([]string{
+		"/Docs/plan.docx",
+		"project code=PX-8", "Budget=1500.5", "approved=yes", "DueDate=2026-03-01T12:00:00Z", "Status=final", "ProjectCode=",
+	})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	require.Len(t, patches, 1)
+	assert.Equal(t, map[string]any{
+		"ProjectCode": nil,
+		"Budget":      1500.5,
+		"Approved":    true,
+		"DueDate":     "2026-03-01T12:00:00Z",
+		"Status":      "Final",
+	}, patches[0], "later assignments to the same column win and an empty value clears it")
+
+	var out fieldsJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, "plan", out.ID)
+	assert.Equal(t, "Final", out.Fields["Status"])
+}
+
+// Validates: R-1.23.2
+func TestBuildFieldsPayload_RejectsBeforePatching(t *testing.T) {
+	columns := []graph.ColumnDefinition{
+		{Name: "Budget", DisplayName: "Budget", Type: graph.ColumnTypeNumber},
+		{Name: "Approved", DisplayName: "Approved", Type: graph.ColumnTypeBoolean},
+		{Name: "Status", DisplayName: "Status", Type: graph.ColumnTypeChoice, Choices: []string{"Draft", "Final"}},
+		{Name: "Modified", DisplayName: "Modified", Type: graph.ColumnTypeDateTime, ReadOnly: true},
+	}
+
+	for _, tc := range []struct {
+		arg, wantErr string
+	}{
+		{"Owner=me", `unknown column "Owner"`},
+		{"Modified=2026-01-01", `column "Modified" is read-only`},
+		{"Budget=lots", `"lots" is not a number`},
+		{"Approved=maybe", `"maybe" is not yes/no`},
+		{"Status=Archived", `"Archived" is not one of Draft, Final`},
+	} {
+		assignments, err := parseFieldAssignments([]string{tc.arg})
+		require.NoError(t, err, tc.arg)
+
+		_, err = buildFieldsPayload(columns, assignments)
+		require.ErrorContains(t, err, tc.wantErr, tc.arg)
+	}
+
+	_, err := parseFieldAssignments([]string{"=value"})
+	require.Error(t, err)
+	_, err = parseFieldAssignments([]string{"Budget"})
+	require.Error(t, err)
+}
+
+// Validates: R-1.23.1
+func TestRunStat_FieldsShowsListItemColumns(t *testing.T) {
+	var patches []map[string]any
+	var stdout, stderr bytes.Buffer
+	cc := newFieldsTestContext(t, &patches, &stdout, &stderr)
+
+	cmd := newStatCmd()
+	cmd.SetArgs([]string{"/Docs/plan.docx", "--fields"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, stdout.String(), "Fields:\n  Budget: 1250\n  ProjectCode: PX-7\n  Status: Draft\n")
+	assert.NotContains(t, stdout.String(), "odata")
+
+	stdout.Reset()
+	cc.Flags.JSON = true
+	cmd = newStatCmd()
+	cmd.SetArgs([]string{"/Docs/plan.docx", "--fields"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	var out statJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, map[string]any{"Budget": 1250.0, "ProjectCode": "PX-7", "Status": "Draft"}, out.Fields)
+	assert.Empty(t, patches)
+}
+
+func TestConvertFieldValue_LocalDateBecomesUTC(t *testing.T) {
+	got, err := convertFieldValue(graph.ColumnDefinition{Type: graph.ColumnTypeDateTime}, "2026-03-01")
+	require.NoError(t, err)
+
+	want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local).UTC().Format(time.RFC3339)
+	assert.Equal(t, want, got)
+}
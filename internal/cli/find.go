@@ -0,0 +1,263 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+)
+
+func newFindCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "find <remote-path>",
+		Short: "Find remote files and folders by exact predicates",
+		Long: `Walk a remote subtree and print every item matching all given predicates.
+Unlike 'search', matching is exact and happens client-side over one delta
+enumeration of the subtree.
+
+Predicates accept find(1) spellings with a single dash as well:
+  -name GLOB     name matches shell glob (case-sensitive); -iname ignores case
+  -size [+|-]N   file size more than / less than / exactly N (K, M, G, T suffixes)
+  -mtime [+|-]N  modified more than / less than N ago (e.g. 7d, 12h, 1h30m; bare N = days)
+  -type f|d      files or folders only
+  -hash H        QuickXorHash (or SHA1/SHA256) equals H
+
+--delete moves every matched file to the recycle bin; combine it with
+--dry-run to preview. Folders are never deleted by find.`,
+		Args:        cobra.ExactArgs(1),
+		RunE:        runFind,
+		Annotations: map[string]string{mutatesDriveAnnotation: "delete"},
+	}
+
+	cmd.Flags().String("name", "", "name glob to match")
+	cmd.Flags().String("iname", "", "case-insensitive name glob to match")
+	cmd.Flags().String("size", "", "size predicate, e.g. +100M or -1K")
+	cmd.Flags().String("mtime", "", "modification age predicate, e.g. -7d or +12h")
+	cmd.Flags().String("type", "", "item type: f (file) or d (folder)")
+	cmd.Flags().String("hash", "", "content hash to match (QuickXorHash, SHA1, or SHA256)")
+	cmd.Flags().Bool("print0", false, "separate output paths with NUL instead of newline")
+	cmd.Flags().Bool("delete", false, "move matched files to the recycle bin")
+	cmd.Flags().Bool("dry-run", false, "with --delete, show what would be deleted without deleting")
+	cmd.MarkFlagsMutuallyExclusive("name", "iname")
+
+	return cmd
+}
+
+// findJSONItem is the JSON output schema for one find match.
+type findJSONItem struct {
+	Path         string `json:"path"`
+	Type         string `json:"type"`
+	Size         int64  `json:"size"`
+	ModifiedAt   string `json:"modified_at"`
+	ID           string `json:"id"`
+	QuickXorHash string `json:"quick_xor_hash,omitempty"`
+	Action       string `json:"action,omitempty"`
+}
+
+const (
+	findActionDeleted     = "deleted"
+	findActionWouldDelete = "would_delete"
+)
+
+type findMatch struct {
+	entry  driveops.RemoteTreeEntry
+	path   string
+	action string
+}
+
+func runFind(cmd *cobra.Command, args []string) error {
+	criteria, err := findCriteriaFromFlags(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+
+	deleteMatches, dryRun, print0, err := findActionFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+	rootPath := driveops.CleanRemotePath(args[0])
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	cc.Logger.Debug("find", "path", rootPath, "delete", deleteMatches, "dry_run", dryRun)
+
+	_, entries, err := session.EnumerateFolder(ctx, rootPath)
+	if err != nil {
+		return fmt.Errorf("enumerating %q: %w", "/"+rootPath, err)
+	}
+
+	matches := make([]findMatch, 0)
+	for i := range entries {
+		if criteria.match(&entries[i].Item) {
+			matches = append(matches, findMatch{entry: entries[i], path: joinRemotePath(rootPath, entries[i].Path)})
+		}
+	}
+
+	if deleteMatches {
+		if err := deleteFindMatches(ctx, cc, session, matches, dryRun); err != nil {
+			return err
+		}
+	}
+
+	if cc.Flags.JSON {
+		return printFindJSON(cc.Output(), matches)
+	}
+
+	return printFindPaths(cc.Output(), matches, print0)
+}
+
+func findCriteriaFromFlags(cmd *cobra.Command, now time.Time) (*findCriteria, error) {
+	values := make(map[string]string)
+	for _, name := range []string{"name", "iname", "size", "mtime", "type", "hash"} {
+		value, err := cmd.Flags().GetString(name)
+		if err != nil {
+			return nil, fmt.Errorf("read --%s flag: %w", name, err)
+		}
+
+		values[name] = value
+	}
+
+	criteria := &findCriteria{
+		namePattern: values["name"],
+		hash:        strings.TrimSpace(values["hash"]),
+	}
+
+	if values["iname"] != "" {
+		criteria.namePattern = values["iname"]
+		criteria.nameInsensitive = true
+	}
+
+	kind, err := parseFindType(values["type"])
+	if err != nil {
+		return nil, err
+	}
+	criteria.kind = kind
+
+	if values["size"] != "" {
+		criteria.sizeCmp, criteria.size, err = parseFindSize(values["size"])
+		if err != nil {
+			return nil, err
+		}
+		criteria.sizeSet = true
+	}
+
+	if values["mtime"] != "" {
+		criteria.mtimeCmp, criteria.mtimeCutoff, err = parseFindAge(values["mtime"], now)
+		if err != nil {
+			return nil, err
+		}
+		criteria.mtimeSet = true
+	}
+
+	return criteria, nil
+}
+
+func findActionFlags(cmd *cobra.Command) (deleteMatches, dryRun, print0 bool, err error) {
+	if deleteMatches, err = cmd.Flags().GetBool("delete"); err != nil {
+		return false, false, false, fmt.Errorf("read --delete flag: %w", err)
+	}
+
+	if dryRun, err = cmd.Flags().GetBool("dry-run"); err != nil {
+		return false, false, false, fmt.Errorf("read --dry-run flag: %w", err)
+	}
+
+	if print0, err = cmd.Flags().GetBool("print0"); err != nil {
+		return false, false, false, fmt.Errorf("read --print0 flag: %w", err)
+	}
+
+	if print0 && mustCLIContext(cmd.Context()).Flags.JSON {
+		return false, false, false, fmt.Errorf("--print0 and --json are mutually exclusive")
+	}
+
+	if dryRun && !deleteMatches {
+		return false, false, false, fmt.Errorf("--dry-run only applies to --delete")
+	}
+
+	return deleteMatches, dryRun, print0, nil
+}
+
+// deleteFindMatches moves matched files to the recycle bin. Folder matches are
+// refused up front so a broad predicate can never recycle a whole subtree.
+func deleteFindMatches(
+	ctx context.Context,
+	cc *CLIContext,
+	session *driveops.MountSession,
+	matches []findMatch,
+	dryRun bool,
+) error {
+	for i := range matches {
+		if matches[i].entry.Item.IsFolder {
+			return fmt.Errorf("--delete matched folder %q — add --type f to delete only files", "/"+matches[i].path)
+		}
+	}
+
+	for i := range matches {
+		if dryRun {
+			matches[i].action = findActionWouldDelete
+			cc.Statusf("Would delete %s\n", "/"+matches[i].path)
+
+			continue
+		}
+
+		if err := session.DeleteResolvedPath(ctx, matches[i].path, matches[i].entry.Item.ID); err != nil {
+			return fmt.Errorf("deleting %q: %w", matches[i].path, err)
+		}
+
+		matches[i].action = findActionDeleted
+		cc.Statusf("Deleted %s (moved to recycle bin)\n", "/"+matches[i].path)
+	}
+
+	return nil
+}
+
+func printFindPaths(w io.Writer, matches []findMatch, print0 bool) error {
+	terminator := "\n"
+	if print0 {
+		terminator = "\x00"
+	}
+
+	for i := range matches {
+		if err := writef(w, "/%s%s", matches[i].path, terminator); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printFindJSON(w io.Writer, matches []findMatch) error {
+	out := make([]findJSONItem, 0, len(matches))
+	for i := range matches {
+		item := &matches[i].entry.Item
+		out = append(out, findJSONItem{
+			Path:         matches[i].path,
+			Type:         itemType(item),
+			Size:         item.Size,
+			ModifiedAt:   formatAPITime(item.ModifiedAt),
+			ID:           item.ID,
+			QuickXorHash: item.QuickXorHash,
+			Action:       matches[i].action,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encode find output: %w", err)
+	}
+
+	return nil
+}
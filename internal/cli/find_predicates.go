@@ -0,0 +1,270 @@
+package cli
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/config"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+const (
+	findCmpLess  = -1
+	findCmpEqual = 0
+	findCmpMore  = 1
+
+	findTypeFile = "f"
+	findTypeDir  = "d"
+)
+
+// findCriteria is the parsed set of find predicates. Every set predicate must
+// match (find's implicit -and).
+type findCriteria struct {
+	namePattern     string
+	nameInsensitive bool
+
+	sizeSet bool
+	sizeCmp int
+	size    int64
+
+	mtimeSet    bool
+	mtimeCmp    int
+	mtimeCutoff time.Time
+
+	kind string // "", typeFile, or typeFolder
+	hash string
+}
+
+func (c *findCriteria) match(item *graph.Item) bool {
+	return c.matchName(item.Name) &&
+		c.matchKind(item) &&
+		c.matchSize(item) &&
+		c.matchMtime(item) &&
+		c.matchHash(item)
+}
+
+func (c *findCriteria) matchName(name string) bool {
+	if c.namePattern == "" {
+		return true
+	}
+
+	if c.nameInsensitive {
+		ok, err := path.Match(strings.ToLower(c.namePattern), strings.ToLower(name))
+		return err == nil && ok
+	}
+
+	ok, err := path.Match(c.namePattern, name)
+
+	return err == nil && ok
+}
+
+func (c *findCriteria) matchKind(item *graph.Item) bool {
+	switch c.kind {
+	case typeFile:
+		return !item.IsFolder
+	case typeFolder:
+		return item.IsFolder
+	default:
+		return true
+	}
+}
+
+func (c *findCriteria) matchSize(item *graph.Item) bool {
+	if !c.sizeSet {
+		return true
+	}
+
+	// Folder sizes are recursive totals, not content; -size applies to files.
+	if item.IsFolder {
+		return false
+	}
+
+	return compareFind(item.Size, c.size) == c.sizeCmp
+}
+
+// matchMtime follows find(1): "-7d" means modified less than 7 days ago
+// (newer than the cutoff), "+7d" means at least 7 days ago, and "7" means
+// an age that rounds down to 7 whole days, so the day before the cutoff.
+// parseFindAge moves a bare "+7" cutoff to 8 days.
+func (c *findCriteria) matchMtime(item *graph.Item) bool {
+	if !c.mtimeSet {
+		return true
+	}
+
+	if item.ModifiedAt.IsZero() {
+		return false
+	}
+
+	switch c.mtimeCmp {
+	case findCmpLess:
+		return item.ModifiedAt.After(c.mtimeCutoff)
+	case findCmpMore:
+		return !item.ModifiedAt.After(c.mtimeCutoff)
+	default:
+		return !item.ModifiedAt.After(c.mtimeCutoff) && item.ModifiedAt.After(c.mtimeCutoff.Add(-time.Hour*hoursPerDay))
+	}
+}
+
+func (c *findCriteria) matchHash(item *graph.Item) bool {
+	if c.hash == "" {
+		return true
+	}
+
+	return item.QuickXorHash == c.hash ||
+		(item.SHA1Hash != "" && strings.EqualFold(item.SHA1Hash, c.hash)) ||
+		(item.SHA256Hash != "" && strings.EqualFold(item.SHA256Hash, c.hash))
+}
+
+func compareFind(value, limit int64) int {
+	switch {
+	case value < limit:
+		return findCmpLess
+	case value > limit:
+		return findCmpMore
+	default:
+		return findCmpEqual
+	}
+}
+
+// splitFindSign strips a leading '+' or '-' and returns the comparison it
+// selects, mirroring find(1)'s numeric argument convention.
+func splitFindSign(raw string) (int, string) {
+	switch {
+	case strings.HasPrefix(raw, "+"):
+		return findCmpMore, raw[1:]
+	case strings.HasPrefix(raw, "-"):
+		return findCmpLess, raw[1:]
+	default:
+		return findCmpEqual, raw
+	}
+}
+
+// parseFindSize parses find-style sizes such as "+100M", "-1G" or "4096".
+// Single-letter K/M/G/T suffixes are binary units like find(1); any suffix
+// accepted by the config size parser (e.g. "10MB", "1GiB") also works.
+func parseFindSize(raw string) (int, int64, error) {
+	cmp, rest := splitFindSign(strings.TrimSpace(raw))
+	if rest == "" {
+		return 0, 0, fmt.Errorf("invalid --size %q", raw)
+	}
+
+	switch strings.ToUpper(rest[len(rest)-1:]) {
+	case "K", "M", "G", "T":
+		rest += "iB"
+	}
+
+	size, err := config.ParseSize(rest)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --size %q: %w", raw, err)
+	}
+
+	return cmp, size, nil
+}
+
+// parseFindAge parses find-style ages such as "-7d", "+12h" or "1h30m" and
+// returns the comparison plus the cutoff time relative to now. A bare number,
+// 0 included, is whole days, like find -mtime; other units follow the pause
+// duration syntax.
+func parseFindAge(raw string, now time.Time) (int, time.Time, error) {
+	cmp, rest := splitFindSign(strings.TrimSpace(raw))
+	if days, err := strconv.Atoi(rest); err == nil && days >= 0 {
+		// find -mtime counts whole days of age, so +N is an age of at least
+		// N+1 days and never overlaps the exact-N window.
+		if cmp == findCmpMore {
+			days++
+		}
+
+		return cmp, now.Add(-time.Duration(days) * time.Hour * hoursPerDay), nil
+	}
+
+	age, err := parseDuration(rest)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("invalid --mtime %q: %w", raw, err)
+	}
+
+	return cmp, now.Add(-age), nil
+}
+
+func parseFindType(raw string) (string, error) {
+	switch raw {
+	case "":
+		return "", nil
+	case findTypeFile, typeFile:
+		return typeFile, nil
+	case findTypeDir, typeFolder:
+		return typeFolder, nil
+	default:
+		return "", fmt.Errorf("invalid --type %q: must be f or d", raw)
+	}
+}
+
+// findStyleFlags are the find(1) single-dash spellings accepted for the find
+// command's long flags.
+func findStyleFlags() map[string]string {
+	return map[string]string{
+		"-name":   "--name",
+		"-iname":  "--iname",
+		"-size":   "--size",
+		"-mtime":  "--mtime",
+		"-type":   "--type",
+		"-hash":   "--hash",
+		"-print0": "--print0",
+		"-delete": "--delete",
+	}
+}
+
+// normalizeFindStyleArgs rewrites find(1)-style single-dash predicates
+// ("-name '*.tmp'") to the double-dash flags cobra understands. It only acts
+// when root resolves args to the find command, and only on the arguments
+// after the word that named it, so a "find" that is a flag value, a
+// positional argument of another command or part of a completion request is
+// left alone. The argument right after a rewritten value flag is kept even if
+// it looks like a flag.
+func normalizeFindStyleArgs(root *cobra.Command, args []string) []string {
+	found, rest, err := root.Find(args)
+	if err != nil || found.Name() != "find" || found.Parent() != root {
+		return args
+	}
+
+	// Find drops the command word from the arguments; the word whose removal
+	// gives rest is the one cobra resolved.
+	findIdx := -1
+	for i, arg := range args {
+		if arg == "find" && slices.Equal(slices.Concat(args[:i], args[i+1:]), rest) {
+			findIdx = i
+			break
+		}
+	}
+
+	if findIdx < 0 {
+		return args
+	}
+
+	styles := findStyleFlags()
+	out := make([]string, len(args))
+	copy(out, args)
+
+	for i := findIdx + 1; i < len(out); i++ {
+		if out[i] == "--" {
+			break
+		}
+
+		long, ok := styles[out[i]]
+		if !ok {
+			continue
+		}
+
+		out[i] = long
+		if long != "--print0" && long != "--delete" {
+			i++
+		}
+	}
+
+	return out
+}
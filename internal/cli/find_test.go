@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+func TestNewFindCmd_Structure(t *testing.T) {
+	t.Parallel()
+
+	cmd := newFindCmd()
+	assert.Equal(t, "find <remote-path>", cmd.Use)
+	for _, name := range []string{"name", "iname", "size", "mtime", "type", "hash", "print0", "delete", "dry-run"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), name)
+	}
+}
+
+// Validates: R-1.12.1
+func TestParseFindSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw      string
+		wantCmp  int
+		wantSize int64
+	}{
+		{"+100M", findCmpMore, 100 << 20},
+		{"-1K", findCmpLess, 1 << 10},
+		{"4096", findCmpEqual, 4096},
+		{"+2GiB", findCmpMore, 2 << 30},
+		{"+10MB", findCmpMore, 10_000_000},
+	}
+
+	for _, tt := range tests {
+		cmp, size, err := parseFindSize(tt.raw)
+		require.NoError(t, err, tt.raw)
+		assert.Equal(t, tt.wantCmp, cmp, tt.raw)
+		assert.Equal(t, tt.wantSize, size, tt.raw)
+	}
+
+	_, _, err := parseFindSize("+")
+	require.Error(t, err)
+	_, _, err = parseFindSize("+lots")
+	require.Error(t, err)
+}
+
+// Validates: R-1.12.1
+func TestParseFindAge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	cmp, cutoff, err := parseFindAge("-7d", now)
+	require.NoError(t, err)
+	assert.Equal(t, findCmpLess, cmp)
+	assert.Equal(t, now.Add(-7*24*time.Hour), cutoff)
+
+	cmp, cutoff, err = parseFindAge("+3", now)
+	require.NoError(t, err)
+	assert.Equal(t, findCmpMore, cmp)
+	assert.Equal(t, now.Add(-4*24*time.Hour), cutoff, "+3 days is an age of at least 4 whole days")
+
+	_, cutoff, err = parseFindAge("+12h", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-12*time.Hour), cutoff)
+
+	_, cutoff, err = parseFindAge("1h30m", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-90*time.Minute), cutoff)
+
+	_, _, err = parseFindAge("-soon", now)
+	require.Error(t, err)
+}
+
+// Validates: R-1.12.1
+func TestFindCriteria_Match(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	file := &graph.Item{
+		Name: "Report.TMP", Size: 200 << 20, ModifiedAt: now.Add(-2 * 24 * time.Hour), QuickXorHash: "qxh==",
+	}
+	folder := &graph.Item{Name: "tmp", IsFolder: true, Size: 500 << 20, ModifiedAt: now}
+
+	assert.True(t, (&findCriteria{namePattern: "*.tmp", nameInsensitive: true}).match(file))
+	assert.False(t, (&findCriteria{namePattern: "*.tmp"}).match(file))
+	assert.True(t, (&findCriteria{sizeSet: true, sizeCmp: findCmpMore, size: 100 << 20}).match(file))
+	assert.False(t, (&findCriteria{sizeSet: true, sizeCmp: findCmpMore, size: 100 << 20}).match(folder),
+		"size predicates only apply to files")
+	assert.True(t, (&findCriteria{mtimeSet: true, mtimeCmp: findCmpLess, mtimeCutoff: now.Add(-7 * 24 * time.Hour)}).match(file))
+	assert.False(t, (&findCriteria{mtimeSet: true, mtimeCmp: findCmpMore, mtimeCutoff: now.Add(-7 * 24 * time.Hour)}).match(file))
+	assert.True(t, (&findCriteria{kind: typeFolder}).match(folder))
+	assert.False(t, (&findCriteria{kind: typeFolder}).match(file))
+	assert.True(t, (&findCriteria{hash: "qxh=="}).match(file))
+	assert.False(t, (&findCriteria{hash: "QXH=="}).match(file), "QuickXorHash is base64 and case-sensitive")
+}
+
+// Validates: R-1.12.1
+func TestFindCriteria_ExactMtimeCountsWholeDaysOfAge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 2, 0, 30, 0, 0, time.UTC)
+	exact := func(days string) *findCriteria {
+		cmp, cutoff, err := parseFindAge(days, now)
+		require.NoError(t, err)
+
+		return &findCriteria{mtimeSet: true, mtimeCmp: cmp, mtimeCutoff: cutoff}
+	}
+	modified := func(ago time.Duration) *graph.Item {
+		return &graph.Item{Name: "a.txt", ModifiedAt: now.Add(-ago)}
+	}
+
+	assert.True(t, exact("0").match(modified(90*time.Minute)), "yesterday's date, but less than a day old")
+	assert.False(t, exact("1").match(modified(90*time.Minute)))
+	assert.False(t, exact("1").match(modified(23*time.Hour+30*time.Minute)), "the same UTC date as the cutoff")
+	assert.True(t, exact("1").match(modified(24*time.Hour)))
+	assert.True(t, exact("1").match(modified(47*time.Hour)))
+	assert.False(t, exact("1").match(modified(48*time.Hour)))
+
+	assert.True(t, exact("7").match(modified(7*24*time.Hour+12*time.Hour)))
+	assert.False(t, exact("+7").match(modified(7*24*time.Hour+12*time.Hour)), "7.5 days rounds down to 7, not more")
+	assert.False(t, exact("7").match(modified(8*24*time.Hour)))
+	assert.True(t, exact("+7").match(modified(8*24*time.Hour)))
+	assert.False(t, exact("+0").match(modified(90*time.Minute)), "+0 needs at least a whole day of age")
+	assert.True(t, exact("+0").match(modified(24*time.Hour)))
+}
+
+// Validates: R-1.12
+func TestNormalizeFindStyleArgs(t *testing.T) {
+	t.Parallel()
+
+	root := newRootCmd()
+
+	assert.Equal(t,
+		[]string{"--drive", "d", "find", "/Docs", "--name", "-weird-", "--type", "f", "--print0"},
+		normalizeFindStyleArgs(root, []string{"--drive", "d", "find", "/Docs", "-name", "-weird-", "-type", "f", "-print0"}),
+	)
+	assert.Equal(t,
+		[]string{"--drive", "find", "find", "/Docs", "--name", "x"},
+		normalizeFindStyleArgs(root, []string{"--drive", "find", "find", "/Docs", "-name", "x"}),
+		"a drive named find is not the command word",
+	)
+
+	for _, args := range [][]string{
+		{"ls", "-name"},
+		{"--drive", "find", "ls", "/Docs", "-name", "x"},
+		{"stat", "find", "-name"},
+		{"__complete", "find", "/Docs", "-name", "x"},
+	} {
+		assert.Equal(t, args, normalizeFindStyleArgs(root, args), "only the find command's arguments are rewritten")
+	}
+}
+
+func newFindTestContext(t *testing.T, stdout, stderr *bytes.Buffer, deleted *[]string) *CLIContext {
+	t.Helper()
+
+	return newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch {
+			case r.Method == http.MethodDelete:
+				*deleted = append(*deleted, r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+			case r.URL.Path == "/drives/0000000drive-123/root:/Docs:":
+				writeTestResponse(t, w, `{"id":"docs","name":"Docs","folder":{},"parentReference":{"id":"root","path":"/drive/root:"}}`)
+			case r.URL.Path == "/drives/0000000drive-123/items/docs/delta":
+				writeTestResponse(t, w, `{"value":[
+					{"id":"docs","name":"Docs","folder":{},"parentReference":{"id":"root"}},
+					{"id":"old","name":"old.tmp","size":10,"file":{},"parentReference":{"id":"docs"}},
+					{"id":"sub","name":"Sub","folder":{},"parentReference":{"id":"docs"}},
+					{"id":"new","name":"b.tmp","size":20,"file":{},"parentReference":{"id":"sub"}},
+					{"id":"keep","name":"keep.txt","size":30,"file":{},"parentReference":{"id":"sub"}}
+				],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`)
+			default:
+				// Parent visibility probes after delete resolve to the Docs folder.
+				writeTestResponse(t, w, `{"id":"docs","name":"Docs","folder":{},"parentReference":{"id":"root","path":"/drive/root:"}}`)
+			}
+		}),
+		stdout,
+		stderr,
+	)
+}
+
+// Validates: R-1.12
+func TestRunFind_PrintsMatchingPaths(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var deleted []string
+	cc := newFindTestContext(t, &stdout, &stderr, &deleted)
+
+	cmd := newFindCmd()
+	cmd.SetArgs([]string{"/Docs", "--name", "*.tmp"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "/Docs/Sub/b.tmp\n/Docs/old.tmp\n", stdout.String())
+	assert.Empty(t, deleted)
+}
+
+// Validates: R-1.12.2
+func TestRunFind_DeleteDryRunDoesNotDelete(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var deleted []string
+	cc := newFindTestContext(t, &stdout, &stderr, &deleted)
+	cc.Flags.JSON = true
+
+	cmd := newFindCmd()
+	cmd.SetArgs([]string{"/Docs", "--name", "*.tmp", "--delete", "--dry-run"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+	assert.Empty(t, deleted)
+
+	var out []findJSONItem
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out, 2)
+	assert.Equal(t, findActionWouldDelete, out[0].Action)
+	assert.Contains(t, stderr.String(), "Would delete /Docs/old.tmp")
+}
+
+// Validates: R-1.12.2
+func TestRunFind_DeleteRecyclesMatchedFiles(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var deleted []string
+	cc := newFindTestContext(t, &stdout, &stderr, &deleted)
+
+	cmd := newFindCmd()
+	cmd.SetArgs([]string{"/Docs", "--name", "*.tmp", "--delete"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+	assert.ElementsMatch(t, []string{
+		"/drives/0000000drive-123/items/new",
+		"/drives/0000000drive-123/items/old",
+	}, deleted)
+}
+
+// Validates: R-1.12.2
+func TestRunFind_DeleteRefusesFolderMatches(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var deleted []string
+	cc := newFindTestContext(t, &stdout, &stderr, &deleted)
+
+	cmd := newFindCmd()
+	cmd.SetArgs([]string{"/Docs", "--name", "S*", "--delete"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "matched folder")
+	assert.Empty(t, deleted)
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -24,12 +25,42 @@ const defaultDirPerm = 0o755
 const defaultDownloadConcurrency = 4
 
 func newGetCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "get <remote-path> [local-path]",
+	cmd := &cobra.Command{
+		Use:   "get <remote-path>... [local-path]",
 		Short: "Download a file or folder",
-		Args:  cobra.RangeArgs(1, 2),
-		RunE:  runGet,
+		Long: `Download a remote file or folder.
+
+With --tar, a remote folder is written to stdout as a tar archive instead,
+for example "onedrive-go get --tar /Projects/foo | tar x". Files download in
+parallel, but entries appear in path order with their modification times.
+
+--ignore-existing leaves every existing local file alone. --update leaves a
+local file alone when its size and QuickXorHash match the remote file, which
+makes re-running a folder download cheap; --checksum and --size-only change
+how --update compares. Skipped files are listed in the JSON output.
+
+Several sources, or a glob in the last component of a source as in
+get '/Photos/2024-*' ./, download into the last argument as a local folder,
+or into the current folder when the only argument is a glob. --dry-run only
+lists what would be downloaded.
+
+--convert pdf|html|jpg downloads files converted by OneDrive, saving
+report.docx as report.pdf; folders convert file by file, and files already
+in the target format, or of a type OneDrive cannot convert to it, download
+unchanged. A converted file whose new name is taken by another file in the
+same folder is reported and not downloaded. Converted output has no hash to
+verify, so only its size is checked.`,
+		Args:              cobra.MinimumNArgs(1),
+		RunE:              runGet,
+		ValidArgsFunction: completeRemotePaths(remoteCompletion{localFallback: true}),
 	}
+
+	cmd.Flags().Bool("tar", false, "stream a remote folder to stdout as a tar archive")
+	cmd.Flags().Bool("dry-run", false, "show what would be downloaded without downloading")
+	cmd.Flags().String("convert", "", "download files converted to this format (pdf, html or jpg)")
+	addTransferSkipFlags(cmd)
+
+	return cmd
 }
 
 // getJSONOutput is the JSON output schema for downloading a single file.
@@ -37,22 +68,64 @@ type getJSONOutput struct {
 	Path         string `json:"path"`
 	Size         int64  `json:"size"`
 	HashVerified bool   `json:"hash_verified"`
+	Skipped      string `json:"skipped,omitempty"`
 }
 
 // getFolderJSONOutput is the JSON output schema for downloading a folder.
 type getFolderJSONOutput struct {
-	Files          []getJSONOutput `json:"files"`
-	FoldersCreated int             `json:"folders_created"`
-	TotalSize      int64           `json:"total_size"`
-	Errors         []string        `json:"errors"`
+	Files          []getJSONOutput     `json:"files"`
+	Skipped        []skippedJSONOutput `json:"skipped"`
+	FoldersCreated int                 `json:"folders_created"`
+	TotalSize      int64               `json:"total_size"`
+	Errors         []string            `json:"errors"`
 }
 
 func runGet(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 	cc := mustCLIContext(ctx)
 
+	asTar, err := cmd.Flags().GetBool("tar")
+	if err != nil {
+		return fmt.Errorf("reading --tar flag: %w", err)
+	}
+
+	skip, err := readTransferSkipPolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("reading --dry-run flag: %w", err)
+	}
+
+	format, err := readConvertFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	if format != "" && (asTar || cc.SharedTarget != nil || skip.active()) {
+		return errors.New("get --convert does not combine with --tar, shared links, --update or --ignore-existing")
+	}
+
+	if (asTar || cc.SharedTarget != nil) && (dryRun || len(args) > 2) {
+		return errors.New("get --tar and shared-link downloads take one source and no --dry-run")
+	}
+
+	if asTar {
+		if skip.active() {
+			return errors.New("get --tar always archives every file; --update and --ignore-existing do not apply")
+		}
+
+		return runGetTar(cmd, args, cc)
+	}
+
 	if cc.SharedTarget != nil {
-		return runSharedGet(cmd, args, cc)
+		return runSharedGet(cmd, args, cc, skip)
+	}
+
+	if sources, localDir, ok := getSourceArgs(args); ok {
+		return runGetSources(cmd, cc, sources, localDir, getSourceOptions{skip: skip, format: format, dryRun: dryRun})
 	}
 
 	remotePath := args[0]
@@ -80,32 +153,37 @@ func runGet(cmd *cobra.Command, args []string) error {
 			localPath = args[1]
 		}
 
-		return downloadFolder(cmd, cc, session, remotePath, localPath)
+		if dryRun {
+			return planGet(cc, remotePath, localPath)
+		}
+
+		return downloadFolder(cmd, cc, session, remotePath, localPath, skip, newGetConversion(cc, session, format))
+	}
+
+	conv := newGetConversion(cc, session, format)
+	if conv != nil && !conv.applies(item) {
+		cc.Statusf("%s is not converted to %s; downloading it unchanged\n", item.Name, format)
 	}
 
-	localPath := item.Name
+	localPath := conv.localName(item)
 	if len(args) > 1 {
 		localPath = args[1]
 	}
 
-	// Parse min_free_space from config for disk space pre-check (R-6.2.6).
-	// Config is validated at load time, so ParseSize won't fail here;
-	// on error we leave minFree at 0 which disables the check (safe default).
-	var minFree int64
-	if cc.Cfg != nil && cc.Cfg.MinFreeSpace != "" {
-		if parsed, parseErr := config.ParseSize(cc.Cfg.MinFreeSpace); parseErr == nil {
-			minFree = parsed
-		}
+	if dryRun {
+		return planGet(cc, remotePath, localPath)
+	}
+
+	if reason, skipErr := skip.skipDownload(item, localPath); skipErr != nil || reason != "" {
+		return reportSkippedGet(cc, localPath, item.Size, reason, skipErr)
 	}
 
+	// Disk space pre-check floor from min_free_space (R-6.2.6).
 	tm := driveops.NewTransferManager(session.Transfer, session.Transfer, nil, logger,
-		driveops.WithDiskCheck(minFree, driveops.DiskAvailable),
+		driveops.WithDiskCheck(sharedMinFreeSpace(cc), driveops.DiskAvailable),
 	)
 
-	result, err := tm.DownloadToFile(ctx, session.DriveID, item.ID, localPath, driveops.DownloadOpts{
-		RemoteHash: item.QuickXorHash,
-		RemoteSize: item.Size,
-	})
+	result, err := downloadGetFile(ctx, tm, conv, session.DriveID, item, localPath)
 	if err != nil {
 		partialPath := localPath + ".partial"
 		if _, statErr := localpath.Stat(partialPath); statErr == nil {
@@ -131,6 +209,22 @@ func runGet(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// reportSkippedGet reports a single-file get that --update or
+// --ignore-existing left alone, or the error from deciding to.
+func reportSkippedGet(cc *CLIContext, localPath string, size int64, reason string, err error) error {
+	if err != nil {
+		return fmt.Errorf("comparing %s: %w", localPath, err)
+	}
+
+	if cc.Flags.JSON {
+		return printGetJSON(cc.Output(), getJSONOutput{Path: localPath, Size: size, Skipped: reason})
+	}
+
+	cc.Statusf("Skipped %s (%s)\n", localPath, reason)
+
+	return nil
+}
+
 // printGetJSON writes the get command's single-file JSON output to w.
 func printGetJSON(w io.Writer, out getJSONOutput) error {
 	enc := json.NewEncoder(w)
@@ -165,6 +259,43 @@ type downloadState struct {
 	total       int
 	childCache  map[string][]graph.Item // keyed by remote path
 	countErrors []string                // non-fatal errors from counting pass
+	skip        transferSkipPolicy
+	convert     *getConversion    // nil unless --convert is set
+	clashes     map[string]string // converted files whose local name is taken, by item ID
+}
+
+// skipExisting applies the --update/--ignore-existing policy to one file and
+// records a skip, or the error from comparing, in the result. It reports
+// whether the download should not run.
+func (s *downloadState) skipExisting(item *graph.Item, localPath string) bool {
+	reason, err := s.skip.skipDownload(item, localPath)
+	if err == nil && reason == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.result.Errors = append(s.result.Errors, fmt.Sprintf("comparing %s: %v", localPath, err))
+
+		return true
+	}
+
+	s.result.Skipped = append(s.result.Skipped, skippedJSONOutput{Path: localPath, Reason: reason})
+	s.done++
+
+	return true
+}
+
+// printGetFolderSummary prints the human-readable folder download summary.
+func printGetFolderSummary(cc *CLIContext, result *getFolderJSONOutput) {
+	cc.Statusf("Downloaded %d files, %d folders (%s)\n",
+		len(result.Files), result.FoldersCreated, formatSize(result.TotalSize))
+
+	if len(result.Skipped) > 0 {
+		cc.Statusf("Skipped %d existing files\n", len(result.Skipped))
+	}
 }
 
 func downloadFolder(
@@ -172,20 +303,46 @@ func downloadFolder(
 	cc *CLIContext,
 	session *driveops.MountSession,
 	remotePath, localPath string,
+	skip transferSkipPolicy,
+	conv *getConversion,
 ) error {
 	ctx := cmd.Context()
-	logger := cc.Logger
-
-	state := &downloadState{
-		childCache: make(map[string][]graph.Item),
-		sem:        make(chan struct{}, defaultDownloadConcurrency),
-	}
+	state := newDownloadState(skip)
+	state.convert = conv
 
 	// Pass 1: count files and cache directory listings.
 	if err := countRemoteFiles(ctx, session, remotePath, state); err != nil {
 		return err
 	}
 
+	// Propagate non-fatal counting errors to the result.
+	state.result.Errors = append(state.result.Errors, state.countErrors...)
+
+	for _, children := range state.childCache {
+		conv.addClashes(state.clashes, children)
+	}
+
+	// Pass 2: download recursively. Goroutines are bounded by the shared semaphore.
+	downloadRecursive(ctx, cc, session, newFolderTransferManager(cc, session), state, remotePath, localPath)
+	state.wg.Wait()
+
+	return finishFolderDownload(cc, state)
+}
+
+func newDownloadState(skip transferSkipPolicy) *downloadState {
+	return &downloadState{
+		childCache: make(map[string][]graph.Item),
+		clashes:    make(map[string]string),
+		sem:        make(chan struct{}, defaultDownloadConcurrency),
+		skip:       skip,
+	}
+}
+
+// newFolderTransferManager builds the transfer manager for folder downloads,
+// with resumable session storage and the configured free-space floor.
+func newFolderTransferManager(cc *CLIContext, session *driveops.MountSession) *driveops.TransferManager {
+	logger := cc.Logger
+
 	// Parse min_free_space from config for disk space pre-check (R-6.2.6).
 	// Config is validated at load time, so ParseSize won't fail here;
 	// on error we leave minFree at 0 which disables the check (safe default).
@@ -197,23 +354,20 @@ func downloadFolder(
 	}
 
 	store := driveops.NewSessionStore(config.DefaultDataDir(), logger)
-	tm := driveops.NewTransferManager(session.Transfer, session.Transfer, store, logger,
+
+	return driveops.NewTransferManager(session.Transfer, session.Transfer, store, logger,
 		driveops.WithDiskCheck(minFree, driveops.DiskAvailable),
 	)
+}
 
-	// Propagate non-fatal counting errors to the result.
-	state.result.Errors = append(state.result.Errors, state.countErrors...)
-
-	// Pass 2: download recursively. Goroutines are bounded by the shared semaphore.
-	downloadRecursive(ctx, cc, session, tm, state, remotePath, localPath)
-	state.wg.Wait()
-
+// finishFolderDownload prints the folder download result and fails when any
+// file could not be downloaded.
+func finishFolderDownload(cc *CLIContext, state *downloadState) error {
 	if cc.Flags.JSON {
 		return printGetFolderJSON(cc.Output(), state.result)
 	}
 
-	cc.Statusf("Downloaded %d files, %d folders (%s)\n",
-		len(state.result.Files), state.result.FoldersCreated, formatSize(state.result.TotalSize))
+	printGetFolderSummary(cc, &state.result)
 
 	if len(state.result.Errors) > 0 {
 		return fmt.Errorf("%d errors during download", len(state.result.Errors))
@@ -291,7 +445,15 @@ func downloadRecursive(
 		}
 
 		child := children[i]
-		childLocal := filepath.Join(localPath, child.Name)
+		childLocal := filepath.Join(localPath, state.convert.localName(&child))
+
+		if clash, ok := state.clashes[child.ID]; ok {
+			state.mu.Lock()
+			state.result.Errors = append(state.result.Errors, fmt.Sprintf("%s: %s", child.Name, clash))
+			state.mu.Unlock()
+
+			continue
+		}
 
 		state.wg.Add(1)
 
@@ -312,10 +474,11 @@ func downloadRecursive(
 
 			defer func() { <-state.sem }()
 
-			dlResult, dlErr := tm.DownloadToFile(ctx, session.DriveID, child.ID, childLocal, driveops.DownloadOpts{
-				RemoteHash: child.QuickXorHash,
-				RemoteSize: child.Size,
-			})
+			if state.skipExisting(&child, childLocal) {
+				return
+			}
+
+			dlResult, dlErr := downloadGetFile(ctx, tm, state.convert, session.DriveID, &child, childLocal)
 
 			state.mu.Lock()
 			defer state.mu.Unlock()
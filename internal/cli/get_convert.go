@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// getConversion carries --convert through a get: the target format and the
+// transfer manager that downloads through the conversion endpoint.
+type getConversion struct {
+	format string
+	tm     *driveops.TransferManager
+}
+
+// readConvertFormat returns the validated --convert format, or "" when the
+// flag is not set.
+func readConvertFormat(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString("convert")
+	if err != nil {
+		return "", fmt.Errorf("reading --convert flag: %w", err)
+	}
+
+	format = strings.ToLower(format)
+	switch format {
+	case "", "pdf", "html", "jpg":
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid --convert %q: want pdf, html or jpg", format)
+	}
+}
+
+func newGetConversion(cc *CLIContext, session *driveops.MountSession, format string) *getConversion {
+	if format == "" {
+		return nil
+	}
+
+	return &getConversion{
+		format: format,
+		tm: driveops.NewTransferManager(
+			driveops.NewConvertingDownloader(session.Transfer, format), session.Transfer, nil, cc.Logger,
+			driveops.WithDiskCheck(sharedMinFreeSpace(cc), driveops.DiskAvailable),
+		),
+	}
+}
+
+// Source extensions the conversion endpoint accepts for each target format,
+// per the Graph documentation for the content endpoint's format parameter.
+const (
+	pdfSourceExtensions = "doc docx dot dotm dotx eml epub htm html markdown md msg odp ods odt pps ppsx ppt pptx " +
+		"rtf tif tiff xls xlsm xlsx"
+	htmlSourceExtensions = "eml fluid loop markdown md msg wbtx"
+	jpgSourceExtensions  = "3g2 3gp 3gp2 3gpp 3mf ai arw asf avi bas bash bat bmp c cbl cmd cool cpp cr2 crw cs css " +
+		"csv cur dcm dcm30 dic dicm dicom dng doc docx dwg eml epi eps epsf epsi epub erf fbx fppx gif glb h hcp " +
+		"heic heif htm html ico icon java jfif js json key log m2ts m4a m4v markdown md mef mov movie mp3 mp4 " +
+		"mp4v mrw msg mts nef nrw numbers obj odp odt ogg orf pages pano pdf pef php pict pl ply png pot potm " +
+		"potx pps ppsx ppsxm ppt pptm pptx ps ps1 psb psd py raw rb rtf rw1 rw2 sh sketch sql sr2 stl tif tiff " +
+		"ts txt vb webm wma wmv xaml xbm xcf xd xml xpm yaml yml"
+)
+
+// convertible reports whether OneDrive converts a file with this name to
+// format. Files already in the format, and files the service cannot convert,
+// download unchanged.
+func convertible(format, name string) bool {
+	var sources string
+
+	switch format {
+	case "pdf":
+		sources = pdfSourceExtensions
+	case "html":
+		sources = htmlSourceExtensions
+	case "jpg":
+		sources = jpgSourceExtensions
+	default:
+		return false
+	}
+
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), ".")
+
+	return ext != "" && slices.Contains(strings.Fields(sources), ext)
+}
+
+// applies reports whether a file is downloaded through the conversion
+// endpoint.
+func (gc *getConversion) applies(item *graph.Item) bool {
+	return gc != nil && !item.IsFolder && convertible(gc.format, item.Name)
+}
+
+// localName maps a remote name to the name its download is saved under, so
+// report.docx becomes report.pdf under --convert pdf.
+func (gc *getConversion) localName(item *graph.Item) string {
+	if !gc.applies(item) {
+		return item.Name
+	}
+
+	return strings.TrimSuffix(item.Name, filepath.Ext(item.Name)) + "." + gc.format
+}
+
+// addClashes records, by item ID, each converted file in entries whose local
+// name another entry also gets, as report.docx and report.pdf both saving
+// as report.pdf. entries are the items downloaded into one local folder;
+// the converted files named in clashes are reported instead of downloaded.
+func (gc *getConversion) addClashes(clashes map[string]string, entries []graph.Item) {
+	if gc == nil {
+		return
+	}
+
+	byName := make(map[string][]int, len(entries))
+	for i := range entries {
+		key := strings.ToLower(gc.localName(&entries[i]))
+		byName[key] = append(byName[key], i)
+	}
+
+	for _, indexes := range byName {
+		if len(indexes) < 2 {
+			continue
+		}
+
+		for _, i := range indexes {
+			if !gc.applies(&entries[i]) {
+				continue
+			}
+
+			others := make([]string, 0, len(indexes)-1)
+			for _, j := range indexes {
+				if j != i {
+					others = append(others, entries[j].Name)
+				}
+			}
+
+			clashes[entries[i].ID] = fmt.Sprintf("not downloaded: converting it to %s clashes with %s",
+				gc.localName(&entries[i]), strings.Join(others, ", "))
+		}
+	}
+}
+
+// downloadGetFile downloads one remote file to localPath: through the
+// conversion endpoint when conv applies, with hash verification otherwise.
+// Converted output has no remote hash to check; the graph client still
+// checks its size.
+func downloadGetFile(
+	ctx context.Context, tm *driveops.TransferManager, conv *getConversion,
+	driveID driveid.ID, item *graph.Item, localPath string,
+) (*driveops.DownloadResult, error) {
+	if conv.applies(item) {
+		return conv.tm.DownloadToFile(ctx, driveID, item.ID, localPath, driveops.DownloadOpts{Converted: true})
+	}
+
+	return tm.DownloadToFile(ctx, driveID, item.ID, localPath, driveops.DownloadOpts{
+		RemoteHash: item.QuickXorHash,
+		RemoteSize: item.Size,
+	})
+}
@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+const (
+	convertTestPDF     = "%PDF-1.7 converted report"
+	convertTestSummary = "%PDF-1.7 original summary"
+)
+
+// newConvertTestContext serves a Reports folder holding report.docx, which
+// converts to convertTestPDF, and summary.pdf, which only downloads as-is.
+func newConvertTestContext(t *testing.T, stdout, stderr *bytes.Buffer) *CLIContext {
+	t.Helper()
+
+	return newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/drives/0000000drive-123/items/report/content":
+				assert.Equal(t, "pdf", r.URL.Query().Get("format"))
+				writeTestResponse(t, w, convertTestPDF)
+			case r.URL.Path == "/dl/summary":
+				writeTestResponse(t, w, convertTestSummary)
+			case strings.HasSuffix(r.URL.Path, "/children"):
+				w.Header().Set("Content-Type", "application/json")
+				writeTestResponsef(t, w, `{"value":[
+					{"id":"report","name":"report.docx","size":4096,"file":{"hashes":{"quickXorHash":"docx-hash"}}},
+					{"id":"summary","name":"summary.pdf","size":%d,"file":{"hashes":{"quickXorHash":%q}}}
+				]}`, len(convertTestSummary), tarTestHash(convertTestSummary))
+			case r.URL.Path == "/drives/0000000drive-123/items/summary":
+				w.Header().Set("Content-Type", "application/json")
+				writeTestResponsef(t, w, `{"id":"summary","name":"summary.pdf","size":%d,
+					"file":{"hashes":{"quickXorHash":%q}},"@microsoft.graph.downloadUrl":"http://%s/dl/summary"}`,
+					len(convertTestSummary), tarTestHash(convertTestSummary), r.Host)
+			case r.URL.Path == "/drives/0000000drive-123/root:/Reports/report.docx:":
+				w.Header().Set("Content-Type", "application/json")
+				writeTestResponse(t, w, `{"id":"report","name":"report.docx","size":4096,
+					"file":{"hashes":{"quickXorHash":"docx-hash"}},"parentReference":{"id":"reports"}}`)
+			default:
+				w.Header().Set("Content-Type", "application/json")
+				writeTestResponse(t, w, `{"id":"reports","name":"Reports","folder":{},"parentReference":{"id":"root"}}`)
+			}
+		}),
+		stdout,
+		stderr,
+	)
+}
+
+// Validates: R-1.24.1, R-1.24.2
+func TestRunGet_ConvertFolderMapsNamesAndSkipsHash(t *testing.T) {
+	local := filepath.Join(t.TempDir(), "Reports")
+
+	var stdout, stderr bytes.Buffer
+	cc := newConvertTestContext(t, &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newGetCmd()
+	cmd.SetArgs([]string{"--convert", "PDF", "/Reports", local})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	data, err := os.ReadFile(filepath.Join(local, "report.pdf"))
+	require.NoError(t, err)
+	assert.Equal(t, convertTestPDF, string(data))
+
+	data, err = os.ReadFile(filepath.Join(local, "summary.pdf"))
+	require.NoError(t, err)
+	assert.Equal(t, convertTestSummary, string(data), "files already in the target format download unchanged")
+
+	var out getFolderJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Empty(t, out.Errors)
+	assert.ElementsMatch(t, []getJSONOutput{
+		{Path: filepath.Join(local, "report.pdf"), Size: int64(len(convertTestPDF))},
+		{Path: filepath.Join(local, "summary.pdf"), Size: int64(len(convertTestSummary)), HashVerified: true},
+	}, out.Files)
+}
+
+// Validates: R-1.24.1
+func TestRunCat_ConvertStreamsConvertedOutput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newConvertTestContext(t, &stdout, &stderr)
+
+	cmd := newCatCmd()
+	cmd.SetArgs([]string{"--convert", "pdf", "/Reports/report.docx"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, convertTestPDF, stdout.String())
+}
+
+// Validates: R-1.24.1
+func TestRunGet_ConvertRejectsUnsupportedCombinations(t *testing.T) {
+	for _, args := range [][]string{
+		{"--convert", "docx", "/Reports"},
+		{"--convert", "pdf", "--tar", "/Reports"},
+		{"--convert", "pdf", "--update", "/Reports"},
+	} {
+		var stdout, stderr bytes.Buffer
+		cc := newConvertTestContext(t, &stdout, &stderr)
+
+		cmd := newGetCmd()
+		cmd.SetArgs(args)
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+		require.Error(t, cmd.Execute(), args)
+	}
+}
+
+// Validates: R-1.24.1
+func TestConvertible_OnlyDocumentedSources(t *testing.T) {
+	assert.True(t, convertible("pdf", "Report.DOCX"))
+	assert.True(t, convertible("html", "notes.md"))
+	assert.True(t, convertible("jpg", "photo.heic"))
+	assert.False(t, convertible("pdf", "summary.pdf"), "already in the target format")
+	assert.False(t, convertible("jpg", "photo.jpeg"), "already in the target format")
+	assert.False(t, convertible("pdf", "archive.zip"))
+	assert.False(t, convertible("html", "report.docx"))
+	assert.False(t, convertible("pdf", "Makefile"))
+}
+
+// Validates: R-1.24.1, R-1.24.3
+func TestRunGet_ConvertReportsNameClashes(t *testing.T) {
+	const (
+		original = "%PDF-1.7 original report"
+		archive  = "zip bytes"
+	)
+
+	local := filepath.Join(t.TempDir(), "Reports")
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/drives/0000000drive-123/root:/Reports:":
+				w.Header().Set("Content-Type", "application/json")
+				writeTestResponse(t, w, `{"id":"reports","name":"Reports","folder":{},"parentReference":{"id":"root"}}`)
+			case "/drives/0000000drive-123/root:/Reports:/children":
+				w.Header().Set("Content-Type", "application/json")
+				writeTestResponsef(t, w, `{"value":[
+					{"id":"report-docx","name":"report.docx","size":4096,"file":{}},
+					{"id":"report-pdf","name":"report.pdf","size":%d,"file":{"hashes":{"quickXorHash":%q}}},
+					{"id":"archive","name":"archive.zip","size":%d,"file":{"hashes":{"quickXorHash":%q}}}
+				]}`, len(original), tarTestHash(original), len(archive), tarTestHash(archive))
+			case "/drives/0000000drive-123/items/report-pdf", "/drives/0000000drive-123/items/archive":
+				id := filepath.Base(r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				writeTestResponsef(t, w, `{"id":%q,"@microsoft.graph.downloadUrl":"http://%s/dl/%s"}`, id, r.Host, id)
+			case "/dl/report-pdf":
+				writeTestResponse(t, w, original)
+			case "/dl/archive":
+				writeTestResponse(t, w, archive)
+			default:
+				assert.Fail(t, "unexpected request", r.URL.String())
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+		&stdout,
+		&stderr,
+	)
+	cc.Flags.JSON = true
+
+	cmd := newGetCmd()
+	cmd.SetArgs([]string{"--convert", "pdf", "/Reports", local})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	var out getFolderJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out.Errors, 1)
+	assert.Contains(t, out.Errors[0], "report.docx: not downloaded: converting it to report.pdf clashes with report.pdf")
+
+	data, err := os.ReadFile(filepath.Join(local, "report.pdf"))
+	require.NoError(t, err)
+	assert.Equal(t, original, string(data), "the clashing conversion never overwrites the original")
+
+	data, err = os.ReadFile(filepath.Join(local, "archive.zip"))
+	require.NoError(t, err)
+	assert.Equal(t, archive, string(data), "files the service cannot convert download unchanged")
+}
@@ -14,7 +14,7 @@ import (
 	"github.com/tonimelisma/onedrive-go/internal/localpath"
 )
 
-func runSharedGet(cmd *cobra.Command, args []string, cc *CLIContext) error {
+func runSharedGet(cmd *cobra.Command, args []string, cc *CLIContext, skip transferSkipPolicy) error {
 	ctx := cmd.Context()
 
 	item, clients, err := cc.resolveSharedItem(ctx)
@@ -28,7 +28,7 @@ func runSharedGet(cmd *cobra.Command, args []string, cc *CLIContext) error {
 			localPath = args[1]
 		}
 
-		return downloadSharedFolder(ctx, cc, clients, item, localPath)
+		return downloadSharedFolder(ctx, cc, clients, item, localPath, skip)
 	}
 
 	localPath := item.Name
@@ -36,6 +36,10 @@ func runSharedGet(cmd *cobra.Command, args []string, cc *CLIContext) error {
 		localPath = args[1]
 	}
 
+	if reason, skipErr := skip.skipDownload(item, localPath); skipErr != nil || reason != "" {
+		return reportSkippedGet(cc, localPath, item.Size, reason, skipErr)
+	}
+
 	tm := driveops.NewTransferManager(
 		clients.Transfer,
 		clients.Transfer,
@@ -83,10 +87,12 @@ func downloadSharedFolder(
 	clients *driveops.AccountClients,
 	root *graph.Item,
 	localPath string,
+	skip transferSkipPolicy,
 ) error {
 	state := &downloadState{
 		childCache: make(map[string][]graph.Item),
 		sem:        make(chan struct{}, defaultDownloadConcurrency),
+		skip:       skip,
 	}
 
 	if err := countSharedFiles(
@@ -116,8 +122,7 @@ func downloadSharedFolder(
 		return printGetFolderJSON(cc.Output(), state.result)
 	}
 
-	cc.Statusf("Downloaded %d files, %d folders (%s)\n",
-		len(state.result.Files), state.result.FoldersCreated, formatSize(state.result.TotalSize))
+	printGetFolderSummary(cc, &state.result)
 
 	if len(state.result.Errors) > 0 {
 		return fmt.Errorf("%d errors during download", len(state.result.Errors))
@@ -191,6 +196,10 @@ func downloadSharedRecursive(
 				return
 			}
 
+			if state.skipExisting(&child, childLocalPath) {
+				return
+			}
+
 			result, err := tm.DownloadToFile(ctx, driveID, child.ID, childLocalPath, driveops.DownloadOpts{
 				RemoteHash: child.QuickXorHash,
 				RemoteSize: child.Size,
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// getSourceArgs splits get arguments that name several sources, or a glob,
+// into the sources and the local folder they download into. ok is false for
+// the plain "get <remote> [local]" form, where local is the target path
+// itself rather than a folder to download into.
+func getSourceArgs(args []string) (sources []string, localDir string, ok bool) {
+	sources, localDir = args, "."
+	if len(args) > 1 {
+		sources, localDir = args[:len(args)-1], args[len(args)-1]
+	}
+
+	if len(sources) > 1 {
+		return sources, localDir, true
+	}
+
+	if _, _, isGlob := splitRemoteGlob(sources[0]); isGlob {
+		return sources, localDir, true
+	}
+
+	return nil, "", false
+}
+
+// planGet reports a single-source get under --dry-run.
+func planGet(cc *CLIContext, remotePath, localPath string) error {
+	return printSourcePlan(cc, "download", []sourcePlanJSON{
+		{Source: remotePath, Destination: localPath, Action: planActionWouldDownload},
+	})
+}
+
+// planActionWouldDownload marks a source get --dry-run would download.
+const planActionWouldDownload = "would_download"
+
+// getSourceOptions carries the get flags that apply to every source.
+type getSourceOptions struct {
+	skip   transferSkipPolicy
+	format string // --convert target format, or ""
+	dryRun bool
+}
+
+// runGetSources downloads several remote sources into localDir. The sources
+// become the top level of one folder download, grouped by the folder they
+// were found in, so they share one concurrency limit and one summary.
+func runGetSources(
+	cmd *cobra.Command, cc *CLIContext, args []string, localDir string, opts getSourceOptions,
+) error {
+	dryRun := opts.dryRun
+	ctx := cmd.Context()
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	cc.Logger.Debug("get", "sources", args, "local_dir", localDir, "dry_run", dryRun)
+
+	sources, _, err := expandRemoteSources(ctx, session, args)
+	if err != nil {
+		return err
+	}
+
+	state := newDownloadState(opts.skip)
+	state.convert = newGetConversion(cc, session, opts.format)
+	parents := make([]string, 0, 1)
+	plan := make([]sourcePlanJSON, 0, len(sources))
+
+	for _, sourcePath := range sources {
+		item, err := session.ResolveItem(ctx, sourcePath)
+		if err != nil {
+			return fmt.Errorf("resolving %q: %w", sourcePath, err)
+		}
+
+		plan = append(plan, sourcePlanJSON{
+			Source: sourcePath, Destination: filepath.Join(localDir, state.convert.localName(item)), Action: planActionWouldDownload,
+		})
+
+		parent, _ := driveops.SplitParentAndName(sourcePath)
+		parent = driveops.CleanRemotePath(parent)
+
+		if _, seen := state.childCache[parent]; !seen {
+			parents = append(parents, parent)
+		}
+
+		state.childCache[parent] = append(state.childCache[parent], *item)
+
+		if !item.IsFolder {
+			state.total++
+		} else if !dryRun {
+			// Pass 1 for a folder source: count its files and cache its listings.
+			if err := countRemoteFiles(ctx, session, joinRemotePath(parent, item.Name), state); err != nil {
+				state.countErrors = append(state.countErrors, err.Error())
+			}
+		}
+	}
+
+	if dryRun {
+		return printSourcePlan(cc, "download", plan)
+	}
+
+	state.result.Errors = append(state.result.Errors, state.countErrors...)
+	findSourceClashes(state, parents)
+	tm := newFolderTransferManager(cc, session)
+
+	for _, parent := range parents {
+		downloadRecursive(ctx, cc, session, tm, state, parent, localDir)
+	}
+
+	state.wg.Wait()
+
+	// Every group creates localDir; count it once.
+	state.result.FoldersCreated -= len(parents) - 1
+
+	return finishFolderDownload(cc, state)
+}
+
+// findSourceClashes records the converted files whose local name is taken.
+// The sources all land in the destination folder, so they are checked
+// together; every folder listed below them is checked on its own.
+func findSourceClashes(state *downloadState, parents []string) {
+	if state.convert == nil {
+		return
+	}
+
+	var sources []graph.Item
+	for _, parent := range parents {
+		sources = append(sources, state.childCache[parent]...)
+	}
+
+	state.convert.addClashes(state.clashes, sources)
+
+	for remotePath, children := range state.childCache {
+		if !slices.Contains(parents, remotePath) {
+			state.convert.addClashes(state.clashes, children)
+		}
+	}
+}
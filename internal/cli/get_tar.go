@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/localpath"
+	"github.com/tonimelisma/onedrive-go/pkg/quickxorhash"
+)
+
+const (
+	tarDirMode  = 0o755
+	tarFileMode = 0o644
+)
+
+// tarStats counts what an archive contains.
+type tarStats struct {
+	Files     int
+	Folders   int
+	TotalSize int64
+	Skipped   int
+}
+
+// tarSpool is one file downloaded ahead of its turn in the archive.
+type tarSpool struct {
+	path string
+	err  error
+}
+
+func runGetTar(cmd *cobra.Command, args []string, cc *CLIContext) error {
+	if len(args) > 1 {
+		return errors.New("get --tar writes the archive to stdout; omit the local path")
+	}
+
+	if cc.SharedTarget != nil {
+		return errors.New("get --tar does not support shared targets; use drive add to mount the folder first")
+	}
+
+	ctx := cmd.Context()
+	remotePath := args[0]
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, entries, err := session.EnumerateFolder(ctx, remotePath)
+	if err != nil {
+		return fmt.Errorf("listing %q: %w", remotePath, err)
+	}
+
+	tw := tar.NewWriter(cc.Output())
+
+	stats, err := writeRemoteTar(ctx, session.Transfer, session.DriveID, entries, tw, defaultDownloadConcurrency, cc.Logger)
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("finishing tar stream: %w", err)
+	}
+
+	if stats.Skipped > 0 {
+		cc.Statusf("Skipped %d items without downloadable content\n", stats.Skipped)
+	}
+
+	cc.Statusf("Archived %d files, %d folders (%s)\n", stats.Files, stats.Folders, formatSize(stats.TotalSize))
+
+	return nil
+}
+
+// writeRemoteTar writes entries to tw in their path order. Up to concurrency
+// files are downloaded ahead into a private spool directory while earlier
+// entries are still being written, so the archive order never depends on
+// which download finishes first. Each spooled file is checked against its
+// QuickXorHash before it is archived.
+func writeRemoteTar(
+	ctx context.Context,
+	dl driveops.Downloader,
+	driveID driveid.ID,
+	entries []driveops.RemoteTreeEntry,
+	tw *tar.Writer,
+	concurrency int,
+	logger *slog.Logger,
+) (tarStats, error) {
+	var stats tarStats
+
+	spoolDir, err := localpath.MkdirTemp(os.TempDir(), "onedrive-go-tar-*")
+	if err != nil {
+		return stats, fmt.Errorf("creating tar spool directory: %w", err)
+	}
+
+	defer func() {
+		if removeErr := localpath.RemoveAll(spoolDir); removeErr != nil {
+			logger.Warn("removing tar spool directory", slog.String("path", spoolDir), slog.String("error", removeErr.Error()))
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	// spools[i] is non-nil only for entries that carry file content.
+	spools := make([]chan tarSpool, len(entries))
+	for i := range entries {
+		if tarHasContent(&entries[i]) {
+			spools[i] = make(chan tarSpool, 1)
+		}
+	}
+
+	// sem bounds the files downloaded but not yet archived. The producer
+	// acquires in entry order and the writer releases in entry order, so the
+	// writer's next entry has always been started.
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for i := range entries {
+			if spools[i] == nil {
+				continue
+			}
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				spools[i] <- spoolTarEntry(ctx, dl, driveID, &entries[i], spoolDir)
+			}(i)
+		}
+	}()
+
+	for i := range entries {
+		entry := &entries[i]
+
+		switch {
+		case entry.Item.IsFolder:
+			if err := writeTarDir(tw, entry); err != nil {
+				return stats, err
+			}
+
+			stats.Folders++
+		case spools[i] == nil:
+			logger.Debug("skipping tar entry without content", slog.String("path", entry.Path))
+			stats.Skipped++
+		default:
+			var spool tarSpool
+
+			select {
+			case spool = <-spools[i]:
+			case <-ctx.Done():
+				return stats, fmt.Errorf("writing tar stream: %w", ctx.Err())
+			}
+
+			if spool.err != nil {
+				return stats, spool.err
+			}
+
+			size, err := writeTarFile(tw, entry, spool.path)
+			if err != nil {
+				return stats, err
+			}
+
+			if removeErr := localpath.Remove(spool.path); removeErr != nil {
+				logger.Debug("removing tar spool file", slog.String("path", spool.path), slog.String("error", removeErr.Error()))
+			}
+
+			<-sem
+
+			stats.Files++
+			stats.TotalSize += size
+		}
+	}
+
+	return stats, nil
+}
+
+// tarHasContent reports whether an entry is a regular file whose bytes can be
+// downloaded. OneNote packages and shortcut placeholders have no content.
+func tarHasContent(entry *driveops.RemoteTreeEntry) bool {
+	item := &entry.Item
+
+	return !item.IsFolder && !item.IsPackage && !driveops.IsShortcut(item)
+}
+
+func spoolTarEntry(
+	ctx context.Context, dl driveops.Downloader, driveID driveid.ID, entry *driveops.RemoteTreeEntry, spoolDir string,
+) tarSpool {
+	f, err := localpath.CreateTemp(spoolDir, "entry-*")
+	if err != nil {
+		return tarSpool{err: fmt.Errorf("spooling %q: %w", entry.Path, err)}
+	}
+
+	h := quickxorhash.New()
+	_, dlErr := dl.Download(ctx, driveID, entry.Item.ID, io.MultiWriter(f, h))
+	closeErr := f.Close()
+
+	if dlErr != nil {
+		return tarSpool{err: fmt.Errorf("downloading %q: %w", entry.Path, dlErr)}
+	}
+
+	if closeErr != nil {
+		return tarSpool{err: fmt.Errorf("spooling %q: %w", entry.Path, closeErr)}
+	}
+
+	if want := entry.Item.QuickXorHash; want != "" {
+		if got := base64.StdEncoding.EncodeToString(h.Sum(nil)); got != want {
+			return tarSpool{err: fmt.Errorf("%w: %q expected %s, got %s", driveops.ErrContentHashMismatch, entry.Path, want, got)}
+		}
+	}
+
+	return tarSpool{path: f.Name()}
+}
+
+func writeTarDir(tw *tar.Writer, entry *driveops.RemoteTreeEntry) error {
+	hdr := &tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     entry.Path + "/",
+		Mode:     tarDirMode,
+		ModTime:  entry.Item.ModifiedAt,
+		Format:   tar.FormatPAX,
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("writing tar header for %q: %w", entry.Path, err)
+	}
+
+	return nil
+}
+
+// writeTarFile archives one spooled file. The header size comes from the
+// spool rather than item metadata, so the archive stays well-formed even if
+// the two disagree.
+func writeTarFile(tw *tar.Writer, entry *driveops.RemoteTreeEntry, spoolPath string) (int64, error) {
+	f, err := localpath.Open(spoolPath)
+	if err != nil {
+		return 0, fmt.Errorf("opening spooled %q: %w", entry.Path, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("stating spooled %q: %w", entry.Path, err)
+	}
+
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     entry.Path,
+		Size:     info.Size(),
+		Mode:     tarFileMode,
+		ModTime:  entry.Item.ModifiedAt,
+		Format:   tar.FormatPAX,
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return 0, fmt.Errorf("writing tar header for %q: %w", entry.Path, err)
+	}
+
+	n, err := io.Copy(tw, f)
+	if err != nil {
+		return n, fmt.Errorf("writing %q to tar stream: %w", entry.Path, err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,497 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/localpath"
+)
+
+func newHashCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hash <path>...",
+		Short: "Print QuickXorHash, SHA1 or SHA256 of local or remote files",
+		Long: `Print each file's hash the way Graph reports it: base64 QuickXorHash by
+default, or uppercase hex SHA1 or SHA256 with --sha1 or --sha256. Lines use
+the sha256sum layout, "<hash>  <path>", so the output can be kept as a
+manifest. Folders are hashed file by file with --recursive.
+
+--remote prints the hashes OneDrive stores for remote paths instead. SHA1 is
+only stored on personal accounts, and SHA256 only sometimes.
+
+--check <local-path> <remote-path> hashes the local side, compares it with
+the stored hashes, prints OK or FAILED for each file, and exits non-zero
+when any file does not match.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runHash,
+		// Hashing local files needs no account; runHash resolves the drive
+		// only for --remote and --check.
+		Annotations: map[string]string{skipConfigAnnotation: skipConfigValue},
+	}
+
+	cmd.Flags().Bool("sha1", false, "print SHA1 instead of QuickXorHash")
+	cmd.Flags().Bool("sha256", false, "print SHA256 instead of QuickXorHash")
+	cmd.Flags().Bool("remote", false, "print the hashes stored for remote paths")
+	cmd.Flags().Bool("check", false, "compare a local path with a remote path")
+	cmd.Flags().BoolP("recursive", "r", false, "hash every file below folder arguments")
+	cmd.MarkFlagsMutuallyExclusive("sha1", "sha256")
+	cmd.MarkFlagsMutuallyExclusive("remote", "check")
+
+	return cmd
+}
+
+// Outcomes of hash --check for one file.
+const (
+	hashStatusOK            = "ok"
+	hashStatusFailed        = "failed"
+	hashStatusNoHash        = "no_hash"
+	hashStatusMissingLocal  = "missing_local"
+	hashStatusMissingRemote = "missing_remote"
+)
+
+// hashJSONOutput is the JSON output schema for the hash command.
+type hashJSONOutput struct {
+	Algorithm string         `json:"algorithm"`
+	Files     []hashJSONFile `json:"files"`
+}
+
+// hashJSONFile is one hashed file. Hash is set when printing hashes;
+// LocalHash, RemoteHash and Status are set by --check.
+type hashJSONFile struct {
+	Path       string `json:"path"`
+	Hash       string `json:"hash,omitempty"`
+	LocalHash  string `json:"local_hash,omitempty"`
+	RemoteHash string `json:"remote_hash,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type hashOptions struct {
+	algorithm string
+	remote    bool
+	check     bool
+	recursive bool
+}
+
+func runHash(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	opts, err := hashFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	if opts.check && len(args) != 2 {
+		return fmt.Errorf("--check takes exactly two arguments: <local-path> <remote-path>")
+	}
+
+	if (opts.remote || opts.check) && cc.Cfg == nil && cc.SharedTarget == nil {
+		if err := initializeResolvedCLIContext(cmd, cc); err != nil {
+			return err
+		}
+	}
+
+	cc.Logger.Debug("hash", "paths", args, "algorithm", opts.algorithm,
+		"remote", opts.remote, "check", opts.check, "recursive", opts.recursive)
+
+	var files []hashJSONFile
+
+	switch {
+	case opts.check:
+		files, err = checkHashes(ctx, cc, args[0], args[1], opts)
+	case opts.remote:
+		files, err = remoteHashes(ctx, cc, args, opts)
+	default:
+		files = localHashes(args, opts)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if cc.Flags.JSON {
+		if err := printHashJSON(cc.Output(), hashJSONOutput{Algorithm: opts.algorithm, Files: files}); err != nil {
+			return err
+		}
+	} else if err := printHashLines(cc, files); err != nil {
+		return err
+	}
+
+	return hashResultError(files, opts.check)
+}
+
+func hashFlags(cmd *cobra.Command) (hashOptions, error) {
+	opts := hashOptions{algorithm: driveops.HashQuickXor}
+
+	for _, f := range []struct {
+		name string
+		dst  *bool
+	}{
+		{"remote", &opts.remote},
+		{"check", &opts.check},
+		{"recursive", &opts.recursive},
+	} {
+		v, err := cmd.Flags().GetBool(f.name)
+		if err != nil {
+			return opts, fmt.Errorf("reading --%s flag: %w", f.name, err)
+		}
+
+		*f.dst = v
+	}
+
+	for _, alg := range []string{driveops.HashSHA1, driveops.HashSHA256} {
+		v, err := cmd.Flags().GetBool(alg)
+		if err != nil {
+			return opts, fmt.Errorf("reading --%s flag: %w", alg, err)
+		}
+
+		if v {
+			opts.algorithm = alg
+		}
+	}
+
+	return opts, nil
+}
+
+// localHashes hashes every local argument. Failures are recorded per file so
+// one unreadable path does not hide the rest, as with sha256sum.
+func localHashes(args []string, opts hashOptions) []hashJSONFile {
+	files := make([]hashJSONFile, 0, len(args))
+
+	for _, arg := range args {
+		paths, err := localHashTargets(arg, opts.recursive)
+		if err != nil {
+			files = append(files, hashJSONFile{Path: arg, Error: err.Error()})
+
+			continue
+		}
+
+		for _, p := range paths {
+			h, err := driveops.ComputeHash(p, opts.algorithm)
+			if err != nil {
+				files = append(files, hashJSONFile{Path: p, Error: err.Error()})
+
+				continue
+			}
+
+			files = append(files, hashJSONFile{Path: p, Hash: h})
+		}
+	}
+
+	return files
+}
+
+// localHashTargets expands one argument into the files to hash.
+func localHashTargets(arg string, recursive bool) ([]string, error) {
+	info, err := localpath.Stat(arg)
+	if err != nil {
+		return nil, fmt.Errorf("stating %s: %w", arg, err)
+	}
+
+	if !info.IsDir() {
+		return []string{arg}, nil
+	}
+
+	if !recursive {
+		return nil, fmt.Errorf("%s is a folder (use --recursive)", arg)
+	}
+
+	tree, err := localMirrorTree(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(tree))
+	for _, key := range tree.sortedKeys() {
+		if e := tree[key]; !e.isDir {
+			paths = append(paths, filepath.Join(arg, filepath.FromSlash(e.path)))
+		}
+	}
+
+	return paths, nil
+}
+
+// remoteHashes prints the stored hashes. Nothing is downloaded.
+func remoteHashes(ctx context.Context, cc *CLIContext, args []string, opts hashOptions) ([]hashJSONFile, error) {
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]hashJSONFile, 0, len(args))
+
+	for _, arg := range args {
+		target, err := resolveRemoteHashTarget(ctx, session, arg, opts.recursive)
+		if err == nil && target.folder && !opts.recursive {
+			err = fmt.Errorf("%q is a folder (use --recursive)", "/"+target.root)
+		}
+
+		if err != nil {
+			files = append(files, hashJSONFile{Path: "/" + driveops.CleanRemotePath(arg), Error: err.Error()})
+
+			continue
+		}
+
+		for _, key := range target.tree.sortedKeys() {
+			e := target.tree[key]
+			if e.isDir {
+				continue
+			}
+
+			file := hashJSONFile{Path: "/" + joinRemotePath(target.root, e.path), Hash: driveops.ItemHash(e.item, opts.algorithm)}
+			if file.Hash == "" {
+				file.Error = noStoredHashError(opts.algorithm)
+			}
+
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// remoteHashTarget is a resolved remote argument: the files to hash, keyed
+// relative to root. A file argument is a tree of one entry named after the
+// file, with its parent folder as root. A folder is only enumerated when
+// asked, so callers can reject it first.
+type remoteHashTarget struct {
+	tree   mirrorTree
+	root   string
+	folder bool
+}
+
+func resolveRemoteHashTarget(
+	ctx context.Context, session *driveops.MountSession, arg string, enumerate bool,
+) (remoteHashTarget, error) {
+	remotePath := driveops.CleanRemotePath(arg)
+
+	item, err := session.ResolveItem(ctx, remotePath)
+	if err != nil {
+		return remoteHashTarget{}, fmt.Errorf("resolving %q: %w", "/"+remotePath, err)
+	}
+
+	if !item.IsFolder {
+		parent := ""
+		if i := strings.LastIndex(remotePath, "/"); i >= 0 {
+			parent = remotePath[:i]
+		}
+
+		tree := mirrorTree{}
+		tree.add(&mirrorEntry{path: item.Name, item: item})
+
+		return remoteHashTarget{tree: tree, root: parent}, nil
+	}
+
+	if !enumerate {
+		return remoteHashTarget{root: remotePath, folder: true}, nil
+	}
+
+	_, entries, err := session.EnumerateFolder(ctx, remotePath)
+	if err != nil {
+		return remoteHashTarget{}, fmt.Errorf("enumerating %q: %w", "/"+remotePath, err)
+	}
+
+	return remoteHashTarget{tree: remoteMirrorTree(entries), root: remotePath, folder: true}, nil
+}
+
+// checkHashes compares a local file or folder with its remote counterpart.
+// Files are matched by path the way mirror and diff match them; a file on
+// one side only is a mismatch.
+func checkHashes(ctx context.Context, cc *CLIContext, localArg, remoteArg string, opts hashOptions) ([]hashJSONFile, error) {
+	info, err := localpath.Stat(localArg)
+	if err != nil {
+		return nil, fmt.Errorf("stating %s: %w", localArg, err)
+	}
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() && !opts.recursive {
+		return nil, fmt.Errorf("%s is a folder (use --recursive)", localArg)
+	}
+
+	remote, err := resolveRemoteHashTarget(ctx, session, remoteArg, info.IsDir())
+	if err != nil {
+		return nil, err
+	}
+
+	if remote.folder != info.IsDir() {
+		return nil, fmt.Errorf("cannot compare %s with %q: one is a file and the other a folder",
+			localArg, "/"+driveops.CleanRemotePath(remoteArg))
+	}
+
+	if !info.IsDir() {
+		// Compare the one file with the remote file, whatever either is named.
+		local := mirrorTree{}
+		for key := range remote.tree {
+			local[key] = &mirrorEntry{path: filepath.Base(localArg)}
+		}
+
+		return compareHashTrees(local, remote.tree, filepath.Dir(localArg), opts.algorithm), nil
+	}
+
+	local, err := localMirrorTree(localArg)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareHashTrees(local, remote.tree, localArg, opts.algorithm), nil
+}
+
+// compareHashTrees checks every file in the union of both trees.
+func compareHashTrees(local, remote mirrorTree, localRoot, algorithm string) []hashJSONFile {
+	keys := mirrorTree{}
+	for k, e := range remote {
+		keys[k] = e
+	}
+
+	for k, e := range local {
+		keys[k] = e
+	}
+
+	files := make([]hashJSONFile, 0, len(keys))
+
+	for _, key := range keys.sortedKeys() {
+		// Only files are checked; a folder where the other side has a file
+		// leaves that file missing its counterpart.
+		l, r := local[key], remote[key]
+		if l != nil && l.isDir {
+			l = nil
+		}
+
+		if r != nil && r.isDir {
+			r = nil
+		}
+
+		if l == nil && r == nil {
+			continue
+		}
+
+		file := hashJSONFile{Path: keys[key].path}
+
+		switch {
+		case l == nil:
+			file.Status = hashStatusMissingLocal
+		case r == nil:
+			file.Status = hashStatusMissingRemote
+		default:
+			file.RemoteHash = driveops.ItemHash(r.item, algorithm)
+			file.LocalHash, file.Status, file.Error = checkLocalHash(filepath.Join(localRoot, filepath.FromSlash(l.path)),
+				algorithm, file.RemoteHash)
+		}
+
+		files = append(files, file)
+	}
+
+	return files
+}
+
+func checkLocalHash(localPath, algorithm, remoteHash string) (localHash, status, errText string) {
+	localHash, err := driveops.ComputeHash(localPath, algorithm)
+
+	switch {
+	case err != nil:
+		return "", hashStatusFailed, err.Error()
+	case remoteHash == "":
+		return localHash, hashStatusNoHash, noStoredHashError(algorithm)
+	case localHash != remoteHash:
+		return localHash, hashStatusFailed, ""
+	default:
+		return localHash, hashStatusOK, ""
+	}
+}
+
+func noStoredHashError(algorithm string) string {
+	return fmt.Sprintf("no %s hash stored on the server", hashAlgorithmName(algorithm))
+}
+
+func hashAlgorithmName(algorithm string) string {
+	switch algorithm {
+	case driveops.HashSHA1:
+		return "SHA1"
+	case driveops.HashSHA256:
+		return "SHA256"
+	default:
+		return "QuickXorHash"
+	}
+}
+
+func printHashLines(cc *CLIContext, files []hashJSONFile) error {
+	for _, f := range files {
+		var err error
+
+		switch {
+		case f.Status != "":
+			err = writef(cc.Output(), "%s: %s\n", f.Path, hashCheckLabel(f))
+		case f.Error != "":
+			cc.Statusf("%s: %s\n", f.Path, f.Error)
+		default:
+			err = writef(cc.Output(), "%s  %s\n", f.Hash, f.Path)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hashCheckLabel(f hashJSONFile) string {
+	switch f.Status {
+	case hashStatusOK:
+		return "OK"
+	case hashStatusMissingLocal:
+		return "FAILED (missing locally)"
+	case hashStatusMissingRemote:
+		return "FAILED (missing remotely)"
+	}
+
+	if f.Error != "" {
+		return "FAILED (" + f.Error + ")"
+	}
+
+	return "FAILED"
+}
+
+// hashResultError turns per-file failures into the command's exit status.
+func hashResultError(files []hashJSONFile, check bool) error {
+	failed := 0
+
+	for _, f := range files {
+		if (check && f.Status != hashStatusOK) || (!check && f.Error != "") {
+			failed++
+		}
+	}
+
+	switch {
+	case failed == 0:
+		return nil
+	case check:
+		return fmt.Errorf("%d of %d files did not match", failed, len(files))
+	default:
+		return fmt.Errorf("%d files could not be hashed", failed)
+	}
+}
+
+// printHashJSON writes the hash command's JSON output to w.
+func printHashJSON(w io.Writer, out hashJSONOutput) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encode hash output: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+func runHashTestCommand(t *testing.T, handler http.Handler, jsonOutput bool, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+
+	var out, errOut bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), handler, &out, &errOut)
+	cc.Flags.JSON = jsonOutput
+
+	cmd := newHashCmd()
+	cmd.SetArgs(args)
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	err = cmd.Execute()
+
+	return out.String(), errOut.String(), err
+}
+
+// Validates: R-1.18, R-1.18.1
+func TestRunHash_LocalRecursiveManifest(t *testing.T) {
+	local := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(local, "sub"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "b.txt"), []byte("bee"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "sub", "a.txt"), []byte("hello world"), 0o600))
+
+	stdout, _, err := runHashTestCommand(t, http.NotFoundHandler(), false, "-r", local)
+	require.NoError(t, err)
+	assert.Equal(t,
+		tarTestHash("bee")+"  "+filepath.Join(local, "b.txt")+"\n"+
+			tarTestHash("hello world")+"  "+filepath.Join(local, "sub", "a.txt")+"\n",
+		stdout)
+
+	stdout, _, err = runHashTestCommand(t, http.NotFoundHandler(), false, "--sha1", filepath.Join(local, "sub", "a.txt"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stdout, "2AAE6C35C94FCFB415DBE95F408B9CE91EE846ED  "), stdout)
+}
+
+// Validates: R-1.18.1
+func TestRunHash_FolderNeedsRecursive(t *testing.T) {
+	local := t.TempDir()
+	file := filepath.Join(local, "a.txt")
+	require.NoError(t, os.WriteFile(file, []byte("a"), 0o600))
+
+	stdout, stderr, err := runHashTestCommand(t, http.NotFoundHandler(), false, local, file)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 files could not be hashed")
+	assert.Contains(t, stderr, "is a folder (use --recursive)")
+	assert.Contains(t, stdout, file, "the other arguments are still hashed")
+}
+
+// Validates: R-1.18.2
+func TestRunHash_RemotePrintsStoredHashes(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		assert.True(t, strings.HasSuffix(r.URL.Path, "root:/Docs/a.txt:"), r.URL.Path)
+		writeTestResponse(t, w, `{"id":"a","name":"a.txt","size":1,
+			"file":{"hashes":{"quickXorHash":"qx==","sha1Hash":"ABC123"}},"parentReference":{"id":"docs"}}`)
+	})
+
+	stdout, _, err := runHashTestCommand(t, handler, false, "--remote", "--sha1", "/Docs/a.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "ABC123  /Docs/a.txt\n", stdout)
+
+	stdout, _, err = runHashTestCommand(t, handler, true, "--remote", "--sha256", "Docs/a.txt")
+	require.Error(t, err)
+
+	var out hashJSONOutput
+	require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+	assert.Equal(t, hashJSONOutput{Algorithm: "sha256", Files: []hashJSONFile{
+		{Path: "/Docs/a.txt", Error: "no SHA256 hash stored on the server"},
+	}}, out)
+}
+
+// Validates: R-1.18.3
+func TestRunHash_CheckFolderReportsMismatches(t *testing.T) {
+	local := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(local, "same.txt"), []byte("same"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "edited.txt"), []byte("new!"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "local.txt"), []byte("x"), 0o600))
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if strings.HasSuffix(r.URL.Path, "/items/site/delta") {
+			writeTestResponsef(t, w, `{"value":[
+				{"id":"site","name":"Site","folder":{},"parentReference":{"id":"root"}},
+				{"id":"s","name":"same.txt","size":4,"file":{"hashes":{"quickXorHash":%q}},"parentReference":{"id":"site"}},
+				{"id":"e","name":"edited.txt","size":4,"file":{"hashes":{"quickXorHash":%q}},"parentReference":{"id":"site"}},
+				{"id":"r","name":"remote.txt","size":1,"file":{},"parentReference":{"id":"site"}}
+			],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`, tarTestHash("same"), tarTestHash("old!"))
+
+			return
+		}
+
+		writeTestResponse(t, w, `{"id":"site","name":"Site","folder":{},"parentReference":{"id":"root","path":"/drive/root:"}}`)
+	})
+
+	stdout, _, err := runHashTestCommand(t, handler, false, "--check", "-r", local, "/Site")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "3 of 4 files did not match")
+	assert.Equal(t, "edited.txt: FAILED\nlocal.txt: FAILED (missing remotely)\n"+
+		"remote.txt: FAILED (missing locally)\nsame.txt: OK\n", stdout)
+}
+
+// Validates: R-1.18.3
+func TestRunHash_CheckSingleFileIgnoresNames(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "copy.txt")
+	require.NoError(t, os.WriteFile(file, []byte("same"), 0o600))
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		writeTestResponsef(t, w, `{"id":"o","name":"original.txt","size":4,
+			"file":{"hashes":{"quickXorHash":%q}},"parentReference":{"id":"root"}}`, tarTestHash("same"))
+	})
+
+	stdout, _, err := runHashTestCommand(t, handler, true, "--check", file, "/original.txt")
+	require.NoError(t, err)
+
+	var out hashJSONOutput
+	require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+	require.Len(t, out.Files, 1)
+	assert.Equal(t, hashJSONFile{
+		Path: "copy.txt", LocalHash: tarTestHash("same"), RemoteHash: tarTestHash("same"), Status: hashStatusOK,
+	}, out.Files[0])
+}
@@ -1,26 +1,87 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"path"
 	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
 	"github.com/tonimelisma/onedrive-go/internal/graph"
 )
 
+// Sort keys for ls --sort.
+const (
+	lsSortName  = "name"
+	lsSortSize  = "size"
+	lsSortMtime = "mtime"
+)
+
 func newLsCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "ls [path]",
 		Short: "List files and folders",
-		Args:  cobra.MaximumNArgs(1),
-		RunE:  runLs,
+		Long: `List a remote folder. The last path component may be a glob, as in
+ls '/Reports/*.xlsx'. Names match without regard to case, a backslash
+escapes *, ? and [, and a pattern that matches nothing is tried as a
+literal name.
+
+-R lists the whole subtree with relative paths and --tree draws it as a
+tree; both read the subtree with one folder delta and accept --depth. -l
+adds each item's eTag and who shared it. Folders are listed before files,
+and --sort orders each group by name, size (largest first) or mtime (newest
+first); --reverse flips that order.`,
+		Args:              cobra.MaximumNArgs(1),
+		RunE:              runLs,
+		ValidArgsFunction: completeRemotePaths(remoteCompletion{maxArgs: 1, sharedArg: noSharedArg}),
 	}
+
+	cmd.Flags().BoolP("long", "l", false, "also show eTag and who shared each item")
+	cmd.Flags().BoolP("recursive", "R", false, "list the whole subtree")
+	cmd.Flags().Bool("tree", false, "draw the subtree as a tree")
+	cmd.Flags().Int("depth", 0, "with -R or --tree, descend at most N levels (0 = unlimited)")
+	cmd.Flags().String("sort", lsSortName, "sort by name, size or mtime")
+	cmd.Flags().BoolP("reverse", "r", false, "reverse the sort order")
+	cmd.MarkFlagsMutuallyExclusive("recursive", "tree")
+
+	return cmd
+}
+
+type lsOptions struct {
+	long      bool
+	recursive bool
+	tree      bool
+	depth     int
+	sortBy    string
+	reverse   bool
+}
+
+// subtree reports whether the listing needs every descendant, not just the
+// folder's children.
+func (o lsOptions) subtree() bool {
+	return o.recursive || o.tree
+}
+
+// lsNode is one listed item. path is relative to the listed folder, and
+// children are only filled for -R and --tree.
+type lsNode struct {
+	path     string
+	item     graph.Item
+	children []*lsNode
 }
 
 func runLs(cmd *cobra.Command, args []string) error {
+	opts, err := lsFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	remotePath := "/"
 	if len(args) > 0 {
 		remotePath = args[0]
@@ -34,39 +95,254 @@ func runLs(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	cc.Logger.Debug("ls", "path", remotePath)
+	cc.Logger.Debug("ls", "path", remotePath, "recursive", opts.recursive, "tree", opts.tree,
+		"depth", opts.depth, "sort", opts.sortBy, "reverse", opts.reverse)
 
-	items, err := session.ListChildren(ctx, remotePath)
+	nodes, err := listLsNodes(ctx, session, remotePath, opts)
 	if err != nil {
-		return fmt.Errorf("listing %q: %w", remotePath, err)
+		return err
 	}
 
 	if cc.Flags.JSON {
-		return printItemsJSON(cc.Output(), items)
+		return printItemsJSON(cc.Output(), nodes, opts)
+	}
+
+	if opts.tree {
+		return printLsTree(cc.Output(), nodes, opts)
 	}
 
-	return printItemsTable(cc.Output(), items)
+	return printItemsTable(cc.Output(), nodes, opts)
 }
 
-// lsJSONItem is the JSON output schema for a single item in ls output.
-type lsJSONItem struct {
-	Name       string `json:"name"`
-	Size       int64  `json:"size"`
-	IsFolder   bool   `json:"is_folder"`
-	ModifiedAt string `json:"modified_at"`
-	ID         string `json:"id"`
+func lsFlags(cmd *cobra.Command) (lsOptions, error) {
+	var opts lsOptions
+
+	for _, f := range []struct {
+		name string
+		dst  *bool
+	}{
+		{"long", &opts.long},
+		{"recursive", &opts.recursive},
+		{"tree", &opts.tree},
+		{"reverse", &opts.reverse},
+	} {
+		v, err := cmd.Flags().GetBool(f.name)
+		if err != nil {
+			return opts, fmt.Errorf("reading --%s flag: %w", f.name, err)
+		}
+
+		*f.dst = v
+	}
+
+	depth, err := cmd.Flags().GetInt("depth")
+	if err != nil {
+		return opts, fmt.Errorf("reading --depth flag: %w", err)
+	}
+
+	sortBy, err := cmd.Flags().GetString("sort")
+	if err != nil {
+		return opts, fmt.Errorf("reading --sort flag: %w", err)
+	}
+
+	opts.depth, opts.sortBy = depth, strings.ToLower(sortBy)
+
+	switch {
+	case opts.sortBy != lsSortName && opts.sortBy != lsSortSize && opts.sortBy != lsSortMtime:
+		return opts, fmt.Errorf("invalid --sort %q: want name, size or mtime", sortBy)
+	case depth < 0:
+		return opts, fmt.Errorf("--depth must not be negative")
+	case depth > 0 && !opts.subtree():
+		return opts, fmt.Errorf("--depth requires -R or --tree")
+	}
+
+	return opts, nil
 }
 
-func printItemsJSON(w io.Writer, items []graph.Item) error {
-	out := make([]lsJSONItem, 0, len(items))
+// listLsNodes lists remotePath, expanding a glob in its last component.
+func listLsNodes(ctx context.Context, session *driveops.MountSession, remotePath string, opts lsOptions) ([]*lsNode, error) {
+	parent, pattern, isGlob := splitRemoteGlob(remotePath)
+	if !isGlob {
+		entries, err := listLsEntries(ctx, session, remotePath, opts.subtree())
+		if err != nil {
+			return nil, err
+		}
+
+		return buildLsForest(entries, "", opts), nil
+	}
+
+	entries, err := listLsEntries(ctx, session, parent, opts.subtree())
+	if err != nil {
+		return nil, err
+	}
+
+	if nodes := buildLsForest(entries, pattern, opts); len(nodes) > 0 {
+		return nodes, nil
+	}
+
+	// A name such as "Photos [2024]" looks like a pattern but may be real.
+	entries, err = listLsEntries(ctx, session, remotePath, opts.subtree())
+	if errors.Is(err, graph.ErrNotFound) {
+		return nil, fmt.Errorf("no items match %q", remotePath)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buildLsForest(entries, "", opts), nil
+}
+
+// listLsEntries reads a folder's children, or with subtree its whole subtree
+// through one folder enumeration.
+func listLsEntries(
+	ctx context.Context, session *driveops.MountSession, remotePath string, subtree bool,
+) ([]driveops.RemoteTreeEntry, error) {
+	if subtree {
+		_, entries, err := session.EnumerateFolder(ctx, remotePath)
+		if err != nil {
+			return nil, fmt.Errorf("listing %q: %w", remotePath, err)
+		}
+
+		return entries, nil
+	}
+
+	items, err := session.ListChildren(ctx, remotePath)
+	if err != nil {
+		return nil, fmt.Errorf("listing %q: %w", remotePath, err)
+	}
+
+	entries := make([]driveops.RemoteTreeEntry, 0, len(items))
 	for i := range items {
-		out = append(out, lsJSONItem{
-			Name:       items[i].Name,
-			Size:       items[i].Size,
-			IsFolder:   items[i].IsFolder,
-			ModifiedAt: formatAPITime(items[i].ModifiedAt),
-			ID:         items[i].ID,
-		})
+		entries = append(entries, driveops.RemoteTreeEntry{Path: items[i].Name, Item: items[i]})
+	}
+
+	return entries, nil
+}
+
+// buildLsForest links entries into sorted trees. pattern, when set, keeps
+// only top-level entries whose name matches it, together with everything
+// below them; --depth drops anything deeper than the limit.
+func buildLsForest(entries []driveops.RemoteTreeEntry, pattern string, opts lsOptions) []*lsNode {
+	byPath := make(map[string]*lsNode, len(entries))
+
+	for i := range entries {
+		rel := entries[i].Path
+		if opts.depth > 0 && strings.Count(rel, "/") >= opts.depth {
+			continue
+		}
+
+		if pattern != "" && !matchRemoteGlob(pattern, strings.SplitN(rel, "/", 2)[0]) {
+			continue
+		}
+
+		byPath[rel] = &lsNode{path: rel, item: entries[i].Item}
+	}
+
+	roots := make([]*lsNode, 0)
+
+	for rel, n := range byPath {
+		parent := path.Dir(rel)
+		if parent == "." {
+			roots = append(roots, n)
+		} else if p := byPath[parent]; p != nil {
+			p.children = append(p.children, n)
+		}
+	}
+
+	sortLsNodes(roots, opts)
+
+	return roots
+}
+
+// sortLsNodes orders every level folders first, then by the sort key. Names
+// break ties, so listings are stable.
+func sortLsNodes(nodes []*lsNode, opts lsOptions) {
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := &nodes[i].item, &nodes[j].item
+		if a.IsFolder != b.IsFolder {
+			return a.IsFolder
+		}
+
+		if opts.reverse {
+			a, b = b, a
+		}
+
+		switch {
+		case opts.sortBy == lsSortSize && a.Size != b.Size:
+			return a.Size > b.Size
+		case opts.sortBy == lsSortMtime && !a.ModifiedAt.Equal(b.ModifiedAt):
+			return a.ModifiedAt.After(b.ModifiedAt)
+		default:
+			return a.Name < b.Name
+		}
+	})
+
+	for _, n := range nodes {
+		sortLsNodes(n.children, opts)
+	}
+}
+
+// flattenLsNodes returns the nodes in listing order: each folder directly
+// followed by its contents.
+func flattenLsNodes(nodes []*lsNode) []*lsNode {
+	out := make([]*lsNode, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, n)
+		out = append(out, flattenLsNodes(n.children)...)
+	}
+
+	return out
+}
+
+// lsJSONItem is the JSON output schema for a single item in ls output. Path
+// is set by -R; Children nests the subtree for --tree.
+type lsJSONItem struct {
+	Name          string       `json:"name"`
+	Path          string       `json:"path,omitempty"`
+	Size          int64        `json:"size"`
+	IsFolder      bool         `json:"is_folder"`
+	ModifiedAt    string       `json:"modified_at"`
+	ID            string       `json:"id"`
+	ETag          string       `json:"etag,omitempty"`
+	SharedByName  string       `json:"shared_by_name,omitempty"`
+	SharedByEmail string       `json:"shared_by_email,omitempty"`
+	Children      []lsJSONItem `json:"children,omitempty"`
+}
+
+func newLsJSONItem(n *lsNode, opts lsOptions) lsJSONItem {
+	out := lsJSONItem{
+		Name:          n.item.Name,
+		Size:          n.item.Size,
+		IsFolder:      n.item.IsFolder,
+		ModifiedAt:    formatAPITime(n.item.ModifiedAt),
+		ID:            n.item.ID,
+		ETag:          n.item.ETag,
+		SharedByName:  n.item.SharedOwnerName,
+		SharedByEmail: n.item.SharedOwnerEmail,
+	}
+
+	if opts.recursive {
+		out.Path = n.path
+	}
+
+	if opts.tree {
+		out.Children = make([]lsJSONItem, 0, len(n.children))
+		for _, c := range n.children {
+			out.Children = append(out.Children, newLsJSONItem(c, opts))
+		}
+	}
+
+	return out
+}
+
+func printItemsJSON(w io.Writer, nodes []*lsNode, opts lsOptions) error {
+	if !opts.tree {
+		nodes = flattenLsNodes(nodes)
+	}
+
+	out := make([]lsJSONItem, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, newLsJSONItem(n, opts))
 	}
 
 	enc := json.NewEncoder(w)
@@ -79,27 +355,47 @@ func printItemsJSON(w io.Writer, items []graph.Item) error {
 	return nil
 }
 
-func printItemsTable(w io.Writer, items []graph.Item) error {
-	// Sort: folders first, then alphabetical.
-	sort.Slice(items, func(i, j int) bool {
-		if items[i].IsFolder != items[j].IsFolder {
-			return items[i].IsFolder
-		}
+func printItemsTable(w io.Writer, nodes []*lsNode, opts lsOptions) error {
+	nameHeader := "NAME"
+	if opts.recursive {
+		nameHeader = "PATH"
+	}
 
-		return items[i].Name < items[j].Name
-	})
+	headers := []string{nameHeader, "SIZE", "MODIFIED"}
+	if opts.long {
+		headers = append(headers, "ETAG", "SHARED BY")
+	}
 
-	headers := []string{"NAME", "SIZE", "MODIFIED"}
-	rows := make([][]string, 0, len(items))
+	nodes = flattenLsNodes(nodes)
+	rows := make([][]string, 0, len(nodes))
 
-	for i := range items {
-		name := items[i].Name
-		if items[i].IsFolder {
+	for _, n := range nodes {
+		name := n.item.Name
+		if opts.recursive {
+			name = n.path
+		}
+
+		if n.item.IsFolder {
 			name += "/"
 		}
 
-		rows = append(rows, []string{name, formatSize(items[i].Size), formatTime(items[i].ModifiedAt)})
+		row := []string{name, formatSize(n.item.Size), formatTime(n.item.ModifiedAt)}
+		if opts.long {
+			row = append(row, n.item.ETag, sharedByLabel(&n.item))
+		}
+
+		rows = append(rows, row)
 	}
 
 	return printTable(w, headers, rows)
 }
+
+// sharedByLabel names who shared an item, or is empty for the user's own
+// items.
+func sharedByLabel(item *graph.Item) string {
+	if item.SharedOwnerName != "" {
+		return item.SharedOwnerName
+	}
+
+	return item.SharedOwnerEmail
+}
@@ -2,16 +2,35 @@ package cli
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
 	"github.com/tonimelisma/onedrive-go/internal/graph"
 )
 
+// lsTestNodes turns a flat folder listing into sorted ls nodes.
+func lsTestNodes(items []graph.Item, opts lsOptions) []*lsNode {
+	entries := make([]driveops.RemoteTreeEntry, 0, len(items))
+	for i := range items {
+		entries = append(entries, driveops.RemoteTreeEntry{Path: items[i].Name, Item: items[i]})
+	}
+
+	if opts.sortBy == "" {
+		opts.sortBy = lsSortName
+	}
+
+	return buildLsForest(entries, "", opts)
+}
+
 func TestPrintItemsTable(t *testing.T) {
 	items := []graph.Item{
 		{
@@ -29,16 +48,60 @@ func TestPrintItemsTable(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	require.NoError(t, printItemsTable(&buf, items))
+	require.NoError(t, printItemsTable(&buf, lsTestNodes(items, lsOptions{}), lsOptions{}))
 	output := buf.String()
 
 	// Headers should be present.
 	assert.Contains(t, output, "NAME")
 	assert.Contains(t, output, "SIZE")
 	assert.Contains(t, output, "MODIFIED")
+	assert.NotContains(t, output, "ETAG")
 	// Folders sort first and get a trailing slash.
-	assert.Contains(t, output, "docs/")
-	assert.Contains(t, output, "readme.txt")
+	assert.Less(t, strings.Index(output, "docs/"), strings.Index(output, "readme.txt"))
+}
+
+// Validates: R-1.1.5
+func TestPrintItemsTable_LongFormat(t *testing.T) {
+	items := []graph.Item{
+		{Name: "mine.txt", Size: 2048, ETag: "etag-1"},
+		{Name: "theirs.txt", Size: 1, ETag: "etag-2", SharedOwnerName: "Alice", SharedOwnerEmail: "alice@example.com"},
+	}
+	opts := lsOptions{long: true}
+
+	var buf bytes.Buffer
+	require.NoError(t, printItemsTable(&buf, lsTestNodes(items, opts), opts))
+	output := buf.String()
+
+	assert.Contains(t, output, "ETAG")
+	assert.Contains(t, output, "SHARED BY")
+	assert.Contains(t, output, "2.0 KB")
+	assert.Contains(t, output, "etag-1")
+	assert.Contains(t, output, "Alice")
+}
+
+// Validates: R-1.1.6
+func TestSortLsNodes(t *testing.T) {
+	base := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	items := []graph.Item{
+		{Name: "b.txt", Size: 10, ModifiedAt: base},
+		{Name: "a.txt", Size: 30, ModifiedAt: base.Add(-time.Hour)},
+		{Name: "c.txt", Size: 20, ModifiedAt: base.Add(time.Hour)},
+		{Name: "z", IsFolder: true},
+	}
+
+	names := func(opts lsOptions) []string {
+		var out []string
+		for _, n := range lsTestNodes(items, opts) {
+			out = append(out, n.item.Name)
+		}
+
+		return out
+	}
+
+	assert.Equal(t, []string{"z", "a.txt", "b.txt", "c.txt"}, names(lsOptions{sortBy: lsSortName}))
+	assert.Equal(t, []string{"z", "a.txt", "c.txt", "b.txt"}, names(lsOptions{sortBy: lsSortSize}))
+	assert.Equal(t, []string{"z", "c.txt", "b.txt", "a.txt"}, names(lsOptions{sortBy: lsSortMtime}))
+	assert.Equal(t, []string{"z", "b.txt", "c.txt", "a.txt"}, names(lsOptions{sortBy: lsSortSize, reverse: true}))
 }
 
 // --- printItemsJSON ---
@@ -51,7 +114,7 @@ func TestPrintItemsJSON(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	require.NoError(t, printItemsJSON(&buf, items))
+	require.NoError(t, printItemsJSON(&buf, lsTestNodes(items, lsOptions{}), lsOptions{}))
 	out := buf.String()
 
 	assert.Contains(t, out, `"file.txt"`)
@@ -68,7 +131,7 @@ func TestPrintItemsJSON_ZeroTimestampUsesEmptyString(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	require.NoError(t, printItemsJSON(&buf, items))
+	require.NoError(t, printItemsJSON(&buf, lsTestNodes(items, lsOptions{}), lsOptions{}))
 
 	var parsed []lsJSONItem
 	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
@@ -76,6 +139,129 @@ func TestPrintItemsJSON_ZeroTimestampUsesEmptyString(t *testing.T) {
 	assert.Empty(t, parsed[0].ModifiedAt, "unknown timestamps should not serialize as year 0001")
 }
 
+// --- runLs ---
+
+// lsTreeHandler serves a Site folder holding Reports/Q1.xlsx, Reports/old/q0.xlsx
+// and notes.txt, both as children listings and as a folder delta.
+func lsTreeHandler(t *testing.T) http.Handler {
+	t.Helper()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.Contains(r.URL.Path, "*"):
+			w.WriteHeader(http.StatusNotFound)
+			writeTestResponse(t, w, `{"error":{"code":"itemNotFound","message":"not found"}}`)
+		case strings.HasSuffix(r.URL.Path, "/items/site/delta"):
+			writeTestResponse(t, w, `{"value":[
+				{"id":"site","name":"Site","folder":{},"parentReference":{"id":"root"}},
+				{"id":"rep","name":"Reports","folder":{},"parentReference":{"id":"site"}},
+				{"id":"q1","name":"Q1.xlsx","size":300,"file":{},"parentReference":{"id":"rep"}},
+				{"id":"old","name":"old","folder":{},"parentReference":{"id":"rep"}},
+				{"id":"q0","name":"q0.xlsx","size":100,"file":{},"parentReference":{"id":"old"}},
+				{"id":"n","name":"notes.txt","size":5,"file":{},"parentReference":{"id":"site"}}
+			],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`)
+		case strings.HasSuffix(r.URL.Path, "root:/Site/Reports:/children"):
+			writeTestResponse(t, w, `{"value":[
+				{"id":"q1","name":"Q1.xlsx","size":300,"file":{},"parentReference":{"id":"rep"}},
+				{"id":"d","name":"draft.docx","size":7,"file":{},"parentReference":{"id":"rep"}},
+				{"id":"old","name":"old","folder":{},"parentReference":{"id":"rep"}}
+			]}`)
+		default:
+			writeTestResponse(t, w, `{"id":"site","name":"Site","folder":{},"parentReference":{"id":"root","path":"/drive/root:"}}`)
+		}
+	})
+}
+
+func runLsTestCommand(t *testing.T, handler http.Handler, jsonOutput bool, args ...string) (string, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), handler, &stdout, &stderr)
+	cc.Flags.JSON = jsonOutput
+
+	cmd := newLsCmd()
+	cmd.SetArgs(args)
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	err := cmd.Execute()
+
+	return stdout.String(), err
+}
+
+// Validates: R-1.1.4
+func TestRunLs_RecursiveListsRelativePathsWithDepth(t *testing.T) {
+	stdout, err := runLsTestCommand(t, lsTreeHandler(t), false, "-R", "/Site")
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 6)
+	assert.True(t, strings.HasPrefix(lines[0], "PATH"))
+
+	var paths []string
+	for _, line := range lines[1:] {
+		paths = append(paths, strings.Fields(line)[0])
+	}
+
+	assert.Equal(t, []string{"Reports/", "Reports/old/", "Reports/old/q0.xlsx", "Reports/Q1.xlsx", "notes.txt"}, paths)
+
+	stdout, err = runLsTestCommand(t, lsTreeHandler(t), false, "-R", "--depth", "1", "/Site")
+	require.NoError(t, err)
+	assert.NotContains(t, stdout, "Reports/Q1.xlsx")
+	assert.Contains(t, stdout, "notes.txt")
+}
+
+// Validates: R-1.1.4
+func TestRunLs_TreeDrawsAndNestsJSON(t *testing.T) {
+	stdout, err := runLsTestCommand(t, lsTreeHandler(t), false, "--tree", "/Site")
+	require.NoError(t, err)
+	assert.Equal(t, "├── Reports/\n"+
+		"│   ├── old/\n"+
+		"│   │   └── q0.xlsx\n"+
+		"│   └── Q1.xlsx\n"+
+		"└── notes.txt\n", stdout)
+
+	stdout, err = runLsTestCommand(t, lsTreeHandler(t), true, "--tree", "--depth", "2", "/Site")
+	require.NoError(t, err)
+
+	var parsed []lsJSONItem
+	require.NoError(t, json.Unmarshal([]byte(stdout), &parsed))
+	require.Len(t, parsed, 2)
+	assert.Equal(t, "Reports", parsed[0].Name)
+	require.Len(t, parsed[0].Children, 2)
+	assert.Equal(t, "old", parsed[0].Children[0].Name)
+	assert.Empty(t, parsed[0].Children[0].Children, "--depth 2 stops below old/")
+	assert.Equal(t, "Q1.xlsx", parsed[0].Children[1].Name)
+}
+
+// Validates: R-1.1.7
+func TestRunLs_GlobMatchesLastComponent(t *testing.T) {
+	stdout, err := runLsTestCommand(t, lsTreeHandler(t), true, "/Site/Reports/*.XLSX")
+	require.NoError(t, err)
+
+	var parsed []lsJSONItem
+	require.NoError(t, json.Unmarshal([]byte(stdout), &parsed))
+	require.Len(t, parsed, 1)
+	assert.Equal(t, "Q1.xlsx", parsed[0].Name)
+
+	_, err = runLsTestCommand(t, lsTreeHandler(t), false, "/Site/Reports/*.pdf")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no items match "/Site/Reports/*.pdf"`)
+}
+
+func TestRunLs_RejectsInvalidFlags(t *testing.T) {
+	_, err := runLsTestCommand(t, lsTreeHandler(t), false, "--depth", "2", "/Site")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--depth requires -R or --tree")
+
+	_, err = runLsTestCommand(t, lsTreeHandler(t), false, "--sort", "owner", "/Site")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --sort")
+
+	_, err = runLsTestCommand(t, lsTreeHandler(t), false, "-R", "--tree", "/Site")
+	require.Error(t, err)
+}
+
 // --- newLsCmd ---
 
 // Validates: R-1.1
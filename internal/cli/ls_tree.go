@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// printLsTree draws nodes as a tree, one item per line. -l appends the
+// size, modification time, eTag and sharer to each line.
+func printLsTree(w io.Writer, nodes []*lsNode, opts lsOptions) error {
+	return writeLsTreeLevel(w, nodes, "", opts)
+}
+
+func writeLsTreeLevel(w io.Writer, nodes []*lsNode, indent string, opts lsOptions) error {
+	for i, n := range nodes {
+		branch, next := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, next = "└── ", "    "
+		}
+
+		if err := writef(w, "%s%s%s\n", indent, branch, lsTreeLabel(n, opts)); err != nil {
+			return err
+		}
+
+		if err := writeLsTreeLevel(w, n.children, indent+next, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func lsTreeLabel(n *lsNode, opts lsOptions) string {
+	name := n.item.Name
+	if n.item.IsFolder {
+		name += "/"
+	}
+
+	if !opts.long {
+		return name
+	}
+
+	details := []string{formatSize(n.item.Size), formatTime(n.item.ModifiedAt)}
+	if n.item.ETag != "" {
+		details = append(details, n.item.ETag)
+	}
+
+	if by := sharedByLabel(&n.item); by != "" {
+		details = append(details, "shared by "+by)
+	}
+
+	return fmt.Sprintf("%s  [%s]", name, strings.Join(details, ", "))
+}
@@ -0,0 +1,426 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tonimelisma/onedrive-go/internal/config"
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+	"github.com/tonimelisma/onedrive-go/internal/localpath"
+)
+
+func newMirrorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mirror <local-dir> <remote-folder>",
+		Short: "Make a remote folder match a local folder, or the reverse",
+		Long: `Make the remote folder an exact copy of the local folder in one pass,
+without a sync_dir or sync state database. With --reverse the local folder
+is made to match the remote folder instead.
+
+Both sides are compared fresh on every run: a local walk against one delta
+enumeration of the remote folder. Files whose size and QuickXorHash already
+match are left alone; the rest are transferred in parallel. --delete also
+removes destination entries missing from the source (remote deletions go to
+the recycle bin). --dry-run prints the plan without changing anything.`,
+		Args: cobra.ExactArgs(2),
+		RunE: runMirror,
+	}
+
+	cmd.Flags().Bool("reverse", false, "make the local folder match the remote folder")
+	cmd.Flags().Bool("delete", false, "delete destination entries that are not in the source")
+	cmd.Flags().Bool("dry-run", false, "show what would change without changing anything")
+
+	return cmd
+}
+
+// mirrorJSONOutput is the JSON output schema for the mirror command. Actions
+// lists what was done, or what would be done under --dry-run.
+type mirrorJSONOutput struct {
+	Source      string             `json:"source"`
+	Destination string             `json:"destination"`
+	DryRun      bool               `json:"dry_run"`
+	Actions     []mirrorActionJSON `json:"actions"`
+	Unchanged   int                `json:"unchanged"`
+	Errors      []string           `json:"errors"`
+}
+
+// mirrorActionJSON is one step of a mirror, with its path relative to the
+// mirror roots.
+type mirrorActionJSON struct {
+	Action string `json:"action"`
+	Path   string `json:"path"`
+	Size   int64  `json:"size,omitempty"`
+}
+
+// mirrorRun carries one mirror execution. folderIDs maps lowercased relative
+// remote folder paths to item IDs so uploads and new folders find their
+// parent without another lookup; "" is the remote root.
+type mirrorRun struct {
+	cc         *CLIContext
+	session    *driveops.MountSession
+	tm         *driveops.TransferManager
+	localRoot  string
+	remoteRoot string
+	upload     bool
+	folderIDs  map[string]string
+
+	mu     sync.Mutex // guards result during parallel transfers
+	result mirrorJSONOutput
+}
+
+func runMirror(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	reverse, deleteExtra, dryRun, err := mirrorFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	if !reverse {
+		if err := requireWritableDrive(cc.Cfg, "mirror"); err != nil {
+			return err
+		}
+	}
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	run := &mirrorRun{
+		cc:         cc,
+		session:    session,
+		localRoot:  args[0],
+		remoteRoot: driveops.CleanRemotePath(args[1]),
+		upload:     !reverse,
+	}
+
+	cc.Logger.Debug("mirror", "local", run.localRoot, "remote", run.remoteRoot,
+		"reverse", reverse, "delete", deleteExtra, "dry_run", dryRun)
+
+	plan, err := run.plan(ctx, deleteExtra, dryRun)
+	if err != nil {
+		return err
+	}
+
+	run.result.Source, run.result.Destination = run.localRoot, "/"+run.remoteRoot
+	if reverse {
+		run.result.Source, run.result.Destination = run.result.Destination, run.result.Source
+	}
+
+	run.result.DryRun = dryRun
+	run.result.Unchanged = plan.unchanged
+	run.result.Actions = make([]mirrorActionJSON, 0, len(plan.ops()))
+	run.result.Errors = make([]string, 0)
+
+	if dryRun {
+		for _, op := range plan.ops() {
+			run.record(op)
+			cc.Statusf("Would %s %s\n", op.action, op.entry.path)
+		}
+	} else if err := run.execute(ctx, plan); err != nil {
+		return err
+	}
+
+	return run.report()
+}
+
+func mirrorFlags(cmd *cobra.Command) (reverse, deleteExtra, dryRun bool, err error) {
+	if reverse, err = cmd.Flags().GetBool("reverse"); err != nil {
+		return false, false, false, fmt.Errorf("reading --reverse flag: %w", err)
+	}
+
+	if deleteExtra, err = cmd.Flags().GetBool("delete"); err != nil {
+		return false, false, false, fmt.Errorf("reading --delete flag: %w", err)
+	}
+
+	if dryRun, err = cmd.Flags().GetBool("dry-run"); err != nil {
+		return false, false, false, fmt.Errorf("reading --dry-run flag: %w", err)
+	}
+
+	return reverse, deleteExtra, dryRun, nil
+}
+
+// plan snapshots both sides and compares them. For an upload into a remote
+// folder that does not exist yet, a dry run treats it as empty while a real
+// run creates it first.
+func (r *mirrorRun) plan(ctx context.Context, deleteExtra, dryRun bool) (*mirrorPlan, error) {
+	if r.upload {
+		if _, err := localpath.Stat(r.localRoot); err != nil {
+			return nil, fmt.Errorf("stating local folder: %w", err)
+		}
+	}
+
+	local, err := localMirrorTree(r.localRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteEntries, err := r.remoteSnapshot(ctx, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	remote := remoteMirrorTree(remoteEntries)
+
+	if r.upload {
+		return planMirror(local, remote, mirrorActionUpload, deleteExtra)
+	}
+
+	return planMirror(remote, local, mirrorActionDownload, deleteExtra)
+}
+
+func (r *mirrorRun) remoteSnapshot(ctx context.Context, dryRun bool) ([]driveops.RemoteTreeEntry, error) {
+	display := "/" + r.remoteRoot
+
+	if r.upload && !dryRun {
+		if _, err := ensureRemoteFolderPath(ctx, r.session, r.remoteRoot); err != nil {
+			return nil, err
+		}
+	}
+
+	root, entries, err := r.session.EnumerateFolder(ctx, r.remoteRoot)
+	if err != nil {
+		if r.upload && dryRun && errors.Is(err, graph.ErrNotFound) {
+			r.folderIDs = map[string]string{}
+
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("enumerating %q: %w", display, err)
+	}
+
+	r.folderIDs = map[string]string{"": root.ID}
+	for i := range entries {
+		if entries[i].Item.IsFolder {
+			r.folderIDs[mirrorKey(entries[i].Path)] = entries[i].Item.ID
+		}
+	}
+
+	return entries, nil
+}
+
+// execute runs the plan. Folder changes run in order; files transfer in
+// parallel. Per-entry failures are collected so one bad file does not stop
+// the rest, but cancellation stops the run.
+func (r *mirrorRun) execute(ctx context.Context, plan *mirrorPlan) error {
+	store := driveops.NewSessionStore(config.DefaultDataDir(), r.cc.Logger)
+	r.tm = driveops.NewTransferManager(r.session.Transfer, r.session.Transfer, store, r.cc.Logger,
+		driveops.WithDiskCheck(sharedMinFreeSpace(r.cc), driveops.DiskAvailable),
+	)
+
+	if !r.upload {
+		if err := localpath.MkdirAll(r.localRoot, defaultDirPerm); err != nil {
+			return fmt.Errorf("creating %s: %w", r.localRoot, err)
+		}
+	}
+
+	for _, op := range append(append([]mirrorOp(nil), plan.replaced...), plan.mkdirs...) {
+		r.apply(ctx, op)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(r.transferWorkers())
+
+	for _, op := range plan.transfers {
+		g.Go(func() error {
+			r.apply(gctx, op)
+
+			return gctx.Err()
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("mirror: %w", err)
+	}
+
+	for _, op := range plan.deletes {
+		r.apply(ctx, op)
+	}
+
+	return nil
+}
+
+func (r *mirrorRun) transferWorkers() int {
+	if r.cc.Cfg != nil && r.cc.Cfg.TransferWorkers > 0 {
+		return r.cc.Cfg.TransferWorkers
+	}
+
+	return defaultDownloadConcurrency
+}
+
+// apply runs one step and records its outcome.
+func (r *mirrorRun) apply(ctx context.Context, op mirrorOp) {
+	var err error
+
+	switch op.action {
+	case mirrorActionMkdir:
+		err = r.mkdir(ctx, op.entry)
+	case mirrorActionUpload:
+		err = r.uploadFile(ctx, op.entry)
+	case mirrorActionDownload:
+		err = r.downloadFile(ctx, op.entry)
+	case mirrorActionDelete:
+		err = r.deleteEntry(ctx, op.entry)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		r.result.Errors = append(r.result.Errors, fmt.Sprintf("%s %s: %v", op.action, op.entry.path, err))
+
+		return
+	}
+
+	r.recordLocked(op)
+}
+
+func (r *mirrorRun) record(op mirrorOp) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recordLocked(op)
+}
+
+func (r *mirrorRun) recordLocked(op mirrorOp) {
+	out := mirrorActionJSON{Action: op.action, Path: op.entry.path}
+	if !op.entry.isDir && op.action != mirrorActionDelete {
+		out.Size = op.entry.side.size
+	}
+
+	r.result.Actions = append(r.result.Actions, out)
+}
+
+func (r *mirrorRun) localPath(rel string) string {
+	return filepath.Join(r.localRoot, filepath.FromSlash(rel))
+}
+
+func (r *mirrorRun) parentFolderID(rel string) (string, error) {
+	parent := path.Dir(rel)
+	if parent == "." {
+		parent = ""
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.folderIDs[mirrorKey(parent)]
+	if !ok {
+		return "", fmt.Errorf("remote parent folder %q was not created", parent)
+	}
+
+	return id, nil
+}
+
+func (r *mirrorRun) mkdir(ctx context.Context, e *mirrorEntry) error {
+	if !r.upload {
+		return localpath.MkdirAll(r.localPath(e.path), defaultDirPerm) //nolint:wrapcheck // recorded with the entry path
+	}
+
+	parentID, err := r.parentFolderID(e.path)
+	if err != nil {
+		return err
+	}
+
+	folder, err := r.session.EnsureFolder(ctx, parentID, path.Base(e.path))
+	if err != nil {
+		return err //nolint:wrapcheck // recorded with the entry path
+	}
+
+	r.mu.Lock()
+	r.folderIDs[mirrorKey(e.path)] = folder.ID
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *mirrorRun) uploadFile(ctx context.Context, e *mirrorEntry) error {
+	parentID, err := r.parentFolderID(e.path)
+	if err != nil {
+		return err
+	}
+
+	localPath := r.localPath(e.path)
+
+	info, err := localpath.Stat(localPath)
+	if err != nil {
+		return err //nolint:wrapcheck // recorded with the entry path
+	}
+
+	_, err = r.tm.UploadFile(ctx, r.session.DriveID, parentID, path.Base(e.path), localPath, driveops.UploadOpts{
+		Mtime: info.ModTime(),
+	})
+
+	return err //nolint:wrapcheck // recorded with the entry path
+}
+
+func (r *mirrorRun) downloadFile(ctx context.Context, e *mirrorEntry) error {
+	_, err := r.tm.DownloadToFile(ctx, r.session.DriveID, e.item.ID, r.localPath(e.path), driveops.DownloadOpts{
+		RemoteHash: e.item.QuickXorHash,
+		RemoteSize: e.item.Size,
+	})
+
+	return err //nolint:wrapcheck // recorded with the entry path
+}
+
+func (r *mirrorRun) deleteEntry(ctx context.Context, e *mirrorEntry) error {
+	if !r.upload {
+		return localpath.RemoveAll(r.localPath(e.path)) //nolint:wrapcheck // recorded with the entry path
+	}
+
+	remotePath := joinRemotePath(r.remoteRoot, e.path)
+
+	return r.session.DeleteResolvedPath(ctx, remotePath, e.item.ID) //nolint:wrapcheck // recorded with the entry path
+}
+
+func (r *mirrorRun) report() error {
+	if r.cc.Flags.JSON {
+		if err := printMirrorJSON(r.cc.Output(), r.result); err != nil {
+			return err
+		}
+	} else if !r.result.DryRun {
+		counts := make(map[string]int)
+		for _, a := range r.result.Actions {
+			counts[a.Action]++
+		}
+
+		r.cc.Statusf("Mirrored %s → %s: %d transferred, %d folders created, %d deleted, %d unchanged\n",
+			r.result.Source, r.result.Destination,
+			counts[mirrorActionUpload]+counts[mirrorActionDownload], counts[mirrorActionMkdir],
+			counts[mirrorActionDelete], r.result.Unchanged)
+	}
+
+	if len(r.result.Errors) > 0 {
+		for _, e := range r.result.Errors {
+			r.cc.Statusf("  %s\n", e)
+		}
+
+		return fmt.Errorf("%d errors during mirror", len(r.result.Errors))
+	}
+
+	return nil
+}
+
+// printMirrorJSON writes the mirror command's JSON output to w.
+func printMirrorJSON(w io.Writer, out mirrorJSONOutput) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encode mirror output: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+	"github.com/tonimelisma/onedrive-go/internal/localpath"
+)
+
+// Actions in a mirror plan.
+const (
+	mirrorActionMkdir    = "mkdir"
+	mirrorActionUpload   = "upload"
+	mirrorActionDownload = "download"
+	mirrorActionDelete   = "delete"
+)
+
+// mirrorEntry is one file or folder on either side of a mirror, with its
+// slash-separated path relative to the mirror root.
+type mirrorEntry struct {
+	path  string
+	isDir bool
+	side  transferSide
+	item  *graph.Item // remote entries only
+}
+
+// mirrorTree indexes one side of a mirror by NFC-normalized, lowercased
+// relative path, because OneDrive names are case-insensitive and macOS hands
+// out NFD names (R-2.13).
+type mirrorTree map[string]*mirrorEntry
+
+func mirrorKey(rel string) string {
+	return strings.ToLower(norm.NFC.String(rel))
+}
+
+func (t mirrorTree) add(e *mirrorEntry) {
+	t[mirrorKey(e.path)] = e
+}
+
+func (t mirrorTree) sortedKeys() []string {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+
+	// Sorting puts every folder before its contents.
+	sort.Strings(keys)
+
+	return keys
+}
+
+// mirrorOp is one planned step. entry is the source entry for mkdir and
+// transfers and the destination entry for deletes.
+type mirrorOp struct {
+	action string
+	entry  *mirrorEntry
+}
+
+// mirrorPlan lists the steps that make the destination match the source, in
+// the order they must run: destination entries of the wrong type are
+// replaced first, then folders are created parents-first, then files are
+// transferred (in parallel), and finally extra destination entries are
+// deleted when --delete was given.
+type mirrorPlan struct {
+	replaced  []mirrorOp
+	mkdirs    []mirrorOp
+	transfers []mirrorOp
+	deletes   []mirrorOp
+	unchanged int
+}
+
+func (p *mirrorPlan) ops() []mirrorOp {
+	out := make([]mirrorOp, 0, len(p.replaced)+len(p.mkdirs)+len(p.transfers)+len(p.deletes))
+	out = append(out, p.replaced...)
+	out = append(out, p.mkdirs...)
+	out = append(out, p.transfers...)
+
+	return append(out, p.deletes...)
+}
+
+// planMirror compares src with dst. transfer is the action that copies a
+// file toward dst. Deleting a folder covers its contents, so nothing below a
+// deleted folder is listed again.
+func planMirror(src, dst mirrorTree, transfer string, deleteExtra bool) (*mirrorPlan, error) {
+	plan := &mirrorPlan{}
+	deletedDirs := make(map[string]bool)
+
+	for _, key := range src.sortedKeys() {
+		s := src[key]
+		d := dst[key]
+
+		if d != nil && d.isDir != s.isDir {
+			plan.replaced = append(plan.replaced, mirrorOp{action: mirrorActionDelete, entry: d})
+			deletedDirs[key] = d.isDir
+			d = nil
+		}
+
+		if s.isDir {
+			if d == nil {
+				plan.mkdirs = append(plan.mkdirs, mirrorOp{action: mirrorActionMkdir, entry: s})
+			}
+
+			continue
+		}
+
+		if d != nil {
+			// A file is current when it matches the way --update compares.
+			reason, err := transferSkipPolicy{update: true}.skipReason(s.side, d.side)
+			if err != nil {
+				return nil, fmt.Errorf("comparing %s: %w", s.path, err)
+			}
+
+			if reason != "" {
+				plan.unchanged++
+
+				continue
+			}
+		}
+
+		plan.transfers = append(plan.transfers, mirrorOp{action: transfer, entry: s})
+	}
+
+	if !deleteExtra {
+		return plan, nil
+	}
+
+	for _, key := range dst.sortedKeys() {
+		if src[key] != nil || hasAncestorIn(deletedDirs, key) {
+			continue
+		}
+
+		d := dst[key]
+		plan.deletes = append(plan.deletes, mirrorOp{action: mirrorActionDelete, entry: d})
+		deletedDirs[key] = d.isDir
+	}
+
+	return plan, nil
+}
+
+// hasAncestorIn reports whether any folder above key is set in dirs.
+func hasAncestorIn(dirs map[string]bool, key string) bool {
+	for dir := path.Dir(key); dir != "."; dir = path.Dir(dir) {
+		if dirs[dir] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// localMirrorTree walks root and records every folder and regular file.
+// Symlinks and special files are left out. A missing root is an empty tree.
+func localMirrorTree(root string) (mirrorTree, error) {
+	tree := mirrorTree{}
+
+	if _, err := localpath.Stat(root); errors.Is(err, os.ErrNotExist) {
+		return tree, nil
+	}
+
+	err := walkMirrorDir(root, "", tree)
+
+	return tree, err
+}
+
+func walkMirrorDir(root, rel string, tree mirrorTree) error {
+	dir := filepath.Join(root, filepath.FromSlash(rel))
+
+	entries, err := localpath.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", dir, err)
+	}
+
+	for _, d := range entries {
+		childRel := path.Join(rel, d.Name())
+
+		switch {
+		case d.IsDir():
+			tree.add(&mirrorEntry{path: childRel, isDir: true})
+
+			if err := walkMirrorDir(root, childRel, tree); err != nil {
+				return err
+			}
+		case d.Type()&fs.ModeType == 0:
+			side, sideErr := localTransferSide(filepath.Join(root, filepath.FromSlash(childRel)))
+			if sideErr != nil {
+				return sideErr
+			}
+
+			tree.add(&mirrorEntry{path: childRel, side: side})
+		}
+	}
+
+	return nil
+}
+
+// remoteMirrorTree indexes a folder enumeration.
+func remoteMirrorTree(entries []driveops.RemoteTreeEntry) mirrorTree {
+	tree := make(mirrorTree, len(entries))
+
+	for i := range entries {
+		item := &entries[i].Item
+		tree.add(&mirrorEntry{
+			path:  entries[i].Path,
+			isDir: item.IsFolder,
+			side:  remoteTransferSide(item),
+			item:  item,
+		})
+	}
+
+	return tree
+}
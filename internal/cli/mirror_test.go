@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+func mirrorFile(p string, size int64, hash string) *mirrorEntry {
+	return &mirrorEntry{path: p, side: transferSide{exists: true, size: size, hash: constHash(hash)}}
+}
+
+func mirrorDir(p string) *mirrorEntry {
+	return &mirrorEntry{path: p, isDir: true, side: transferSide{exists: true, size: -1, hash: constHash("")}}
+}
+
+func mirrorTreeOf(entries ...*mirrorEntry) mirrorTree {
+	tree := mirrorTree{}
+	for _, e := range entries {
+		tree.add(e)
+	}
+
+	return tree
+}
+
+func mirrorOpStrings(ops []mirrorOp) []string {
+	out := make([]string, 0, len(ops))
+	for _, op := range ops {
+		out = append(out, op.action+" "+op.entry.path)
+	}
+
+	return out
+}
+
+// Validates: R-1.16.1
+func TestPlanMirror(t *testing.T) {
+	t.Parallel()
+
+	src := mirrorTreeOf(
+		mirrorFile("same.txt", 4, "h1"),
+		mirrorFile("changed.txt", 4, "new"),
+		mirrorFile("grown.txt", 9, "h3"),
+		mirrorDir("Docs"),
+		mirrorFile("Docs/new.txt", 1, "h4"),
+		mirrorDir("was-file"),
+		mirrorFile("was-dir", 2, "h5"),
+	)
+	dst := mirrorTreeOf(
+		mirrorFile("SAME.txt", 4, "h1"),
+		mirrorFile("changed.txt", 4, "old"),
+		mirrorFile("grown.txt", 4, "h3"),
+		mirrorFile("was-file", 1, "x"),
+		mirrorDir("was-dir"),
+		mirrorFile("was-dir/inner.txt", 1, "x"),
+		mirrorDir("extra"),
+		mirrorFile("extra/a.txt", 1, "x"),
+		mirrorFile("stale.txt", 1, "x"),
+	)
+
+	plan, err := planMirror(src, dst, mirrorActionUpload, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"delete was-dir", "delete was-file",
+		"mkdir Docs", "mkdir was-file",
+		"upload changed.txt", "upload Docs/new.txt", "upload grown.txt", "upload was-dir",
+	}, mirrorOpStrings(plan.ops()))
+	assert.Equal(t, 1, plan.unchanged, "names match case-insensitively")
+
+	plan, err = planMirror(src, dst, mirrorActionUpload, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"delete extra", "delete stale.txt"}, mirrorOpStrings(plan.deletes),
+		"contents of deleted folders are not listed again")
+}
+
+// Validates: R-1.16.1
+func TestLocalMirrorTree_MissingRootIsEmpty(t *testing.T) {
+	t.Parallel()
+
+	tree, err := localMirrorTree(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, tree)
+}
+
+// mirrorTestServer serves the Site folder with one current file, one stale
+// file, and one extra subfolder, and records every mutation.
+type mirrorTestServer struct {
+	mu        sync.Mutex
+	mutations []string
+}
+
+func (s *mirrorTestServer) recorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.mutations...)
+}
+
+func (s *mirrorTestServer) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPut:
+			name := strings.TrimSuffix(r.URL.Path, ":/content")
+			s.record("upload " + name[strings.LastIndex(name, ":/")+2:])
+			w.WriteHeader(http.StatusCreated)
+			writeTestResponse(t, w, `{"id":"up","name":"changed.txt"}`)
+		case r.Method == http.MethodPatch:
+			writeTestResponse(t, w, `{"id":"up","name":"changed.txt"}`)
+		case r.Method == http.MethodDelete:
+			s.record("delete " + r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/drives/0000000drive-123/items/site/delta":
+			writeTestResponsef(t, w, `{"value":[
+				{"id":"site","name":"Site","folder":{},"parentReference":{"id":"root"}},
+				{"id":"same","name":"same.txt","size":4,"file":{"hashes":{"quickXorHash":%q}},"parentReference":{"id":"site"}},
+				{"id":"changed","name":"changed.txt","size":3,"file":{},"parentReference":{"id":"site"}},
+				{"id":"extra","name":"Extra","folder":{},"parentReference":{"id":"site"}},
+				{"id":"extra-file","name":"a.txt","size":1,"file":{},"parentReference":{"id":"extra"}}
+			],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`, tarTestHash("same"))
+		default:
+			writeTestResponse(t, w, `{"id":"site","name":"Site","folder":{},"parentReference":{"id":"root","path":"/drive/root:"}}`)
+		}
+	}
+}
+
+func (s *mirrorTestServer) record(m string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mutations = append(s.mutations, m)
+}
+
+func newMirrorTestLocal(t *testing.T) string {
+	t.Helper()
+
+	local := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(local, "same.txt"), []byte("same"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "changed.txt"), []byte("changed"), 0o600))
+
+	return local
+}
+
+// Validates: R-1.16, R-1.16.1
+func TestRunMirror_UploadsChangedFilesAndDeletesExtras(t *testing.T) {
+	srv := &mirrorTestServer{}
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), srv.handler(t), &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newMirrorCmd()
+	cmd.SetArgs([]string{"--delete", newMirrorTestLocal(t), "/Site"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, []string{"upload changed.txt", "delete extra"}, srv.recorded())
+
+	var out mirrorJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, []mirrorActionJSON{
+		{Action: mirrorActionUpload, Path: "changed.txt", Size: 7},
+		{Action: mirrorActionDelete, Path: "Extra"},
+	}, out.Actions)
+	assert.Equal(t, 1, out.Unchanged)
+	assert.Empty(t, out.Errors)
+}
+
+// Validates: R-1.16.2
+func TestRunMirror_DryRunChangesNothing(t *testing.T) {
+	srv := &mirrorTestServer{}
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), srv.handler(t), &stdout, &stderr)
+
+	cmd := newMirrorCmd()
+	cmd.SetArgs([]string{"--delete", "--dry-run", newMirrorTestLocal(t), "/Site"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	assert.Empty(t, srv.recorded())
+	assert.Contains(t, stderr.String(), "Would upload changed.txt")
+	assert.Contains(t, stderr.String(), "Would delete Extra")
+}
+
+// Validates: R-3.1.10
+func TestRunMirror_ReadOnlyDriveRefusesUpload(t *testing.T) {
+	srv := &mirrorTestServer{}
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), srv.handler(t), &stdout, &stderr)
+	cc.Cfg.ReadOnly = true
+
+	cmd := newMirrorCmd()
+	cmd.SetArgs([]string{newMirrorTestLocal(t), "/Site"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signed in read-only")
+	assert.Empty(t, srv.recorded())
+}
+
+// Validates: R-1.16.3
+func TestRunMirror_ReverseDownloadsIntoLocalFolder(t *testing.T) {
+	local := filepath.Join(t.TempDir(), "copy")
+	require.NoError(t, os.MkdirAll(local, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "local-only.txt"), []byte("x"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch {
+			case r.URL.Path == "/drives/0000000drive-123/items/site/delta":
+				writeTestResponsef(t, w, `{"value":[
+					{"id":"site","name":"Site","folder":{},"parentReference":{"id":"root"}},
+					{"id":"sub","name":"Sub","folder":{},"parentReference":{"id":"site"}},
+					{"id":"f","name":"f.txt","size":5,"file":{"hashes":{"quickXorHash":%q}},"parentReference":{"id":"sub"}}
+				],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`, tarTestHash("hello"))
+			case r.URL.Path == "/drives/0000000drive-123/items/f":
+				writeTestResponsef(t, w, `{"id":"f","name":"f.txt","size":5,"file":{"hashes":{"quickXorHash":%q}},
+					"@microsoft.graph.downloadUrl":"http://%s/dl/f"}`, tarTestHash("hello"), r.Host)
+			case r.URL.Path == "/dl/f":
+				w.Header().Set("Content-Type", "application/octet-stream")
+				_, err := w.Write([]byte("hello"))
+				assert.NoError(t, err)
+			default:
+				writeTestResponse(t, w, `{"id":"site","name":"Site","folder":{},"parentReference":{"id":"root","path":"/drive/root:"}}`)
+			}
+		}), &stdout, &stderr)
+
+	cmd := newMirrorCmd()
+	cmd.SetArgs([]string{"--reverse", "--delete", local, "/Site"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	got, err := os.ReadFile(filepath.Join(local, "Sub", "f.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(got))
+	assert.NoFileExists(t, filepath.Join(local, "local-only.txt"))
+	assert.Contains(t, stderr.String(), "1 transferred, 1 folders created, 1 deleted")
+}
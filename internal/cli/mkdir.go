@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,12 +13,21 @@ import (
 )
 
 func newMkdirCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "mkdir <path>",
 		Short: "Create a folder (recursive)",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runMkdir,
+		Long: `Create a remote folder together with any missing parent folders. A folder
+that already exists, or that another client creates at the same moment, is
+used as it is, so re-running mkdir is safe; a file holding one of the names
+fails the command. -p is accepted for familiarity and changes nothing.`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{mutatesDriveAnnotation: mutatesDriveValue},
+		RunE:        runMkdir,
 	}
+
+	cmd.Flags().BoolP("parents", "p", false, "create missing parent folders (always on)")
+
+	return cmd
 }
 
 // mkdirJSONOutput is the JSON output schema for the mkdir command.
@@ -43,21 +53,9 @@ func runMkdir(cmd *cobra.Command, args []string) error {
 	logger := cc.Logger
 	logger.Debug("mkdir", "path", remotePath)
 
-	// Walk path segments, creating each missing folder.
-	segments := strings.Split(remotePath, "/")
-	parentID := mkdirStartParentID(session)
-
-	for _, seg := range segments {
-		if seg == "" {
-			continue
-		}
-
-		item, createErr := session.EnsureFolder(ctx, parentID, seg)
-		if createErr != nil {
-			return fmt.Errorf("creating folder %q: %w", seg, createErr)
-		}
-
-		parentID = item.ID
+	parentID, err := ensureRemoteFolderPath(ctx, session, remotePath)
+	if err != nil {
+		return err
 	}
 
 	item, err := session.WaitPathVisible(ctx, remotePath)
@@ -76,6 +74,62 @@ func runMkdir(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// ensureRemoteFolderPath walks remotePath from the mount root, creating each
+// missing folder, and returns the ID of the last one. Nested paths first look
+// up every ancestor in one JSON batch and only walk from the deepest existing
+// folder.
+func ensureRemoteFolderPath(ctx context.Context, session *driveops.MountSession, remotePath string) (string, error) {
+	segs := strings.Split(driveops.CleanRemotePath(remotePath), "/")
+	parentID, done := existingFolderPrefix(ctx, session, segs)
+
+	for _, seg := range segs[done:] {
+		if seg == "" {
+			continue
+		}
+
+		item, err := session.EnsureFolder(ctx, parentID, seg)
+		if err != nil {
+			return "", fmt.Errorf("creating folder %q: %w", seg, err)
+		}
+
+		parentID = item.ID
+	}
+
+	return parentID, nil
+}
+
+// existingFolderPrefix returns the ID of the deepest folder that already
+// exists along segs and how many segments it covers. The lookup only saves
+// requests, so a failed batch falls back to walking from the mount root.
+func existingFolderPrefix(ctx context.Context, session *driveops.MountSession, segs []string) (string, int) {
+	parentID := mkdirStartParentID(session)
+	if len(segs) < 2 {
+		return parentID, 0
+	}
+
+	prefixes := make([]string, 0, len(segs))
+	for i := range segs {
+		prefixes = append(prefixes, strings.Join(segs[:i+1], "/"))
+	}
+
+	results, err := session.LookupPathsBatch(ctx, prefixes)
+	if err != nil {
+		return parentID, 0
+	}
+
+	done := 0
+
+	for i := range results {
+		if results[i].Err != nil || !results[i].Item.IsFolder {
+			break
+		}
+
+		parentID, done = results[i].Item.ID, i+1
+	}
+
+	return parentID, done
+}
+
 func mkdirStartParentID(session *driveops.MountSession) string {
 	if session == nil || session.RemoteRootItemID == "" {
 		return "root"
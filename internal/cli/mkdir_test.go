@@ -2,12 +2,18 @@ package cli
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
 	"github.com/tonimelisma/onedrive-go/internal/driveops"
 )
 
@@ -34,3 +40,59 @@ func TestMkdirStartParentID(t *testing.T) {
 	assert.Equal(t, "root", mkdirStartParentID(driveops.NewMountSession(&driveops.Session{}, "root")))
 	assert.Equal(t, "mount-root-id", mkdirStartParentID(driveops.NewMountSession(&driveops.Session{}, "mount-root-id")))
 }
+
+// Validates: R-1.5.3, R-6.8.17
+func TestRunMkdir_BatchLooksUpAncestorsAndCreatesOnlyMissing(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		creates []string
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/$batch"):
+			var batch struct {
+				Requests []struct {
+					ID  string `json:"id"`
+					URL string `json:"url"`
+				} `json:"requests"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+
+			responses := make([]string, 0, len(batch.Requests))
+			for _, req := range batch.Requests {
+				if strings.HasSuffix(req.URL, "root:/A:") {
+					responses = append(responses, fmt.Sprintf(`{"id":%q,"status":200,"body":{"id":"a","name":"A","folder":{}}}`, req.ID))
+					continue
+				}
+
+				responses = append(responses, fmt.Sprintf(`{"id":%q,"status":404,"body":{"error":{"code":"itemNotFound"}}}`, req.ID))
+			}
+
+			writeTestResponsef(t, w, `{"responses":[%s]}`, strings.Join(responses, ","))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/children"):
+			parent := strings.TrimSuffix(r.URL.Path[strings.Index(r.URL.Path, "/items/")+len("/items/"):], "/children")
+
+			mu.Lock()
+			creates = append(creates, parent)
+			mu.Unlock()
+
+			writeTestResponsef(t, w, `{"id":"%s-child","name":"x","folder":{}}`, parent)
+		default:
+			writeTestResponse(t, w, `{"id":"c","name":"C","folder":{},"parentReference":{"id":"b"}}`)
+		}
+	})
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), handler, &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newMkdirCmd()
+	cmd.SetArgs([]string{"-p", "/A/B/C"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, []string{"a", "a-child"}, creates, "A exists, so only B and C are created")
+}
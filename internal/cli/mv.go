@@ -2,10 +2,8 @@ package cli
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 
 	"github.com/spf13/cobra"
 
@@ -14,18 +12,29 @@ import (
 )
 
 func newMvCmd() *cobra.Command {
-	var force bool
-
 	cmd := &cobra.Command{
-		Use:   "mv <source> <dest>",
-		Short: "Move or rename a file or folder",
-		Args:  cobra.ExactArgs(2),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runMv(cmd, args, force)
-		},
+		Use:   "mv <source>... <dest>",
+		Short: "Move or rename files and folders",
+		Long: `Move or rename a file or folder on the server.
+
+Several sources, or a glob in the last component of a source as in
+mv '/Inbox/*.pdf' /Archive, move into dest, which must then be an existing
+folder. Every source is resolved before anything moves, and --dry-run only
+lists the moves.
+
+--on-conflict decides what happens when the destination name is taken: fail
+(the default) reports an error, replace overwrites it (same as --force), skip
+leaves both items alone, and rename lets the service pick a free name such as
+"report 1.pdf". The reported destination is the name actually used.`,
+		Args:              cobra.MinimumNArgs(2),
+		Annotations:       map[string]string{mutatesDriveAnnotation: mutatesDriveValue},
+		RunE:              runMv,
+		ValidArgsFunction: completeRemotePaths(remoteCompletion{sharedArg: noSharedArg}),
 	}
 
-	cmd.Flags().BoolVarP(&force, "force", "f", false, "overwrite existing file at destination")
+	cmd.Flags().BoolP("force", "f", false, "overwrite existing file at destination (same as --on-conflict=replace)")
+	cmd.Flags().Bool("dry-run", false, "show what would be moved without moving")
+	addConflictFlag(cmd, conflictFail)
 
 	return cmd
 }
@@ -35,36 +44,38 @@ type mvJSONOutput struct {
 	Source      string `json:"source"`
 	Destination string `json:"destination"`
 	ID          string `json:"id"`
+	Skipped     string `json:"skipped,omitempty"`
 }
 
 // destInfo holds the result of resolving a destination path.
 type destInfo struct {
 	parentID   string
 	newName    string
-	existingID string // non-empty when force=true and dest is an existing file
+	existingID string // non-empty when the policy allows a taken dest and one exists
 	destIsDir  bool   // true when dest resolved to an existing folder
 }
 
 // resolveDest resolves a destination path to a destInfo.
 // If dest exists and is a folder, the item moves into it keeping sourceName.
 // If dest doesn't exist, the parent must exist — the item moves there with the new name.
-// If dest exists and is a file, returns an error unless force is true.
-// When force is true and dest is an existing file, existingID is set to the
-// file's ID so the caller can delete it before the operation.
+// If dest exists and is a file, returns an error when policy is fail.
+// Otherwise existingID is set to the file's ID so the caller can delete it
+// (replace) or leave it alone (skip, rename). With skip, a folder dest is
+// also probed for a child named sourceName.
 func resolveDest(
 	ctx context.Context,
 	session *driveops.MountSession,
 	destPath, sourceName string,
-	force bool,
+	policy conflictPolicy,
 ) (info destInfo, err error) {
 	// Attempt 1: does the dest path already exist?
 	item, resolveErr := session.ResolveItem(ctx, destPath)
 	if resolveErr == nil {
 		if item.IsFolder {
-			return destInfo{parentID: item.ID, newName: sourceName, destIsDir: true}, nil
+			return resolveDestFolder(ctx, session, destPath, sourceName, item, policy)
 		}
 
-		if force {
+		if policy != conflictFail {
 			if item.ParentID == "" {
 				return destInfo{}, fmt.Errorf("destination %q has no parent reference; cannot overwrite", destPath)
 			}
@@ -72,7 +83,7 @@ func resolveDest(
 			return destInfo{parentID: item.ParentID, newName: item.Name, existingID: item.ID}, nil
 		}
 
-		return destInfo{}, fmt.Errorf("destination %q already exists (file); use --force to overwrite", destPath)
+		return destInfo{}, fmt.Errorf("destination %q already exists (file); use --force or --on-conflict to overwrite", destPath)
 	}
 
 	// Attempt 2: if not found, split into parent + name and resolve parent.
@@ -94,6 +105,50 @@ func resolveDest(
 	return destInfo{parentID: parentItem.ID, newName: destName}, nil
 }
 
+// resolveDestFolder resolves a dest that is an existing folder. Only skip
+// looks for a taken child name up front; the other policies leave that to
+// the service's conflict behavior.
+func resolveDestFolder(
+	ctx context.Context,
+	session *driveops.MountSession,
+	destPath, sourceName string,
+	folder *graph.Item,
+	policy conflictPolicy,
+) (destInfo, error) {
+	info := destInfo{parentID: folder.ID, newName: sourceName, destIsDir: true}
+	if policy != conflictSkip {
+		return info, nil
+	}
+
+	child, err := session.ResolveItem(ctx, joinRemotePath(destPath, sourceName))
+	if err == nil {
+		info.existingID = child.ID
+
+		return info, nil
+	}
+
+	if !errors.Is(err, graph.ErrNotFound) {
+		return destInfo{}, fmt.Errorf("resolving destination %q: %w", joinRemotePath(destPath, sourceName), err)
+	}
+
+	return info, nil
+}
+
+// finalDestPath is the path to report for an item that landed in dest under
+// finalName, which differs from the requested name after a rename.
+func finalDestPath(destPath string, dest destInfo, finalName string) string {
+	if !dest.destIsDir {
+		return renamedPath(destPath, finalName)
+	}
+
+	clean := driveops.CleanRemotePath(destPath)
+	if clean == "" {
+		return destPath
+	}
+
+	return clean + "/" + finalName
+}
+
 // isSelfReference returns true when --force resolved to the source item itself.
 func isSelfReference(sourceID string, dest destInfo) bool {
 	return dest.existingID != "" && dest.existingID == sourceID
@@ -104,71 +159,111 @@ func isNoOpMove(dest destInfo, sourceParentID, sourceName string) bool {
 	return dest.parentID == sourceParentID && dest.newName == sourceName
 }
 
-// emitMoveResult writes the move result as JSON or status text.
-func emitMoveResult(cc *CLIContext, sourcePath, displayDest, itemID string) error {
+// reportMove writes the status line for one finished or skipped move and
+// returns its JSON record; the caller prints JSON once for all sources.
+func reportMove(cc *CLIContext, out mvJSONOutput) mvJSONOutput {
 	if cc.Flags.JSON {
-		return printMvJSON(cc.Output(), mvJSONOutput{
-			Source:      sourcePath,
-			Destination: displayDest,
-			ID:          itemID,
-		})
+		return out
 	}
 
-	cc.Statusf("Moved %s → %s\n", sourcePath, displayDest)
-
-	return nil
-}
-
-// printMvJSON writes the mv command's JSON output to w.
-func printMvJSON(w io.Writer, out mvJSONOutput) error {
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-
-	if err := enc.Encode(out); err != nil {
-		return fmt.Errorf("encode move output: %w", err)
+	if out.Skipped != "" {
+		cc.Statusf("Skipped %s (%s exists)\n", out.Source, out.Destination)
+	} else {
+		cc.Statusf("Moved %s → %s\n", out.Source, out.Destination)
 	}
 
-	return nil
+	return out
 }
 
-func runMv(cmd *cobra.Command, args []string, force bool) error {
-	sourcePath := args[0]
-	destPath := args[1]
+func runMv(cmd *cobra.Command, args []string) error {
+	destPath := args[len(args)-1]
 	ctx := cmd.Context()
 	cc := mustCLIContext(ctx)
 
-	session, err := cc.Session(ctx)
+	onConflict, err := readConflictPolicy(cmd)
 	if err != nil {
 		return err
 	}
 
-	logger := cc.Logger
-	logger.Debug("mv", "source", sourcePath, "dest", destPath)
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("read --dry-run flag: %w", err)
+	}
 
-	sourceItem, err := session.ResolveItem(ctx, sourcePath)
+	session, err := cc.Session(ctx)
 	if err != nil {
-		return fmt.Errorf("resolving source %q: %w", sourcePath, err)
+		return err
 	}
 
-	dest, err := resolveDest(ctx, session, destPath, sourceItem.Name, force)
+	cc.Logger.Debug("mv", "sources", args[:len(args)-1], "dest", destPath, "on_conflict", onConflict, "dry_run", dryRun)
+
+	sources, err := resolveTransferSources(ctx, session, args[:len(args)-1], destPath)
 	if err != nil {
 		return err
 	}
 
+	if dryRun {
+		return planTransferSources(ctx, cc, session, sources, destPath, onConflict, "move")
+	}
+
+	outs := make([]mvJSONOutput, 0, len(sources.items))
+
+	for i := range sources.items {
+		out, err := moveOne(ctx, cc, session, &sources.items[i], destPath, onConflict)
+		if err != nil {
+			return err
+		}
+
+		outs = append(outs, reportMove(cc, out))
+	}
+
+	if cc.Flags.JSON {
+		return printSourcesJSON(cc.Output(), outs, sources.batch, "move")
+	}
+
+	return nil
+}
+
+// moveOne moves one resolved source to destPath.
+func moveOne(
+	ctx context.Context,
+	cc *CLIContext,
+	session *driveops.MountSession,
+	source *transferSource,
+	destPath string,
+	onConflict conflictPolicy,
+) (mvJSONOutput, error) {
+	logger := cc.Logger
+	sourcePath, sourceItem := source.path, source.item
+
+	dest, err := resolveDest(ctx, session, destPath, sourceItem.Name, onConflict)
+	if err != nil {
+		return mvJSONOutput{}, err
+	}
+
 	// Check for no-op BEFORE any destructive action (delete or move).
 	if isNoOpMove(dest, sourceItem.ParentID, sourceItem.Name) {
 		logger.Debug("mv: no-op, source and dest are the same")
 
-		return emitMoveResult(cc, sourcePath, destPath, sourceItem.ID)
+		return mvJSONOutput{Source: sourcePath, Destination: destPath, ID: sourceItem.ID}, nil
+	}
+
+	if onConflict == conflictSkip && dest.existingID != "" {
+		return mvJSONOutput{
+			Source:      sourcePath,
+			Destination: finalDestPath(destPath, dest, dest.newName),
+			ID:          dest.existingID,
+			Skipped:     skipReasonExists,
+		}, nil
 	}
 
 	// If --force resolved to an existing file, delete it before moving.
 	// Skip when the existing file IS the source (self-reference via different paths).
 	// NOTE: This is a TOCTOU race — another client could recreate the file
 	// between delete and move. The Graph API has no atomic overwrite for moves.
-	if dest.existingID != "" && !isSelfReference(sourceItem.ID, dest) {
+	if onConflict == conflictReplace && dest.existingID != "" && !isSelfReference(sourceItem.ID, dest) {
 		if delErr := session.DeleteResolvedPath(ctx, destPath, dest.existingID); delErr != nil {
-			return fmt.Errorf("deleting existing %q: %w", destPath, delErr)
+			return mvJSONOutput{}, fmt.Errorf("deleting existing %q: %w", destPath, delErr)
 		}
 	}
 
@@ -186,23 +281,26 @@ func runMv(cmd *cobra.Command, args []string, force bool) error {
 		moveName = ""
 	}
 
-	moved, err := session.MoveItem(ctx, sourceItem.ID, moveParentID, moveName)
+	moved, err := session.MoveItem(ctx, sourceItem.ID, moveParentID, moveName, onConflict.behavior())
+	if onConflict.skipsConflict(err) {
+		return mvJSONOutput{
+			Source:      sourcePath,
+			Destination: finalDestPath(destPath, dest, dest.newName),
+			Skipped:     skipReasonExists,
+		}, nil
+	}
+
 	if err != nil {
-		return fmt.Errorf("moving %q: %w", sourcePath, err)
+		return mvJSONOutput{}, fmt.Errorf("moving %q: %w", sourcePath, err)
 	}
 
 	// Build display destination from info we already have — no extra API call.
-	displayDest := destPath
-	if dest.destIsDir {
-		clean := driveops.CleanRemotePath(destPath)
-		if clean != "" {
-			displayDest = clean + "/" + moved.Name
-		}
-	}
+	// The moved item carries the final name, which differs after a rename.
+	displayDest := finalDestPath(destPath, dest, moved.Name)
 
 	if _, err := session.WaitPathVisible(ctx, displayDest); err != nil {
-		return fmt.Errorf("confirming move to %q visibility: %w", displayDest, err)
+		return mvJSONOutput{}, fmt.Errorf("confirming move to %q visibility: %w", displayDest, err)
 	}
 
-	return emitMoveResult(cc, sourcePath, displayDest, moved.ID)
+	return mvJSONOutput{Source: sourcePath, Destination: displayDest, ID: moved.ID}, nil
 }
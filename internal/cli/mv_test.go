@@ -46,7 +46,7 @@ func TestResolveDest_ForceReturnsExistingID(t *testing.T) {
 		writeTestResponsef(t, w, `{"id":"existing-file-id","name":"dest.txt","parentReference":{"id":"parent-folder-id"}}`)
 	}))
 
-	dest, err := resolveDest(t.Context(), session, "dest.txt", "source.txt", true)
+	dest, err := resolveDest(t.Context(), session, "dest.txt", "source.txt", conflictReplace)
 	require.NoError(t, err)
 	assert.Equal(t, "parent-folder-id", dest.parentID)
 	assert.Equal(t, "dest.txt", dest.newName)
@@ -61,7 +61,7 @@ func TestResolveDest_ForceEmptyParentID(t *testing.T) {
 		writeTestResponsef(t, w, `{"id":"file-id","name":"dest.txt"}`)
 	}))
 
-	dest, err := resolveDest(t.Context(), session, "dest.txt", "source.txt", true)
+	dest, err := resolveDest(t.Context(), session, "dest.txt", "source.txt", conflictReplace)
 	require.Error(t, err)
 	assert.Empty(t, dest.parentID)
 	assert.Contains(t, err.Error(), "parent")
@@ -74,7 +74,7 @@ func TestResolveDest_NoForceFileExists(t *testing.T) {
 		writeTestResponsef(t, w, `{"id":"file-id","name":"dest.txt","parentReference":{"id":"p1"}}`)
 	}))
 
-	dest, err := resolveDest(t.Context(), session, "dest.txt", "source.txt", false)
+	dest, err := resolveDest(t.Context(), session, "dest.txt", "source.txt", conflictFail)
 	require.Error(t, err)
 	assert.Empty(t, dest.parentID)
 	assert.Contains(t, err.Error(), "--force")
@@ -87,7 +87,7 @@ func TestResolveDest_FolderDest(t *testing.T) {
 		writeTestResponsef(t, w, `{"id":"folder-id","name":"destdir","folder":{}}`)
 	}))
 
-	dest, err := resolveDest(t.Context(), session, "destdir", "source.txt", false)
+	dest, err := resolveDest(t.Context(), session, "destdir", "source.txt", conflictFail)
 	require.NoError(t, err)
 	assert.Equal(t, "folder-id", dest.parentID)
 	assert.Equal(t, "source.txt", dest.newName)
@@ -112,7 +112,7 @@ func TestResolveDest_NotFound(t *testing.T) {
 		writeTestResponsef(t, w, `{"id":"parent-id","name":"parentdir","folder":{}}`)
 	}))
 
-	dest, err := resolveDest(t.Context(), session, "parentdir/newname.txt", "source.txt", false)
+	dest, err := resolveDest(t.Context(), session, "parentdir/newname.txt", "source.txt", conflictFail)
 	require.NoError(t, err)
 	assert.Equal(t, "parent-id", dest.parentID)
 	assert.Equal(t, "newname.txt", dest.newName)
@@ -128,7 +128,7 @@ func TestResolveDest_SelfReferenceDetected(t *testing.T) {
 		writeTestResponsef(t, w, `{"id":"item-1","name":"file.txt","parentReference":{"id":"parent-1"}}`)
 	}))
 
-	dest, err := resolveDest(t.Context(), session, "file.txt", "file.txt", true)
+	dest, err := resolveDest(t.Context(), session, "file.txt", "file.txt", conflictReplace)
 	require.NoError(t, err)
 	assert.True(t, isSelfReference("item-1", dest), "should detect self-reference")
 	assert.Equal(t, "item-1", dest.existingID)
@@ -189,7 +189,7 @@ func TestNoOpMoveProducesOutput(t *testing.T) {
 	cc := &CLIContext{StatusWriter: &buf}
 
 	// Simulate a no-op move output.
-	require.NoError(t, emitMoveResult(cc, "file.txt", "file.txt", "item-1"))
+	reportMove(cc, mvJSONOutput{Source: "file.txt", Destination: "file.txt", ID: "item-1"})
 	assert.Contains(t, buf.String(), "file.txt")
 }
 
@@ -235,11 +235,11 @@ func TestPrintMvJSON(t *testing.T) {
 	t.Parallel()
 
 	var buf bytes.Buffer
-	err := printMvJSON(&buf, mvJSONOutput{
+	err := printSourcesJSON(&buf, []mvJSONOutput{{
 		Source:      "/docs/report.pdf",
 		Destination: "/archive/report.pdf",
 		ID:          "item-456",
-	})
+	}}, false, "move")
 	require.NoError(t, err)
 
 	var decoded mvJSONOutput
@@ -18,27 +18,58 @@ import (
 )
 
 func newPutCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "put <local-path> [remote-path]",
 		Short: "Upload a file or directory",
-		Args:  cobra.RangeArgs(1, 2),
-		RunE:  runPut,
-	}
+		Long: `Upload a local file or directory.
+
+Pass - as the local path to upload stdin to the given remote file path, for
+example "pg_dump db | gzip | onedrive-go put - /Backups/db.sql.gz". The
+length does not need to be known in advance, and the upload fails if the
+server's QuickXorHash does not match the bytes that were read.
+
+With --tar, stdin is read as a tar archive and extracted into the remote
+folder without staging to disk, for example
+"tar c . | onedrive-go put --tar - /Projects/foo".
+
+--ignore-existing leaves every existing remote file alone. --update leaves a
+remote file alone when its size and QuickXorHash match the local file, so an
+interrupted folder upload can be re-run to pick up where it stopped;
+--checksum and --size-only change how --update compares. Skipped files are
+listed in the JSON output.
+
+--on-conflict decides what happens when the remote name is already taken:
+replace (the default) overwrites it, fail reports an error, skip leaves the
+remote file alone, and rename lets the service pick a free name such as
+"report 1.pdf". The path reported for each file is the name actually used.`,
+		Args:              cobra.RangeArgs(1, 2),
+		Annotations:       map[string]string{mutatesDriveAnnotation: mutatesDriveValue},
+		RunE:              runPut,
+		ValidArgsFunction: completeRemotePaths(remoteCompletion{localArgs: 1, maxArgs: 2, sharedArg: 1}),
+	}
+
+	cmd.Flags().Bool("tar", false, "extract a tar archive from stdin into the remote folder")
+	addTransferSkipFlags(cmd)
+	addConflictFlag(cmd, conflictReplace)
+
+	return cmd
 }
 
 // putJSONOutput is the JSON output schema for uploading a single file.
 type putJSONOutput struct {
-	Path string `json:"path"`
-	ID   string `json:"id"`
-	Size int64  `json:"size"`
+	Path    string `json:"path"`
+	ID      string `json:"id"`
+	Size    int64  `json:"size"`
+	Skipped string `json:"skipped,omitempty"`
 }
 
 // putFolderJSONOutput is the JSON output schema for uploading a directory.
 type putFolderJSONOutput struct {
-	Files          []putJSONOutput `json:"files"`
-	FoldersCreated int             `json:"folders_created"`
-	TotalSize      int64           `json:"total_size"`
-	Errors         []string        `json:"errors"`
+	Files          []putJSONOutput     `json:"files"`
+	Skipped        []skippedJSONOutput `json:"skipped"`
+	FoldersCreated int                 `json:"folders_created"`
+	TotalSize      int64               `json:"total_size"`
+	Errors         []string            `json:"errors"`
 }
 
 type uploadParentResolver interface {
@@ -58,41 +89,96 @@ func runPut(cmd *cobra.Command, args []string) error {
 	localPath := args[0]
 	ctx := cmd.Context()
 
-	fi, err := localpath.Stat(localPath)
+	asTar, err := cmd.Flags().GetBool("tar")
 	if err != nil {
-		return fmt.Errorf("stating local path: %w", err)
+		return fmt.Errorf("reading --tar flag: %w", err)
 	}
 
-	cc := mustCLIContext(ctx)
+	skip, err := readTransferSkipPolicy(cmd)
+	if err != nil {
+		return err
+	}
 
-	if cc.SharedTarget != nil {
-		return runSharedPut(cmd, args, cc, fi)
+	if skip.active() && (asTar || localPath == stdinPath) {
+		return errors.New("--update and --ignore-existing need a local file or directory; they do not apply to stdin uploads")
 	}
 
-	session, err := cc.Session(ctx)
+	onConflict, err := readConflictPolicy(cmd)
 	if err != nil {
 		return err
 	}
 
-	logger := cc.Logger
+	if asTar {
+		if onConflict != conflictReplace {
+			return errors.New("--on-conflict does not apply to --tar uploads")
+		}
 
-	if fi.IsDir() {
-		remotePath := "/" + filepath.Base(localPath)
-		if len(args) > 1 {
-			remotePath = args[1]
+		return runPutTar(cmd, args)
+	}
+
+	if localPath == stdinPath {
+		return runPutStdin(cmd, args, onConflict)
+	}
+
+	fi, err := localpath.Stat(localPath)
+	if err != nil {
+		return fmt.Errorf("stating local path: %w", err)
+	}
+
+	cc := mustCLIContext(ctx)
+
+	if cc.SharedTarget != nil {
+		if onConflict != conflictReplace {
+			return errors.New("--on-conflict does not apply to shared targets, which are overwritten by identity")
 		}
 
-		return uploadFolder(cmd, cc, session, localPath, remotePath)
+		return runSharedPut(cmd, args, cc, fi, skip)
+	}
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
 	}
 
-	// Default remote path is root + local filename.
+	// Default remote path is root + local name.
 	remotePath := "/" + filepath.Base(localPath)
 	if len(args) > 1 {
 		remotePath = args[1]
 	}
 
+	if fi.IsDir() {
+		return uploadFolder(cmd, cc, session, localPath, remotePath, skip, onConflict)
+	}
+
+	return uploadSingleFile(ctx, cc, session, localPath, remotePath, fi, skip, onConflict)
+}
+
+// uploadSingleFile uploads one local file to remotePath and reports the
+// upload, the --update/--ignore-existing skip, or the --on-conflict skip.
+func uploadSingleFile(
+	ctx context.Context,
+	cc *CLIContext,
+	session *driveops.MountSession,
+	localPath, remotePath string,
+	fi os.FileInfo,
+	skip transferSkipPolicy,
+	onConflict conflictPolicy,
+) error {
+	logger := cc.Logger
+
 	logger.Debug("put", "local_path", localPath, "remote_path", remotePath, "size", fi.Size())
 
+	if skip.active() {
+		existing, resolveErr := session.ResolveItem(ctx, remotePath)
+		if resolveErr != nil && !errors.Is(resolveErr, graph.ErrNotFound) {
+			return fmt.Errorf("resolving %q: %w", remotePath, resolveErr)
+		}
+
+		if reason, skipErr := skip.skipUpload(localPath, existing); skipErr != nil || reason != "" {
+			return reportSkippedPut(cc, remotePath, existing, fi.Size(), reason, skipErr)
+		}
+	}
+
 	parentPath, name := driveops.SplitParentAndName(remotePath)
 
 	parentItem, err := resolveUploadParent(ctx, session, parentPath)
@@ -108,13 +194,20 @@ func runPut(cmd *cobra.Command, args []string) error {
 	tm := driveops.NewTransferManager(session.Transfer, session.Transfer, store, logger)
 
 	result, err := tm.UploadFile(ctx, session.DriveID, parentItem.ID, name, localPath, driveops.UploadOpts{
-		Mtime:    fi.ModTime(),
-		Progress: progress,
+		Mtime:            fi.ModTime(),
+		Progress:         progress,
+		ConflictBehavior: onConflict.behavior(),
 	})
+	if onConflict.skipsConflict(err) {
+		return reportConflictSkippedPut(ctx, cc, session, remotePath, fi.Size())
+	}
+
 	if err != nil {
 		return fmt.Errorf("uploading %q: %w", remotePath, err)
 	}
 
+	remotePath = renamedPath(remotePath, result.Item.Name)
+
 	visibleItem, err := session.WaitPathVisible(ctx, remotePath)
 	if err != nil {
 		return fmt.Errorf("confirming upload %q visibility: %w", remotePath, err)
@@ -135,6 +228,35 @@ func runPut(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// reportSkippedPut reports a single-file put that --update or
+// --ignore-existing left alone, or the error from deciding to.
+func reportSkippedPut(cc *CLIContext, remotePath string, existing *graph.Item, size int64, reason string, err error) error {
+	if err != nil {
+		return fmt.Errorf("comparing %q: %w", remotePath, err)
+	}
+
+	if cc.Flags.JSON {
+		return printPutJSON(cc.Output(), putJSONOutput{Path: remotePath, ID: existing.ID, Size: size, Skipped: reason})
+	}
+
+	cc.Statusf("Skipped %s (%s)\n", remotePath, reason)
+
+	return nil
+}
+
+// reportConflictSkippedPut reports a single-file upload that the service
+// refused under --on-conflict=skip because remotePath was already taken.
+func reportConflictSkippedPut(
+	ctx context.Context, cc *CLIContext, session *driveops.MountSession, remotePath string, size int64,
+) error {
+	existing, err := session.ResolveItem(ctx, remotePath)
+	if err != nil {
+		return fmt.Errorf("resolving %q: %w", remotePath, err)
+	}
+
+	return reportSkippedPut(cc, remotePath, existing, size, skipReasonExists, nil)
+}
+
 // printPutJSON writes the put command's single-file JSON output to w.
 func printPutJSON(w io.Writer, out putJSONOutput) error {
 	enc := json.NewEncoder(w)
@@ -161,10 +283,53 @@ func printPutFolderJSON(w io.Writer, out putFolderJSONOutput) error {
 
 // uploadWalkState holds mutable state for the upload walk callback.
 type uploadWalkState struct {
-	result putFolderJSONOutput
-	dirIDs map[string]string
-	done   int
-	total  int
+	result     putFolderJSONOutput
+	dirIDs     map[string]string
+	done       int
+	total      int
+	skip       transferSkipPolicy
+	existing   remoteChildIndex
+	onConflict conflictPolicy
+}
+
+// skipExisting applies the --update/--ignore-existing policy to one file and
+// records a skip, or the error from comparing, in the result. Each remote
+// folder is listed at most once. It reports whether the upload should not run.
+func (s *uploadWalkState) skipExisting(
+	ctx context.Context, session *driveops.MountSession, parentID, localPath, name, remotePath string,
+) bool {
+	if !s.skip.active() {
+		return false
+	}
+
+	existing, err := s.existing.lookup(func() ([]graph.Item, error) {
+		items, listErr := session.Meta.ListChildren(ctx, session.DriveID, parentID)
+		if listErr != nil {
+			return nil, fmt.Errorf("listing remote folder: %w", listErr)
+		}
+
+		return items, nil
+	}, parentID, name)
+
+	reason := ""
+	if err == nil {
+		reason, err = s.skip.skipUpload(localPath, existing)
+	}
+
+	if err != nil {
+		appendUploadWalkError(s, localPath, fmt.Errorf("comparing: %w", err))
+
+		return true
+	}
+
+	if reason == "" {
+		return false
+	}
+
+	s.result.Skipped = append(s.result.Skipped, skippedJSONOutput{Path: remotePath, Reason: reason})
+	s.done++
+
+	return true
 }
 
 func uploadFolder(
@@ -172,6 +337,8 @@ func uploadFolder(
 	cc *CLIContext,
 	session *driveops.MountSession,
 	localPath, remotePath string,
+	skip transferSkipPolicy,
+	onConflict conflictPolicy,
 ) error {
 	ctx := cmd.Context()
 	logger := cc.Logger
@@ -195,7 +362,10 @@ func uploadFolder(
 	tm := driveops.NewTransferManager(session.Transfer, session.Transfer, store, logger)
 
 	state := &uploadWalkState{
-		dirIDs: map[string]string{localPath: rootFolder.ID},
+		dirIDs:     map[string]string{localPath: rootFolder.ID},
+		skip:       skip,
+		existing:   remoteChildIndex{},
+		onConflict: onConflict,
 	}
 	state.result.FoldersCreated = 1
 
@@ -222,6 +392,10 @@ func uploadFolder(
 	cc.Statusf("Uploaded %d files, %d folders (%s)\n",
 		len(state.result.Files), state.result.FoldersCreated, formatSize(state.result.TotalSize))
 
+	if len(state.result.Skipped) > 0 {
+		cc.Statusf("Skipped %d existing files\n", len(state.result.Skipped))
+	}
+
 	if len(state.result.Errors) > 0 {
 		return fmt.Errorf("%d errors during upload", len(state.result.Errors))
 	}
@@ -351,14 +525,33 @@ func uploadFileEntry(
 		return nil
 	}
 
+	rel, relErr := filepath.Rel(localRoot, path)
+	if relErr != nil {
+		rel = d.Name()
+	}
+
+	remoteFilePath := driveops.CleanRemotePath(remotePath) + "/" + filepath.ToSlash(rel)
+
+	if state.skipExisting(ctx, session, parentID, path, d.Name(), remoteFilePath) {
+		return nil
+	}
+
 	progress := func(uploaded, totalBytes int64) {
 		cc.Statusf("Uploading %s: %s / %s\n", d.Name(), formatSize(uploaded), formatSize(totalBytes))
 	}
 
 	uploadResult, uploadErr := tm.UploadFile(ctx, session.DriveID, parentID, d.Name(), path, driveops.UploadOpts{
-		Mtime:    fi.ModTime(),
-		Progress: progress,
+		Mtime:            fi.ModTime(),
+		Progress:         progress,
+		ConflictBehavior: state.onConflict.behavior(),
 	})
+	if state.onConflict.skipsConflict(uploadErr) {
+		state.result.Skipped = append(state.result.Skipped, skippedJSONOutput{Path: remoteFilePath, Reason: skipReasonExists})
+		state.done++
+
+		return nil
+	}
+
 	if uploadErr != nil {
 		if isFatalUploadWalkError(uploadErr) {
 			return fmt.Errorf("upload file %q: %w", path, uploadErr)
@@ -369,15 +562,8 @@ func uploadFileEntry(
 		return nil
 	}
 
-	rel, relErr := filepath.Rel(localRoot, path)
-	if relErr != nil {
-		rel = d.Name()
-	}
-
-	remoteFilePath := driveops.CleanRemotePath(remotePath) + "/" + filepath.ToSlash(rel)
-
 	state.result.Files = append(state.result.Files, putJSONOutput{
-		Path: remoteFilePath,
+		Path: renamedPath(remoteFilePath, uploadResult.Item.Name),
 		ID:   uploadResult.Item.ID,
 		Size: fi.Size(),
 	})
@@ -11,7 +11,7 @@ import (
 	"github.com/tonimelisma/onedrive-go/internal/driveops"
 )
 
-func runSharedPut(cmd *cobra.Command, args []string, cc *CLIContext, fi os.FileInfo) error {
+func runSharedPut(cmd *cobra.Command, args []string, cc *CLIContext, fi os.FileInfo, skip transferSkipPolicy) error {
 	ctx := cmd.Context()
 
 	if fi.IsDir() {
@@ -30,6 +30,10 @@ func runSharedPut(cmd *cobra.Command, args []string, cc *CLIContext, fi os.FileI
 		)
 	}
 
+	if reason, skipErr := skip.skipUpload(args[0], item); skipErr != nil || reason != "" {
+		return reportSkippedPut(cc, cc.SharedTarget.Selector(), item, fi.Size(), reason, skipErr)
+	}
+
 	progress := func(uploaded, total int64) {
 		cc.Statusf("Uploading: %s / %s\n", formatSize(uploaded), formatSize(total))
 	}
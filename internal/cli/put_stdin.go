@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+)
+
+// stdinPath is the local-path argument that makes put read from stdin.
+const stdinPath = "-"
+
+// runPutStdin uploads stdin to an explicit remote file path. Stdin cannot be
+// rewound or re-read, so the upload is neither resumable across runs nor
+// retried from the start once bytes have been consumed.
+func runPutStdin(cmd *cobra.Command, args []string, onConflict conflictPolicy) error {
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	if len(args) < 2 {
+		return errors.New("put -: a remote file path is required when reading stdin")
+	}
+
+	if cc.SharedTarget != nil {
+		return errors.New("put -: uploading stdin to a shared target is not supported")
+	}
+
+	remotePath := args[1]
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	parentPath, name := driveops.SplitParentAndName(remotePath)
+	if name == "" {
+		return fmt.Errorf("put -: %q does not name a file", remotePath)
+	}
+
+	parentItem, err := resolveUploadParent(ctx, session, parentPath)
+	if err != nil {
+		return err
+	}
+
+	cc.Logger.Debug("put from stdin", "remote_path", remotePath)
+
+	progress := func(uploaded, _ int64) {
+		cc.Statusf("Uploading: %s\n", formatSize(uploaded))
+	}
+
+	tm := driveops.NewTransferManager(session.Transfer, session.Transfer, nil, cc.Logger)
+
+	result, err := tm.UploadStream(ctx, session.DriveID, parentItem.ID, name, cmd.InOrStdin(), driveops.UploadOpts{
+		Mtime:            time.Now(),
+		Progress:         progress,
+		ConflictBehavior: onConflict.behavior(),
+	})
+	if onConflict.skipsConflict(err) {
+		return reportConflictSkippedPut(ctx, cc, session, remotePath, 0)
+	}
+
+	if err != nil {
+		return fmt.Errorf("uploading stdin to %q: %w", remotePath, err)
+	}
+
+	remotePath = renamedPath(remotePath, result.Item.Name)
+
+	visibleItem, err := session.WaitPathVisible(ctx, remotePath)
+	if err != nil {
+		return fmt.Errorf("confirming upload %q visibility: %w", remotePath, err)
+	}
+
+	if cc.Flags.JSON {
+		return printPutJSON(cc.Output(), putJSONOutput{
+			Path: remotePath,
+			ID:   visibleItem.ID,
+			Size: result.Size,
+		})
+	}
+
+	cc.Statusf("Uploaded %s (%s)\n", remotePath, formatSize(result.Size))
+
+	return nil
+}
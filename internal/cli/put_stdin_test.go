@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/pkg/quickxorhash"
+)
+
+func newPutStdinTestContext(t *testing.T, remoteHash string, uploaded *string, stdout, stderr *bytes.Buffer) *CLIContext {
+	t.Helper()
+
+	return newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			if r.Method == http.MethodPut {
+				body, err := io.ReadAll(r.Body)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				assert.True(t, strings.HasSuffix(r.URL.Path, "/backups:/db.sql:/content"), r.URL.Path)
+				*uploaded = string(body)
+				w.WriteHeader(http.StatusCreated)
+				writeTestResponsef(t, w, `{"id":"db","name":"db.sql","size":%d,"file":{"hashes":{"quickXorHash":%q}}}`,
+					len(body), remoteHash)
+
+				return
+			}
+
+			if r.Method == http.MethodPatch || strings.Contains(r.URL.Path, "db.sql") {
+				// The mtime PATCH after a simple upload returns the final item.
+				writeTestResponsef(t, w, `{"id":"db","name":"db.sql","file":{"hashes":{"quickXorHash":%q}},
+					"parentReference":{"id":"backups"}}`, remoteHash)
+				return
+			}
+
+			writeTestResponse(t, w, `{"id":"backups","name":"Backups","folder":{},"parentReference":{"id":"root"}}`)
+		}),
+		stdout,
+		stderr,
+	)
+}
+
+func putStdinTestHash(content string) string {
+	h := quickxorhash.New()
+	_, _ = h.Write([]byte(content))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// Validates: R-1.3.7
+func TestRunPut_StdinUploadsAndVerifiesHash(t *testing.T) {
+	const content = "CREATE TABLE t (id int);\n"
+
+	var stdout, stderr bytes.Buffer
+	var uploaded string
+	cc := newPutStdinTestContext(t, putStdinTestHash(content), &uploaded, &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newPutCmd()
+	cmd.SetArgs([]string{"-", "/Backups/db.sql"})
+	cmd.SetIn(strings.NewReader(content))
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, content, uploaded)
+
+	var out putJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, "/Backups/db.sql", out.Path)
+	assert.Equal(t, "db", out.ID)
+	assert.Equal(t, int64(len(content)), out.Size)
+}
+
+// Validates: R-1.3.7
+func TestRunPut_StdinHashMismatchFails(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var uploaded string
+	cc := newPutStdinTestContext(t, putStdinTestHash("different"), &uploaded, &stdout, &stderr)
+
+	cmd := newPutCmd()
+	cmd.SetArgs([]string{"-", "/Backups/db.sql"})
+	cmd.SetIn(strings.NewReader("content"))
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "content hash mismatch")
+}
+
+func TestRunPut_StdinRequiresRemotePath(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var uploaded string
+	cc := newPutStdinTestContext(t, "", &uploaded, &stdout, &stderr)
+
+	cmd := newPutCmd()
+	cmd.SetArgs([]string{"-"})
+	cmd.SetIn(strings.NewReader("content"))
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.Error(t, cmd.Execute())
+	assert.Empty(t, uploaded)
+}
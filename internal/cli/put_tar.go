@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+)
+
+// tarExtractor recreates a tar stream below one remote folder. Folder IDs are
+// cached by archive-relative path ("" is the destination folder itself).
+type tarExtractor struct {
+	session *driveops.MountSession
+	tm      *driveops.TransferManager
+	root    string
+	folders map[string]string
+	result  putFolderJSONOutput
+	skipped int
+}
+
+func runPutTar(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	if args[0] != stdinPath || len(args) < 2 {
+		return errors.New("put --tar reads the archive from stdin: use put --tar - <remote-folder>")
+	}
+
+	if cc.SharedTarget != nil {
+		return errors.New("put --tar does not support shared targets; use drive add to mount the folder first")
+	}
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	root := driveops.CleanRemotePath(args[1])
+
+	rootID, err := ensureRemoteFolderPath(ctx, session, root)
+	if err != nil {
+		return err
+	}
+
+	ex := &tarExtractor{
+		session: session,
+		tm:      driveops.NewTransferManager(session.Transfer, session.Transfer, nil, cc.Logger),
+		root:    root,
+		folders: map[string]string{"": rootID},
+	}
+
+	if err := ex.extract(ctx, cc, tar.NewReader(cmd.InOrStdin())); err != nil {
+		return err
+	}
+
+	if ex.skipped > 0 {
+		cc.Statusf("Skipped %d entries that are not files or folders\n", ex.skipped)
+	}
+
+	if cc.Flags.JSON {
+		if err := printPutFolderJSON(cc.Output(), ex.result); err != nil {
+			return err
+		}
+	} else {
+		cc.Statusf("Uploaded %d files, %d folders (%s)\n",
+			len(ex.result.Files), ex.result.FoldersCreated, formatSize(ex.result.TotalSize))
+	}
+
+	if len(ex.result.Errors) > 0 {
+		return fmt.Errorf("%d errors during tar upload", len(ex.result.Errors))
+	}
+
+	return nil
+}
+
+// extract consumes the archive in one pass. A failed entry is recorded and
+// skipped; tar.Reader.Next discards whatever the failed upload left unread,
+// so later entries still line up.
+func (ex *tarExtractor) extract(ctx context.Context, cc *CLIContext, tr *tar.Reader) error {
+	ex.result.Files = []putJSONOutput{}
+	ex.result.Errors = []string{}
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("reading tar stream: %w", err)
+		}
+
+		name, ok := cleanTarEntryName(hdr.Name)
+		if !ok {
+			ex.result.Errors = append(ex.result.Errors, fmt.Sprintf("%q: path escapes the destination folder", hdr.Name))
+			continue
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if _, err := ex.folderID(ctx, name); err != nil {
+				ex.result.Errors = append(ex.result.Errors, fmt.Sprintf("%s: %v", name, err))
+			}
+		case tar.TypeReg:
+			if err := ex.upload(ctx, hdr, name, tr); err != nil {
+				ex.result.Errors = append(ex.result.Errors, fmt.Sprintf("%s: %v", name, err))
+				continue
+			}
+
+			cc.Statusf("Uploaded %s\n", name)
+		default:
+			cc.Logger.Debug("skipping tar entry", "name", hdr.Name, "type", string(hdr.Typeflag))
+			ex.skipped++
+		}
+	}
+}
+
+func (ex *tarExtractor) upload(ctx context.Context, hdr *tar.Header, name string, r io.Reader) error {
+	dir, base := path.Split(name)
+
+	parentID, err := ex.folderID(ctx, strings.TrimSuffix(dir, "/"))
+	if err != nil {
+		return err
+	}
+
+	result, err := ex.tm.UploadStream(ctx, ex.session.DriveID, parentID, base, r, driveops.UploadOpts{
+		Mtime: hdr.ModTime,
+	})
+	if err != nil {
+		return fmt.Errorf("uploading: %w", err)
+	}
+
+	ex.result.Files = append(ex.result.Files, putJSONOutput{
+		Path: joinRemotePath(ex.root, name),
+		ID:   result.Item.ID,
+		Size: result.Size,
+	})
+	ex.result.TotalSize += result.Size
+
+	return nil
+}
+
+// folderID returns the remote ID of the archive-relative folder rel, creating
+// it and any missing ancestors. Archives need not list parent directories
+// before their contents.
+func (ex *tarExtractor) folderID(ctx context.Context, rel string) (string, error) {
+	if id, ok := ex.folders[rel]; ok {
+		return id, nil
+	}
+
+	parent, base := path.Split(rel)
+
+	parentID, err := ex.folderID(ctx, strings.TrimSuffix(parent, "/"))
+	if err != nil {
+		return "", err
+	}
+
+	item, err := ex.session.EnsureFolder(ctx, parentID, base)
+	if err != nil {
+		return "", fmt.Errorf("creating folder %q: %w", rel, err)
+	}
+
+	ex.folders[rel] = item.ID
+	ex.result.FoldersCreated++
+
+	return item.ID, nil
+}
+
+// cleanTarEntryName normalizes an archive path ("./a/b/", "a//b") to "a/b".
+// Absolute paths and paths that climb out of the archive root are rejected.
+// The archive root itself ("." or "./") comes back as "".
+func cleanTarEntryName(name string) (string, bool) {
+	if strings.HasPrefix(name, "/") {
+		return "", false
+	}
+
+	clean := path.Clean(name)
+	if clean == "." {
+		return "", true
+	}
+
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", false
+	}
+
+	return clean, true
+}
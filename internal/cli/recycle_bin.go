@@ -36,10 +36,14 @@ func newRecycleBinListCmd() *cobra.Command {
 
 func newRecycleBinRestoreCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "restore <item-id>",
-		Short: "Restore an item from the recycle bin",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runRecycleBinRestore,
+		Use:   "restore <item-id>...",
+		Short: "Restore items from the recycle bin",
+		Long: `Restore recycle-bin items to their original locations. Several IDs are
+restored through Graph JSON batching, 20 per request; an item that cannot be
+restored does not stop the others.`,
+		Args:        cobra.MinimumNArgs(1),
+		Annotations: map[string]string{mutatesDriveAnnotation: mutatesDriveValue},
+		RunE:        runRecycleBinRestore,
 	}
 }
 
@@ -49,7 +53,8 @@ func newRecycleBinEmptyCmd() *cobra.Command {
 		Short: "Permanently delete all items in the recycle bin",
 		Long: `Permanently delete all items in the recycle bin. This action cannot be undone.
 Requires --confirm flag to proceed.`,
-		RunE: runRecycleBinEmpty,
+		Annotations: map[string]string{mutatesDriveAnnotation: mutatesDriveValue},
+		RunE:        runRecycleBinEmpty,
 	}
 
 	cmd.Flags().Bool("confirm", false, "confirm permanent deletion of all recycle bin items")
@@ -66,7 +71,7 @@ func runRecycleBinList(cmd *cobra.Command, _ []string) error {
 func runRecycleBinRestore(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 	cc := mustCLIContext(ctx)
-	return runRecycleBinRestoreWithFactory(ctx, cc, args[0], defaultRecycleBinSessionFactory(cc))
+	return runRecycleBinRestoreWithFactory(ctx, cc, args, defaultRecycleBinSessionFactory(cc))
 }
 
 func runRecycleBinEmpty(cmd *cobra.Command, _ []string) error {
@@ -142,6 +147,16 @@ func printRecycleBinRestoreJSON(w io.Writer, item recycleBinJSONItem) error {
 	return nil
 }
 
+func newRecycleBinRestoreJSONItem(item *graph.Item) recycleBinJSONItem {
+	return recycleBinJSONItem{
+		ID:      item.ID,
+		Name:    item.Name,
+		Size:    item.Size,
+		Type:    itemType(item),
+		Deleted: item.ModifiedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
 func formatRecycleBinJSON(w io.Writer, items []graph.Item) error {
 	out := make([]recycleBinJSONItem, 0, len(items))
 	for i := range items {
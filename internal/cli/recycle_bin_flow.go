@@ -11,6 +11,7 @@ import (
 type recycleBinSession interface {
 	ListRecycleBinItems(ctx context.Context) ([]graph.Item, error)
 	RestoreItem(ctx context.Context, itemID string) (*graph.Item, error)
+	RestoreItemsBatch(ctx context.Context, itemIDs []string) ([]graph.BatchItemResult, error)
 	PermanentDeleteItem(ctx context.Context, itemID string) error
 	DeleteItem(ctx context.Context, itemID string) error
 }
@@ -52,7 +53,7 @@ func runRecycleBinListWithFactory(
 func runRecycleBinRestoreWithFactory(
 	ctx context.Context,
 	cc *CLIContext,
-	itemID string,
+	itemIDs []string,
 	sessionFactory recycleBinSessionFactory,
 ) error {
 	session, err := sessionFactory(ctx)
@@ -60,23 +61,17 @@ func runRecycleBinRestoreWithFactory(
 		return err
 	}
 
-	item, err := session.RestoreItem(ctx, itemID)
-	if err != nil {
-		if errors.Is(err, graph.ErrConflict) {
-			return fmt.Errorf("cannot restore %q: a file with the same name already exists at the original location", itemID)
-		}
+	if len(itemIDs) > 1 {
+		return restoreRecycleBinItems(ctx, cc, session, itemIDs)
+	}
 
-		return fmt.Errorf("restoring item: %w", err)
+	item, err := session.RestoreItem(ctx, itemIDs[0])
+	if err != nil {
+		return recycleBinRestoreError(itemIDs[0], err)
 	}
 
 	if cc.Flags.JSON {
-		return printRecycleBinRestoreJSON(cc.Output(), recycleBinJSONItem{
-			ID:      item.ID,
-			Name:    item.Name,
-			Size:    item.Size,
-			Type:    itemType(item),
-			Deleted: item.ModifiedAt.Format("2006-01-02T15:04:05Z"),
-		})
+		return printRecycleBinRestoreJSON(cc.Output(), newRecycleBinRestoreJSONItem(item))
 	}
 
 	cc.Statusf("Restored %q (id: %s)\n", item.Name, item.ID)
@@ -84,6 +79,51 @@ func runRecycleBinRestoreWithFactory(
 	return nil
 }
 
+// restoreRecycleBinItems restores several items through one JSON batch per
+// 20 IDs, reporting every restore before returning the failures together.
+func restoreRecycleBinItems(ctx context.Context, cc *CLIContext, session recycleBinSession, itemIDs []string) error {
+	results, err := session.RestoreItemsBatch(ctx, itemIDs)
+	if err != nil {
+		return fmt.Errorf("restoring items: %w", err)
+	}
+
+	outs := make([]recycleBinJSONItem, 0, len(results))
+
+	var failed []error
+
+	for i := range results {
+		if results[i].Err != nil {
+			failed = append(failed, recycleBinRestoreError(itemIDs[i], results[i].Err))
+			continue
+		}
+
+		item := results[i].Item
+		outs = append(outs, newRecycleBinRestoreJSONItem(item))
+
+		if !cc.Flags.JSON {
+			cc.Statusf("Restored %q (id: %s)\n", item.Name, item.ID)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d restores failed: %w", len(failed), len(itemIDs), errors.Join(failed...))
+	}
+
+	if cc.Flags.JSON {
+		return printSourcesJSON(cc.Output(), outs, true, "recycle bin restore")
+	}
+
+	return nil
+}
+
+func recycleBinRestoreError(itemID string, err error) error {
+	if errors.Is(err, graph.ErrConflict) {
+		return fmt.Errorf("cannot restore %q: a file with the same name already exists at the original location", itemID)
+	}
+
+	return fmt.Errorf("restoring item: %w", err)
+}
+
 func runRecycleBinEmptyWithFactory(
 	ctx context.Context,
 	cc *CLIContext,
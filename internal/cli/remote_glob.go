@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"path"
+	"strings"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+)
+
+// remoteGlobMeta are the characters that make a remote path component a
+// path.Match pattern. OneDrive forbids backslashes in names, so a backslash
+// can only be an escape.
+const remoteGlobMeta = `*?[\`
+
+// splitRemoteGlob splits a remote path whose last component is a glob into
+// the folder to list and the pattern. ok is false for a plain path or an
+// invalid pattern. Only the last component is ever a pattern, so folder names
+// containing brackets still work as parents.
+func splitRemoteGlob(remotePath string) (parent, pattern string, ok bool) {
+	parent, pattern = driveops.SplitParentAndName(remotePath)
+	if !strings.ContainsAny(pattern, remoteGlobMeta) {
+		return "", "", false
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return "", "", false
+	}
+
+	return parent, pattern, true
+}
+
+// matchRemoteGlob matches a name the way remote names are compared
+// everywhere else: NFC-normalized and without regard to case.
+func matchRemoteGlob(pattern, name string) bool {
+	ok, err := path.Match(mirrorKey(pattern), mirrorKey(name))
+
+	return err == nil && ok
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Validates: R-1.1.7
+func TestSplitRemoteGlob(t *testing.T) {
+	t.Parallel()
+
+	parent, pattern, ok := splitRemoteGlob("/Reports/*.xlsx")
+	assert.True(t, ok)
+	assert.Equal(t, "Reports", parent)
+	assert.Equal(t, "*.xlsx", pattern)
+
+	_, _, ok = splitRemoteGlob("/Reports/q1.xlsx")
+	assert.False(t, ok, "a plain path is not a glob")
+
+	_, _, ok = splitRemoteGlob("/Reports/[2024")
+	assert.False(t, ok, "an invalid pattern is treated as a literal name")
+
+	parent, _, ok = splitRemoteGlob("/Photos [2024]/*.jpg")
+	assert.True(t, ok)
+	assert.Equal(t, "Photos [2024]", parent, "only the last component is a pattern")
+}
+
+// Validates: R-1.1.7
+func TestMatchRemoteGlob(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, matchRemoteGlob("*.LOG", "app.log"))
+	assert.True(t, matchRemoteGlob("Café*", "Café menu.pdf"))
+	assert.True(t, matchRemoteGlob(`\*.txt`, "*.txt"))
+	assert.False(t, matchRemoteGlob(`\*.txt`, "a.txt"))
+	assert.False(t, matchRemoteGlob("*.log", "app.log.old"))
+}
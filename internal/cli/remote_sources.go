@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// expandRemoteSources expands the source arguments of a file command. A glob
+// becomes the matching children of its folder, sorted by name, and a glob
+// that matches nothing is kept when it names a real item. batch reports
+// whether the arguments could stand for several items, in which case JSON
+// output is an array even when only one item matched.
+func expandRemoteSources(
+	ctx context.Context, session *driveops.MountSession, args []string,
+) (sources []string, batch bool, err error) {
+	batch = len(args) > 1
+
+	for _, arg := range args {
+		parent, pattern, isGlob := splitRemoteGlob(arg)
+		if !isGlob {
+			sources = append(sources, arg)
+
+			continue
+		}
+
+		batch = true
+
+		matches, err := globRemoteChildren(ctx, session, parent, pattern)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if len(matches) > 0 {
+			sources = append(sources, matches...)
+
+			continue
+		}
+
+		// A name such as "Photos [2024]" looks like a pattern but may be real.
+		if _, err := session.ResolveItem(ctx, arg); err != nil {
+			if errors.Is(err, graph.ErrNotFound) {
+				return nil, false, fmt.Errorf("no items match %q", arg)
+			}
+
+			return nil, false, fmt.Errorf("resolving %q: %w", arg, err)
+		}
+
+		sources = append(sources, arg)
+	}
+
+	return sources, batch, nil
+}
+
+// globRemoteChildren lists parent and returns the paths of the children
+// whose names match pattern.
+func globRemoteChildren(ctx context.Context, session *driveops.MountSession, parent, pattern string) ([]string, error) {
+	children, err := session.ListChildren(ctx, parent)
+	if err != nil {
+		return nil, fmt.Errorf("listing %q: %w", parent, err)
+	}
+
+	var matches []string
+
+	for i := range children {
+		if matchRemoteGlob(pattern, children[i].Name) {
+			matches = append(matches, "/"+joinRemotePath(parent, children[i].Name))
+		}
+	}
+
+	sort.Strings(matches)
+
+	return matches, nil
+}
+
+// planActionWouldSkip marks a --dry-run source whose destination name is
+// taken under --on-conflict=skip.
+const planActionWouldSkip = "would_skip"
+
+// sourcePlanJSON is the JSON output schema for one source under --dry-run.
+type sourcePlanJSON struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination,omitempty"`
+	Action      string `json:"action"`
+}
+
+// printSourcePlan reports what a --dry-run would have done to each source.
+// verb is the status-line wording, such as "delete" or "move".
+func printSourcePlan(cc *CLIContext, verb string, plan []sourcePlanJSON) error {
+	if cc.Flags.JSON {
+		return printSourcesJSON(cc.Output(), plan, true, verb+" plan")
+	}
+
+	for _, p := range plan {
+		switch {
+		case p.Action == planActionWouldSkip:
+			cc.Statusf("Would skip %s (%s exists)\n", p.Source, p.Destination)
+		case p.Destination == "":
+			cc.Statusf("Would %s %s\n", verb, p.Source)
+		default:
+			cc.Statusf("Would %s %s → %s\n", verb, p.Source, p.Destination)
+		}
+	}
+
+	return nil
+}
+
+// printSourcesJSON prints the results of a file command: the lone object for
+// a single plain source, otherwise an array with one object per source.
+func printSourcesJSON[T any](w io.Writer, outs []T, batch bool, what string) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	var v any = outs
+	if !batch && len(outs) == 1 {
+		v = outs[0]
+	}
+
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encode %s output: %w", what, err)
+	}
+
+	return nil
+}
+
+// transferSource is one resolved source of an mv or cp invocation.
+type transferSource struct {
+	path string
+	item *graph.Item
+}
+
+// transferSources are the resolved sources of an mv or cp invocation.
+type transferSources struct {
+	items []transferSource
+	batch bool
+}
+
+// resolveTransferSources expands and resolves every source before anything
+// is moved or copied. Several sources need destPath to be an existing folder,
+// since they cannot all take its name.
+func resolveTransferSources(
+	ctx context.Context, session *driveops.MountSession, args []string, destPath string,
+) (transferSources, error) {
+	paths, batch, err := expandRemoteSources(ctx, session, args)
+	if err != nil {
+		return transferSources{}, err
+	}
+
+	if batch {
+		dest, err := session.ResolveItem(ctx, destPath)
+		if err != nil {
+			return transferSources{}, fmt.Errorf("resolving destination %q: %w", destPath, err)
+		}
+
+		if !dest.IsFolder {
+			return transferSources{}, fmt.Errorf("destination %q must be an existing folder for several sources", destPath)
+		}
+	}
+
+	sources := transferSources{items: make([]transferSource, 0, len(paths)), batch: batch}
+
+	for _, sourcePath := range paths {
+		item, err := session.ResolveItem(ctx, sourcePath)
+		if err != nil {
+			return transferSources{}, fmt.Errorf("resolving source %q: %w", sourcePath, err)
+		}
+
+		sources.items = append(sources.items, transferSource{path: sourcePath, item: item})
+	}
+
+	return sources, nil
+}
+
+// planTransferSources prints where each source would go under --dry-run.
+// Resolving the destination is read-only, so the plan shows the same taken
+// names and skips the real run would hit.
+func planTransferSources(
+	ctx context.Context,
+	cc *CLIContext,
+	session *driveops.MountSession,
+	sources transferSources,
+	destPath string,
+	onConflict conflictPolicy,
+	verb string,
+) error {
+	plan := make([]sourcePlanJSON, 0, len(sources.items))
+
+	for _, source := range sources.items {
+		dest, err := resolveDest(ctx, session, destPath, source.item.Name, onConflict)
+		if err != nil {
+			return err
+		}
+
+		action := "would_" + verb
+		if onConflict == conflictSkip && dest.existingID != "" {
+			action = planActionWouldSkip
+		}
+
+		plan = append(plan, sourcePlanJSON{
+			Source:      source.path,
+			Destination: finalDestPath(destPath, dest, dest.newName),
+			Action:      action,
+		})
+	}
+
+	return printSourcePlan(cc, verb, plan)
+}
@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// sourcesTestServer fakes a drive with a Tmp folder holding a.log, B.LOG,
+// keep.txt and the folder "Photos [2024]", plus an empty Archive folder. It
+// records every mutation as "METHOD id".
+type sourcesTestServer struct {
+	mu        sync.Mutex
+	mutations []string
+}
+
+var sourcesTestTmpItems = map[string]string{
+	"a.log":         `{"id":"a","name":"a.log","size":3,"file":{},"parentReference":{"id":"tmp"}}`,
+	"B.LOG":         `{"id":"b","name":"B.LOG","size":3,"file":{},"parentReference":{"id":"tmp"}}`,
+	"keep.txt":      `{"id":"k","name":"keep.txt","size":4,"file":{},"parentReference":{"id":"tmp"}}`,
+	"Photos [2024]": `{"id":"p","name":"Photos [2024]","folder":{},"parentReference":{"id":"tmp"}}`,
+}
+
+func (s *sourcesTestServer) recorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.mutations...)
+}
+
+func (s *sourcesTestServer) handler(t *testing.T) http.Handler {
+	t.Helper()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/$batch"):
+			s.serveBatch(t, w, r)
+		case r.Method == http.MethodDelete || r.Method == http.MethodPatch:
+			s.mu.Lock()
+			s.mutations = append(s.mutations, r.Method+" "+id)
+			s.mu.Unlock()
+
+			if r.Method == http.MethodDelete {
+				w.WriteHeader(http.StatusNoContent)
+
+				return
+			}
+
+			writeTestResponsef(t, w, `{"id":%q,"name":"moved-%s","parentReference":{"id":"arch"}}`, id, id)
+		case strings.HasPrefix(r.URL.Path, "/dl/"):
+			writeTestResponse(t, w, "log")
+		case strings.HasSuffix(r.URL.Path, "root:/Tmp:/children"):
+			items := make([]string, 0, len(sourcesTestTmpItems))
+			for _, item := range sourcesTestTmpItems {
+				items = append(items, item)
+			}
+
+			writeTestResponsef(t, w, `{"value":[%s]}`, strings.Join(items, ","))
+		case strings.Contains(r.URL.Path, "root:/Tmp/"):
+			if item, ok := sourcesTestTmpItems[sourcesTestName(r, "root:/Tmp/")]; ok {
+				writeTestResponse(t, w, item)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+			writeTestResponse(t, w, `{"error":{"code":"itemNotFound"}}`)
+		case strings.Contains(r.URL.Path, "root:/Archive/"):
+			writeTestResponsef(t, w, `{"id":"moved","name":%q,"file":{},"parentReference":{"id":"arch"}}`,
+				sourcesTestName(r, "root:/Archive/"))
+		case strings.HasSuffix(r.URL.Path, "root:/Archive:"):
+			writeTestResponse(t, w, `{"id":"arch","name":"Archive","folder":{},"parentReference":{"id":"root"}}`)
+		case strings.HasSuffix(r.URL.Path, "/items/a") || strings.HasSuffix(r.URL.Path, "/items/b"):
+			writeTestResponsef(t, w, `{"id":%q,"name":"x","size":3,"file":{},"@microsoft.graph.downloadUrl":"http://%s/dl/%s"}`,
+				id, r.Host, id)
+		default:
+			writeTestResponse(t, w, `{"id":"tmp","name":"Tmp","folder":{},"parentReference":{"id":"root","path":"/drive/root:"}}`)
+		}
+	})
+}
+
+// serveBatch answers a JSON batch of deletes, recording each like a single
+// DELETE.
+func (s *sourcesTestServer) serveBatch(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	t.Helper()
+
+	var batch struct {
+		Requests []struct {
+			ID     string `json:"id"`
+			Method string `json:"method"`
+			URL    string `json:"url"`
+		} `json:"requests"`
+	}
+	assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+
+	responses := make([]string, 0, len(batch.Requests))
+
+	s.mu.Lock()
+	for _, req := range batch.Requests {
+		s.mutations = append(s.mutations, req.Method+" "+req.URL[strings.LastIndex(req.URL, "/")+1:])
+		responses = append(responses, fmt.Sprintf(`{"id":%q,"status":204}`, req.ID))
+	}
+	s.mu.Unlock()
+
+	writeTestResponsef(t, w, `{"responses":[%s]}`, strings.Join(responses, ","))
+}
+
+// sourcesTestName returns the item name a path lookup under prefix asks for.
+func sourcesTestName(r *http.Request, prefix string) string {
+	return strings.TrimSuffix(r.URL.Path[strings.Index(r.URL.Path, prefix)+len(prefix):], ":")
+}
+
+func runSourcesTestCommand(
+	t *testing.T, srv *sourcesTestServer, newCmd func() *cobra.Command, args ...string,
+) (stdout, stderr string, err error) {
+	t.Helper()
+
+	var out, errOut bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), srv.handler(t), &out, &errOut)
+	cc.Flags.JSON = true
+
+	cmd := newCmd()
+	cmd.SetArgs(args)
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	err = cmd.Execute()
+
+	return out.String(), errOut.String(), err
+}
+
+// Validates: R-1.19, R-1.19.1
+func TestExpandRemoteSources(t *testing.T) {
+	srv := &sourcesTestServer{}
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), srv.handler(t), &stdout, &stderr)
+
+	session, err := cc.Session(t.Context())
+	require.NoError(t, err)
+
+	sources, batch, err := expandRemoteSources(t.Context(), session, []string{"/Tmp/*.LOG"})
+	require.NoError(t, err)
+	assert.True(t, batch)
+	assert.Equal(t, []string{"/Tmp/B.LOG", "/Tmp/a.log"}, sources)
+
+	sources, batch, err = expandRemoteSources(t.Context(), session, []string{"/Tmp/Photos [2024]"})
+	require.NoError(t, err)
+	assert.True(t, batch)
+	assert.Equal(t, []string{"/Tmp/Photos [2024]"}, sources, "a real name that looks like a pattern is kept")
+
+	sources, batch, err = expandRemoteSources(t.Context(), session, []string{"/Tmp/keep.txt"})
+	require.NoError(t, err)
+	assert.False(t, batch)
+	assert.Equal(t, []string{"/Tmp/keep.txt"}, sources)
+
+	_, _, err = expandRemoteSources(t.Context(), session, []string{"/Tmp/*.pdf"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no items match "/Tmp/*.pdf"`)
+}
+
+// Validates: R-1.19.2
+func TestRunRm_GlobDryRunDeletesNothing(t *testing.T) {
+	srv := &sourcesTestServer{}
+
+	stdout, _, err := runSourcesTestCommand(t, srv, newRmCmd, "--dry-run", "/Tmp/*.log")
+	require.NoError(t, err)
+	assert.Empty(t, srv.recorded())
+
+	var plan []sourcePlanJSON
+	require.NoError(t, json.Unmarshal([]byte(stdout), &plan))
+	assert.Equal(t, []sourcePlanJSON{
+		{Source: "/Tmp/B.LOG", Action: findActionWouldDelete},
+		{Source: "/Tmp/a.log", Action: findActionWouldDelete},
+	}, plan)
+}
+
+// Validates: R-1.19.3, R-1.19.5
+func TestRunRm_ResolvesEverySourceBeforeDeleting(t *testing.T) {
+	srv := &sourcesTestServer{}
+
+	_, _, err := runSourcesTestCommand(t, srv, newRmCmd, "/Tmp/a.log", "/Tmp/Photos [2024]")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "without --recursive")
+	assert.Empty(t, srv.recorded(), "no source is deleted when another one fails to resolve")
+
+	stdout, _, err := runSourcesTestCommand(t, srv, newRmCmd, "/Tmp/*.log")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DELETE b", "DELETE a"}, srv.recorded())
+
+	var out []rmJSONOutput
+	require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+	assert.Equal(t, []rmJSONOutput{{Deleted: "/Tmp/B.LOG"}, {Deleted: "/Tmp/a.log"}}, out)
+}
+
+// Validates: R-1.19.3
+func TestRunMv_SeveralSourcesMoveIntoFolder(t *testing.T) {
+	srv := &sourcesTestServer{}
+
+	_, _, err := runSourcesTestCommand(t, srv, newMvCmd, "/Tmp/a.log", "/Tmp/keep.txt", "/Tmp/B.LOG")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be an existing folder")
+	assert.Empty(t, srv.recorded())
+
+	stdout, _, err := runSourcesTestCommand(t, srv, newMvCmd, "/Tmp/a.log", "/Tmp/keep.txt", "/Archive")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PATCH a", "PATCH k"}, srv.recorded())
+
+	var out []mvJSONOutput
+	require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+	require.Len(t, out, 2)
+	assert.Equal(t, "Archive/moved-a", out[0].Destination)
+	assert.Equal(t, "/Tmp/keep.txt", out[1].Source)
+}
+
+// Validates: R-1.19.2
+func TestRunCp_DryRunListsDestinations(t *testing.T) {
+	srv := &sourcesTestServer{}
+
+	stdout, _, err := runSourcesTestCommand(t, srv, newCpCmd, "--dry-run", "/Tmp/*.log", "/Archive")
+	require.NoError(t, err)
+	assert.Empty(t, srv.recorded())
+
+	var plan []sourcePlanJSON
+	require.NoError(t, json.Unmarshal([]byte(stdout), &plan))
+	assert.Equal(t, []sourcePlanJSON{
+		{Source: "/Tmp/B.LOG", Destination: "Archive/B.LOG", Action: "would_copy"},
+		{Source: "/Tmp/a.log", Destination: "Archive/a.log", Action: "would_copy"},
+	}, plan)
+}
+
+// Validates: R-1.19.4
+func TestRunGet_GlobDownloadsIntoLocalFolder(t *testing.T) {
+	srv := &sourcesTestServer{}
+	local := filepath.Join(t.TempDir(), "logs")
+
+	stdout, _, err := runSourcesTestCommand(t, srv, newGetCmd, "/Tmp/*.log", local)
+	require.NoError(t, err)
+
+	var out getFolderJSONOutput
+	require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+	assert.Len(t, out.Files, 2)
+	assert.Equal(t, 1, out.FoldersCreated)
+	assert.Empty(t, out.Errors)
+
+	for _, name := range []string{"a.log", "B.LOG"} {
+		data, err := os.ReadFile(filepath.Join(local, name))
+		require.NoError(t, err, name)
+		assert.Equal(t, "log", string(data), fmt.Sprintf("%s content", name))
+	}
+}
@@ -2,7 +2,6 @@ package cli
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -17,21 +16,29 @@ import (
 
 func newRmCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "rm <path>",
-		Short: "Delete a file or folder (moves to OneDrive recycle bin)",
-		Long: `Delete a file or folder on OneDrive. Items are moved to the OneDrive
+		Use:   "rm <path>...",
+		Short: "Delete files or folders (moves to OneDrive recycle bin)",
+		Long: `Delete files or folders on OneDrive. Items are moved to the OneDrive
 recycle bin by default and can be restored from the OneDrive web interface.
 
 Folder deletion is recursive — all contents will be deleted.
 Use --recursive (-r) to confirm intent when deleting folders.
 
-Use --permanent for permanent deletion (bypasses the recycle bin).`,
-		Args: cobra.ExactArgs(1),
-		RunE: runRm,
+Use --permanent for permanent deletion (bypasses the recycle bin).
+
+The last component of each path may be a glob, as in rm '/Tmp/*.log'. Every
+source is resolved before anything is deleted, and --dry-run only lists what
+would be deleted. Several items are deleted through Graph JSON batching, 20
+per request; a failed delete does not stop the others.`,
+		Args:              cobra.MinimumNArgs(1),
+		Annotations:       map[string]string{mutatesDriveAnnotation: mutatesDriveValue},
+		RunE:              runRm,
+		ValidArgsFunction: completeRemotePaths(remoteCompletion{sharedArg: noSharedArg}),
 	}
 
 	cmd.Flags().BoolP("recursive", "r", false, "confirm recursive folder deletion")
 	cmd.Flags().Bool("permanent", false, "permanently delete instead of moving to recycle bin (Business/SharePoint only)")
+	cmd.Flags().Bool("dry-run", false, "show what would be deleted without deleting")
 
 	return cmd
 }
@@ -48,56 +55,167 @@ const (
 	rmDeletePermanent
 )
 
+// rmTarget is one resolved source of an rm invocation.
+type rmTarget struct {
+	path   string
+	itemID string
+}
+
 func runRm(cmd *cobra.Command, args []string) error {
-	remotePath := args[0]
 	ctx := cmd.Context()
 	cc := mustCLIContext(ctx)
 
+	recursive, err := cmd.Flags().GetBool("recursive")
+	if err != nil {
+		return fmt.Errorf("read --recursive flag: %w", err)
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("read --dry-run flag: %w", err)
+	}
+
+	deleteMode, err := resolveRmDeleteMode(cmd)
+	if err != nil {
+		return err
+	}
+
 	session, err := cc.Session(ctx)
 	if err != nil {
 		return err
 	}
 
 	logger := cc.Logger
-	logger.Debug("rm", "path", remotePath)
+	logger.Debug("rm", "paths", args, "dry_run", dryRun)
 
-	item, err := session.ResolveDeleteTarget(ctx, remotePath)
+	sources, batch, err := expandRemoteSources(ctx, session, args)
 	if err != nil {
-		return fmt.Errorf("resolving %q: %w", remotePath, err)
+		return err
 	}
 
-	// Require --recursive for folder deletion (B-156).
-	recursive, err := cmd.Flags().GetBool("recursive")
+	targets, err := resolveRmTargets(ctx, session, sources, recursive)
 	if err != nil {
-		return fmt.Errorf("read --recursive flag: %w", err)
+		return err
 	}
 
-	if item.IsFolder && !recursive {
-		return fmt.Errorf("cannot delete folder %q without --recursive (-r) flag", remotePath)
+	if dryRun {
+		plan := make([]sourcePlanJSON, 0, len(targets))
+		for _, target := range targets {
+			plan = append(plan, sourcePlanJSON{Source: target.path, Action: findActionWouldDelete})
+		}
+
+		return printSourcePlan(cc, "delete", plan)
 	}
 
-	deleteMode, err := resolveRmDeleteMode(cmd)
+	if len(targets) > 1 {
+		return runRmBatch(ctx, cc, session, targets, deleteMode, batch)
+	}
+
+	outs := make([]rmJSONOutput, 0, len(targets))
+
+	for _, target := range targets {
+		if err := executeRmDelete(ctx, session, target.itemID, target.path, deleteMode, logger); err != nil {
+			return err
+		}
+
+		if err := confirmRmParentVisibility(ctx, session, target.path, cc.Status()); err != nil {
+			return err
+		}
+
+		if !cc.Flags.JSON {
+			writeRmStatus(cc, target.path, deleteMode)
+		}
+
+		outs = append(outs, rmJSONOutput{Deleted: target.path})
+	}
+
+	if cc.Flags.JSON {
+		return printSourcesJSON(cc.Output(), outs, batch, "remove")
+	}
+
+	return nil
+}
+
+// runRmBatch deletes several targets through Graph JSON batching, 20 per
+// request. Every delete is attempted; the ones that fail are reported
+// together after the rest have been confirmed.
+func runRmBatch(
+	ctx context.Context,
+	cc *CLIContext,
+	session *driveops.MountSession,
+	targets []rmTarget,
+	deleteMode rmDeleteMode,
+	batch bool,
+) error {
+	paths := make([]string, 0, len(targets))
+	itemIDs := make([]string, 0, len(targets))
+
+	for _, target := range targets {
+		paths = append(paths, target.path)
+		itemIDs = append(itemIDs, target.itemID)
+	}
+
+	errs, err := session.DeleteResolvedPathsBatch(ctx, paths, itemIDs, deleteMode == rmDeletePermanent)
 	if err != nil {
-		return err
+		return fmt.Errorf("deleting %d items: %w", len(targets), err)
 	}
 
-	if err := executeRmDelete(ctx, session, item.ID, remotePath, deleteMode, logger); err != nil {
-		return err
+	outs := make([]rmJSONOutput, 0, len(targets))
+
+	var failed []error
+
+	for i, target := range targets {
+		if errs[i] != nil {
+			failed = append(failed, fmt.Errorf("deleting %q: %w", target.path, errs[i]))
+			continue
+		}
+
+		if err := confirmRmParentVisibility(ctx, session, target.path, cc.Status()); err != nil {
+			return err
+		}
+
+		if !cc.Flags.JSON {
+			writeRmStatus(cc, target.path, deleteMode)
+		}
+
+		outs = append(outs, rmJSONOutput{Deleted: target.path})
 	}
 
-	if err := confirmRmParentVisibility(ctx, session, remotePath, cc.Status()); err != nil {
-		return err
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d deletes failed: %w", len(failed), len(targets), errors.Join(failed...))
 	}
 
 	if cc.Flags.JSON {
-		return printRmJSON(cc.Output(), rmJSONOutput{Deleted: remotePath})
+		return printSourcesJSON(cc.Output(), outs, batch, "remove")
 	}
 
-	writeRmStatus(cc, remotePath, deleteMode)
-
 	return nil
 }
 
+// resolveRmTargets resolves every source before the first delete, so a typo
+// or a folder without --recursive fails the whole command up front.
+func resolveRmTargets(
+	ctx context.Context, session *driveops.MountSession, sources []string, recursive bool,
+) ([]rmTarget, error) {
+	targets := make([]rmTarget, 0, len(sources))
+
+	for _, remotePath := range sources {
+		item, err := session.ResolveDeleteTarget(ctx, remotePath)
+		if err != nil {
+			return nil, fmt.Errorf("resolving %q: %w", remotePath, err)
+		}
+
+		// Require --recursive for folder deletion (B-156).
+		if item.IsFolder && !recursive {
+			return nil, fmt.Errorf("cannot delete folder %q without --recursive (-r) flag", remotePath)
+		}
+
+		targets = append(targets, rmTarget{path: remotePath, itemID: item.ID})
+	}
+
+	return targets, nil
+}
+
 type rmParentVisibilitySession interface {
 	WaitPathVisible(context.Context, string) (*graph.Item, error)
 }
@@ -197,15 +315,3 @@ func removableParentPath(remotePath string) string {
 
 	return parent
 }
-
-// printRmJSON writes the rm command's JSON output to w.
-func printRmJSON(w io.Writer, out rmJSONOutput) error {
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-
-	if err := enc.Encode(out); err != nil {
-		return fmt.Errorf("encode remove output: %w", err)
-	}
-
-	return nil
-}
@@ -52,7 +52,7 @@ func TestPrintRmJSON(t *testing.T) {
 	t.Parallel()
 
 	var buf bytes.Buffer
-	err := printRmJSON(&buf, rmJSONOutput{Deleted: "/docs/old-report.pdf"})
+	err := printSourcesJSON(&buf, []rmJSONOutput{{Deleted: "/docs/old-report.pdf"}}, false, "remove")
 	require.NoError(t, err)
 
 	var decoded rmJSONOutput
@@ -7,6 +7,7 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -59,6 +60,20 @@ const (
 	skipConfigValue      = "true"
 )
 
+// mutatesDriveAnnotation marks commands that change the drive. They are
+// refused before any Graph call on drives signed in with login --read-only.
+// Instead of mutatesDriveValue the annotation may name the flag that makes a
+// command change the drive, such as find's "delete".
+const (
+	mutatesDriveAnnotation = "mutatesDrive"
+	mutatesDriveValue      = "true"
+)
+
+// allDrivesAnnotation names the flag that makes a command span every
+// configured drive, such as dupes' "all-drives". With that flag set, Phase 2
+// skips single-drive resolution so no --drive is needed.
+const allDrivesAnnotation = "allDrives"
+
 const (
 	commandPerfUpdateInterval      = 30 * time.Second
 	watchCommandPerfUpdateInterval = 5 * time.Minute
@@ -141,6 +156,7 @@ type CLIContext struct {
 	statusErr                     error
 	reconcileMu                   sync.Mutex // guards reconcileNotices and selector mutation
 	reconcileNotices              map[string]struct{}
+	pinnedSession                 *driveops.MountSession // set by shell so every command it runs shares one session
 }
 
 func (cc *CLIContext) replaceCommandLogger(logger *slog.Logger, closer io.Closer) error {
@@ -234,6 +250,10 @@ func mustCLIContext(ctx context.Context) *CLIContext {
 // the resolved CLI drive at the config boundary.
 // Eliminates 7 identical boilerplate blocks across file operation commands.
 func (cc *CLIContext) Session(ctx context.Context) (*driveops.MountSession, error) {
+	if cc.pinnedSession != nil {
+		return cc.pinnedSession, nil
+	}
+
 	runtime := cc.runtime()
 	if cc.GraphBaseURL != "" {
 		runtime.GraphBaseURL = cc.GraphBaseURL
@@ -256,7 +276,19 @@ func (cc *CLIContext) Session(ctx context.Context) (*driveops.MountSession, erro
 		}
 	}
 
-	mountCfg, err := interactiveMountSessionConfigFromResolvedDrive(cc.Cfg)
+	return cc.sessionForDrive(ctx, cc.Cfg)
+}
+
+// sessionForDrive creates an interactive session for any resolved drive.
+// Commands that span several configured drives use it after Session has
+// validated the primary selection.
+func (cc *CLIContext) sessionForDrive(ctx context.Context, rd *config.ResolvedDrive) (*driveops.MountSession, error) {
+	runtime := cc.runtime()
+	if cc.GraphBaseURL != "" {
+		runtime.GraphBaseURL = cc.GraphBaseURL
+	}
+
+	mountCfg, err := interactiveMountSessionConfigFromResolvedDrive(rd)
 	if err != nil {
 		return nil, err
 	}
@@ -373,10 +405,21 @@ func initializeCLIContext(
 	}
 	if found {
 		cc.SharedTarget = sharedTarget
+
+		if commandMutatesDrive(cmd) {
+			if err := requireWritableSharedTarget(sharedTarget, commandLabel(cmd)); err != nil {
+				return err
+			}
+		}
 	}
 
 	if cc.SharedTarget == nil && cmd.Annotations[skipConfigAnnotation] != skipConfigValue {
-		if err := initializeResolvedCLIContext(cmd, cc); err != nil {
+		initialize := initializeResolvedCLIContext
+		if commandSpansAllDrives(cmd) {
+			initialize = initializeAllDrivesCLIContext
+		}
+
+		if err := initialize(cmd, cc); err != nil {
 			return err
 		}
 	}
@@ -384,6 +427,26 @@ func initializeCLIContext(
 	return nil
 }
 
+// initializeAllDrivesCLIContext prepares a command that spans every
+// configured drive. It loads the config without picking a drive, so cc.Cfg
+// stays nil; the command resolves its drives and checks each one itself.
+func initializeAllDrivesCLIContext(_ *cobra.Command, cc *CLIContext) error {
+	rawCfg, err := config.LoadOrDefault(cc.CfgPath, cc.Logger)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	cfgForLog := &config.ResolvedDrive{LoggingConfig: rawCfg.LoggingConfig}
+	dualLogger, closer := buildLoggerDualWithStatusWriter(cfgForLog, cc.Flags, cc.StatusWriter)
+	if err := cc.replaceCommandLogger(dualLogger, closer); err != nil {
+		return err
+	}
+	holder := config.NewHolder(rawCfg, cc.CfgPath)
+	cc.Runtime = driveops.NewSessionRuntime(holder, "onedrive-go/"+version, cc.Logger)
+
+	return nil
+}
+
 func initializeResolvedCLIContext(cmd *cobra.Command, cc *CLIContext) error {
 	resolved, rawCfg, err := loadAndResolve(cmd, cc.Flags, cc.Env, cc.Logger)
 	if err != nil {
@@ -392,6 +455,12 @@ func initializeResolvedCLIContext(cmd *cobra.Command, cc *CLIContext) error {
 
 	cc.Cfg = resolved
 
+	if commandMutatesDrive(cmd) {
+		if err := requireWritableDrive(resolved, commandLabel(cmd)); err != nil {
+			return err
+		}
+	}
+
 	dualLogger, closer := buildLoggerDualWithStatusWriter(resolved, cc.Flags, cc.StatusWriter)
 	if err := cc.replaceCommandLogger(dualLogger, closer); err != nil {
 		return err
@@ -402,6 +471,72 @@ func initializeResolvedCLIContext(cmd *cobra.Command, cc *CLIContext) error {
 	return nil
 }
 
+// commandMutatesDrive reports whether cmd, with the flags it was given,
+// changes the drive. A flag-gated command stays read-only under --dry-run.
+func commandMutatesDrive(cmd *cobra.Command) bool {
+	switch value := cmd.Annotations[mutatesDriveAnnotation]; value {
+	case "":
+		return false
+	case mutatesDriveValue:
+		return true
+	default:
+		if dryRun := cmd.Flags().Lookup("dry-run"); dryRun != nil && dryRun.Value.String() == "true" {
+			return false
+		}
+
+		flag := cmd.Flags().Lookup(value)
+
+		return flag != nil && flag.Value.String() != "" && flag.Value.String() != "false"
+	}
+}
+
+// commandSpansAllDrives reports whether cmd was asked, through the flag its
+// allDrivesAnnotation names, to work on every configured drive.
+func commandSpansAllDrives(cmd *cobra.Command) bool {
+	name := cmd.Annotations[allDrivesAnnotation]
+	if name == "" {
+		return false
+	}
+
+	flag := cmd.Flags().Lookup(name)
+
+	return flag != nil && flag.Value.String() == "true"
+}
+
+// commandLabel names cmd the way the user typed it, without the binary name.
+func commandLabel(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// requireWritableDrive refuses a change to a drive whose account signed in
+// with login --read-only, which Graph would reject anyway.
+func requireWritableDrive(rd *config.ResolvedDrive, command string) error {
+	if rd == nil || !rd.ReadOnly {
+		return nil
+	}
+
+	return fmt.Errorf("%s changes the drive, but %s is signed in read-only; run 'onedrive-go login' for full access",
+		command, rd.CanonicalID)
+}
+
+// requireWritableSharedTarget refuses a change through a shared target whose
+// recipient account signed in with login --read-only. Shared targets resolve
+// no configured drive, so the scope comes straight from the catalog account.
+func requireWritableSharedTarget(target *sharedTarget, command string) error {
+	catalog, err := config.LoadCatalogForDataDir(config.DefaultDataDir())
+	if err != nil {
+		return fmt.Errorf("loading catalog: %w", err)
+	}
+
+	account, found := catalog.AccountByEmail(target.Ref.AccountEmail)
+	if !found || account.LoginScope != config.LoginScopeReadOnly {
+		return nil
+	}
+
+	return fmt.Errorf("%s changes the drive, but %s is signed in read-only; run 'onedrive-go login' for full access",
+		command, account.CanonicalID)
+}
+
 func bindRootFlags(cmd *cobra.Command, bindings *rootFlagBindings) {
 	cmd.PersistentFlags().StringVar(&bindings.configPath, "config", "", "config file path")
 	cmd.PersistentFlags().StringVar(&bindings.account, "account", "", "account for auth commands (e.g., user@example.com)")
@@ -412,6 +547,10 @@ func bindRootFlags(cmd *cobra.Command, bindings *rootFlagBindings) {
 	cmd.PersistentFlags().BoolVar(&bindings.debug, "debug", false, "enable debug logging (HTTP requests, config resolution)")
 	cmd.PersistentFlags().BoolVarP(&bindings.quiet, "quiet", "q", false, "suppress informational output")
 	cmd.MarkFlagsMutuallyExclusive("verbose", "debug", "quiet")
+
+	if err := cmd.RegisterFlagCompletionFunc("drive", completeDriveSelectors); err != nil {
+		panic("BUG: registering --drive completion: " + err.Error())
+	}
 }
 
 func newRootCmdWithWriters(outputWriter, statusWriter io.Writer) *cobra.Command {
@@ -428,10 +567,19 @@ func newRootCmdWithWriters(outputWriter, statusWriter io.Writer) *cobra.Command
 		SilenceErrors: true,
 		SilenceUsage:  true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if isCompletionCommand(cmd) {
+				return nil
+			}
+
 			return initializeCLIContext(cmd, args, bindings, outputWriter, statusWriter)
 		},
 	}
 
+	// Help and cobra's completion output go to the same writers as command
+	// output, so they can be captured like any other command.
+	cmd.SetOut(outputWriter)
+	cmd.SetErr(statusWriter)
+
 	bindRootFlags(cmd, &bindings)
 
 	addRootSubcommands(cmd)
@@ -439,6 +587,23 @@ func newRootCmdWithWriters(outputWriter, statusWriter io.Writer) *cobra.Command
 	return cmd
 }
 
+// isCompletionCommand reports whether cmd is cobra's completion machinery:
+// the completion script generators and the hidden request commands shells
+// call on Tab. They need no config; completion functions initialize the
+// context of the command being completed themselves.
+func isCompletionCommand(cmd *cobra.Command) bool {
+	const completionCommandName = "completion"
+
+	switch {
+	case cmd.Name() == cobra.ShellCompRequestCmd, cmd.Name() == cobra.ShellCompNoDescRequestCmd:
+		return true
+	case cmd.Name() == completionCommandName:
+		return true
+	default:
+		return cmd.HasParent() && cmd.Parent().Name() == completionCommandName
+	}
+}
+
 func addRootSubcommands(cmd *cobra.Command) {
 	cmd.AddCommand(
 		newLoginCmd(), newLogoutCmd(), newStatusCmd(),
@@ -447,8 +612,12 @@ func addRootSubcommands(cmd *cobra.Command) {
 		newDriveCmd(), newLsCmd(), newGetCmd(), newPutCmd(),
 		newRmCmd(), newMkdirCmd(), newStatCmd(), newSyncCmd(),
 		newPauseCmd(), newResumeCmd(),
-		newMvCmd(), newCpCmd(),
+		newMvCmd(), newCpCmd(), newMirrorCmd(), newDiffCmd(), newHashCmd(), newTouchCmd(), newTransferCmd(),
+		newCheckoutCmd(), newCheckinCmd(), newDiscardCheckoutCmd(), newFieldsCmd(),
 		newRecycleBinCmd(),
+		newShortcutCmd(),
+		newSearchCmd(), newFindCmd(), newDuCmd(), newDupesCmd(), newCatCmd(),
+		newShellCmd(),
 	)
 }
 
@@ -479,7 +648,7 @@ func closeRootCommandLogger(cmd *cobra.Command) error {
 
 func mainWithWriters(args []string, outputWriter, statusWriter io.Writer) int {
 	cmd := newRootCmdWithWriters(outputWriter, statusWriter)
-	cmd.SetArgs(args)
+	cmd.SetArgs(normalizeFindStyleArgs(cmd, args))
 
 	err := cmd.Execute()
 	completeRootCommandPerf(cmd, err)
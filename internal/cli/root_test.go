@@ -478,6 +478,100 @@ func TestNewRootCmd_DriveSubcommandsSkipConfig(t *testing.T) {
 	}
 }
 
+// Validates: R-3.1.10
+func TestNewRootCmd_ReadOnlyLoginRefusesMutatingCommands(t *testing.T) {
+	setTestDriveHome(t)
+
+	cfgPath := filepath.Join(t.TempDir(), "config.toml")
+	cid := driveid.MustCanonicalID("personal:reader@example.com")
+	require.NoError(t, config.RecordLogin(
+		config.DefaultDataDir(), cid, "user-reader", "Reader", "", driveid.New(rootTestDrive123),
+		config.LoginAccess{Scope: config.LoginScopeReadOnly},
+	))
+	require.NoError(t, config.AppendDriveSection(cfgPath, cid, filepath.Join(t.TempDir(), "sync")))
+
+	for _, tc := range []struct {
+		args     []string
+		flags    map[string]string
+		readOnly bool
+	}{
+		{args: []string{"ls"}, readOnly: true},
+		{args: []string{"find"}, readOnly: true},
+		{args: []string{"find"}, flags: map[string]string{"delete": "true", "dry-run": "true"}, readOnly: true},
+		{args: []string{"dupes"}, flags: map[string]string{"keep": "oldest", "dry-run": "true"}, readOnly: true},
+		{args: []string{"rm"}},
+		{args: []string{"fields", "set"}},
+		{args: []string{"find"}, flags: map[string]string{"delete": "true"}},
+		{args: []string{"dupes"}, flags: map[string]string{"keep": "oldest"}},
+	} {
+		cmd := newRootCmd()
+		require.NoError(t, cmd.PersistentFlags().Set("config", cfgPath))
+
+		sub, _, err := cmd.Find(tc.args)
+		require.NoError(t, err)
+		sub.SetContext(t.Context())
+
+		for name, value := range tc.flags {
+			require.NoError(t, sub.Flags().Set(name, value))
+		}
+
+		err = cmd.PersistentPreRunE(sub, nil)
+		if tc.readOnly {
+			require.NoError(t, err, "%v %v should stay available on a read-only drive", tc.args, tc.flags)
+			continue
+		}
+
+		require.Error(t, err, "%v %v", tc.args, tc.flags)
+		assert.Equal(t,
+			strings.Join(tc.args, " ")+" changes the drive, but personal:reader@example.com is signed in read-only; "+
+				"run 'onedrive-go login' for full access",
+			err.Error())
+	}
+}
+
+// Validates: R-3.1.10
+func TestNewRootCmd_ReadOnlyLoginRefusesPutToSharedTarget(t *testing.T) {
+	setTestDriveHome(t)
+
+	reader := driveid.MustCanonicalID("personal:reader@example.com")
+	require.NoError(t, config.RecordLogin(
+		config.DefaultDataDir(), reader, "user-reader", "Reader", "", driveid.New(rootTestDrive123),
+		config.LoginAccess{Scope: config.LoginScopeReadOnly},
+	))
+	writer := driveid.MustCanonicalID("personal:writer@example.com")
+	require.NoError(t, config.RecordLogin(
+		config.DefaultDataDir(), writer, "user-writer", "Writer", "", driveid.New(rootTestDrive123),
+		config.LoginAccess{},
+	))
+
+	for _, tc := range []struct {
+		command  string
+		args     []string
+		readOnly bool
+	}{
+		{command: "stat", args: []string{"shared:reader@example.com:b!drive:item-1"}, readOnly: true},
+		{command: "put", args: []string{"local.txt", "shared:writer@example.com:b!drive:item-1"}, readOnly: true},
+		{command: "put", args: []string{"local.txt", "shared:reader@example.com:b!drive:item-1"}},
+	} {
+		cmd := newRootCmd()
+		sub, _, err := cmd.Find([]string{tc.command})
+		require.NoError(t, err)
+		sub.SetContext(t.Context())
+
+		err = cmd.PersistentPreRunE(sub, tc.args)
+		if tc.readOnly {
+			require.NoError(t, err, "%s %v", tc.command, tc.args)
+			continue
+		}
+
+		require.Error(t, err, "%s %v", tc.command, tc.args)
+		assert.Equal(t,
+			"put changes the drive, but personal:reader@example.com is signed in read-only; "+
+				"run 'onedrive-go login' for full access",
+			err.Error())
+	}
+}
+
 // --- annotation-based skip config (tree-walking) ---
 
 // TestAnnotationTreeWalk walks the entire command tree and verifies that every
@@ -502,6 +596,22 @@ func TestAnnotationTreeWalk(t *testing.T) {
 		"onedrive-go recycle-bin list":    true,
 		"onedrive-go recycle-bin restore": true,
 		"onedrive-go recycle-bin empty":   true,
+		"onedrive-go shortcut add":        true,
+		"onedrive-go shortcut list":       true,
+		"onedrive-go shortcut remove":     true,
+		"onedrive-go search":              true,
+		"onedrive-go find":                true,
+		"onedrive-go du":                  true,
+		"onedrive-go dupes":               true,
+		"onedrive-go cat":                 true,
+		"onedrive-go mirror":              true,
+		"onedrive-go diff":                true,
+		"onedrive-go touch":               true,
+		"onedrive-go checkout":            true,
+		"onedrive-go checkin":             true,
+		"onedrive-go discard-checkout":    true,
+		"onedrive-go fields set":          true,
+		"onedrive-go shell":               true,
 	}
 
 	cmd := newRootCmd()
@@ -971,7 +1081,7 @@ func TestNewGetCmd_Structure(t *testing.T) {
 	t.Parallel()
 
 	cmd := newGetCmd()
-	assert.Equal(t, "get <remote-path> [local-path]", cmd.Use)
+	assert.Equal(t, "get <remote-path>... [local-path]", cmd.Use)
 }
 
 // Validates: R-1.3
@@ -987,7 +1097,8 @@ func TestNewRmCmd_Structure(t *testing.T) {
 	t.Parallel()
 
 	cmd := newRmCmd()
-	assert.Equal(t, "rm <path>", cmd.Use)
+	assert.Equal(t, "rm <path>...", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("dry-run"))
 	assert.NotNil(t, cmd.Flags().Lookup("recursive"))
 	assert.NotNil(t, cmd.Flags().Lookup("permanent"))
 }
@@ -998,6 +1109,7 @@ func TestNewMkdirCmd_Structure(t *testing.T) {
 
 	cmd := newMkdirCmd()
 	assert.Equal(t, "mkdir <path>", cmd.Use)
+	assert.NotNil(t, cmd.Flags().ShorthandLookup("p"))
 }
 
 // --- multiHandler tests ---
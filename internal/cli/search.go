@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// defaultSearchLimit caps search results unless --limit overrides it. Drive
+// search is relevance-ranked, so the first hits are the useful ones.
+const defaultSearchLimit = 100
+
+func newSearchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search the drive by name, metadata, and content",
+		Long: `Run a server-side OneDrive search. Matches file and folder names, metadata,
+and — where the service supports it — file content. Results are relevance-ranked.
+
+Use --path to search only below a folder, --type to keep only files or
+folders, and --limit to cap the number of results (0 = no limit).`,
+		Args: cobra.ExactArgs(1),
+		RunE: runSearch,
+	}
+
+	cmd.Flags().String("path", "", "only search below this remote folder")
+	cmd.Flags().String("type", "", "only return items of this type (file or folder)")
+	cmd.Flags().Int("limit", defaultSearchLimit, "maximum number of results (0 = no limit)")
+
+	return cmd
+}
+
+// searchJSONItem is the JSON output schema for one search hit.
+type searchJSONItem struct {
+	Path       string `json:"path"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Size       int64  `json:"size"`
+	ModifiedAt string `json:"modified_at"`
+	ID         string `json:"id"`
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	query := strings.TrimSpace(args[0])
+	if query == "" {
+		return fmt.Errorf("search query must not be empty")
+	}
+
+	opts, err := searchOptionsFromFlags(cmd, query)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	cc.Logger.Debug("search", "query", query, "path", opts.ScopePath, "limit", opts.Limit)
+
+	hits, err := session.Search(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("searching for %q: %w", query, err)
+	}
+
+	if cc.Flags.JSON {
+		return printSearchJSON(cc.Output(), hits)
+	}
+
+	return printSearchTable(cc.Output(), hits)
+}
+
+func searchOptionsFromFlags(cmd *cobra.Command, query string) (driveops.SearchOptions, error) {
+	scope, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return driveops.SearchOptions{}, fmt.Errorf("read --path flag: %w", err)
+	}
+
+	itemKind, err := cmd.Flags().GetString("type")
+	if err != nil {
+		return driveops.SearchOptions{}, fmt.Errorf("read --type flag: %w", err)
+	}
+
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return driveops.SearchOptions{}, fmt.Errorf("read --limit flag: %w", err)
+	}
+
+	if limit < 0 {
+		return driveops.SearchOptions{}, fmt.Errorf("--limit must not be negative")
+	}
+
+	opts := driveops.SearchOptions{
+		Query:     query,
+		ScopePath: scope,
+		Limit:     limit,
+	}
+
+	switch itemKind {
+	case "":
+	case typeFile:
+		opts.Keep = func(item *graph.Item) bool { return !item.IsFolder }
+	case typeFolder:
+		opts.Keep = func(item *graph.Item) bool { return item.IsFolder }
+	default:
+		return driveops.SearchOptions{}, fmt.Errorf("invalid --type %q: must be %s or %s", itemKind, typeFile, typeFolder)
+	}
+
+	return opts, nil
+}
+
+func printSearchJSON(w io.Writer, hits []driveops.RemoteTreeEntry) error {
+	out := make([]searchJSONItem, 0, len(hits))
+	for i := range hits {
+		out = append(out, searchJSONItem{
+			Path:       hits[i].Path,
+			Name:       hits[i].Item.Name,
+			Type:       itemType(&hits[i].Item),
+			Size:       hits[i].Item.Size,
+			ModifiedAt: formatAPITime(hits[i].Item.ModifiedAt),
+			ID:         hits[i].Item.ID,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encode search output: %w", err)
+	}
+
+	return nil
+}
+
+func printSearchTable(w io.Writer, hits []driveops.RemoteTreeEntry) error {
+	if len(hits) == 0 {
+		return writeln(w, "No matches found.")
+	}
+
+	headers := []string{"PATH", "SIZE", "MODIFIED"}
+	rows := make([][]string, 0, len(hits))
+
+	for i := range hits {
+		name := "/" + hits[i].Path
+		if hits[i].Item.IsFolder {
+			name += "/"
+		}
+
+		rows = append(rows, []string{name, formatSize(hits[i].Item.Size), formatTime(hits[i].Item.ModifiedAt)})
+	}
+
+	return printTable(w, headers, rows)
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+func TestNewSearchCmd_Structure(t *testing.T) {
+	t.Parallel()
+
+	cmd := newSearchCmd()
+	assert.Equal(t, "search <query>", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("path"))
+	assert.NotNil(t, cmd.Flags().Lookup("type"))
+	assert.NotNil(t, cmd.Flags().Lookup("limit"))
+}
+
+func newSearchTestContext(t *testing.T, stdout, stderr *bytes.Buffer) *CLIContext {
+	t.Helper()
+
+	return newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch r.URL.Path {
+			case "/drives/0000000drive-123/items/root":
+				writeTestResponse(t, w, `{"id":"root-real","name":"root","root":{},"folder":{}}`)
+			case "/drives/0000000drive-123/root/search(q='budget')":
+				writeTestResponse(t, w, `{"value":[
+					{"id":"f1","name":"budget.xlsx","size":2048,"file":{},
+					 "lastModifiedDateTime":"2024-06-01T12:00:00Z",
+					 "parentReference":{"id":"fin","path":"/drive/root:/Finance"}},
+					{"id":"d1","name":"Budgets","folder":{"childCount":1},
+					 "parentReference":{"id":"root-real","path":"/drive/root:"}}
+				]}`)
+			default:
+				assert.Failf(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+		stdout,
+		stderr,
+	)
+}
+
+// Validates: R-1.11
+func TestRunSearch_PrintsResolvedPaths(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newSearchTestContext(t, &stdout, &stderr)
+
+	cmd := newSearchCmd()
+	cmd.SetArgs([]string{"budget"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, stdout.String(), "/Finance/budget.xlsx")
+	assert.Contains(t, stdout.String(), "/Budgets/")
+}
+
+// Validates: R-1.11.1
+func TestRunSearch_JSONFiltersByType(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newSearchTestContext(t, &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newSearchCmd()
+	cmd.SetArgs([]string{"budget", "--type", "file"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+
+	var out []searchJSONItem
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out, 1)
+	assert.Equal(t, "Finance/budget.xlsx", out[0].Path)
+	assert.Equal(t, typeFile, out[0].Type)
+	assert.Equal(t, int64(2048), out[0].Size)
+}
+
+func TestRunSearch_RejectsInvalidType(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newSearchTestContext(t, &stdout, &stderr)
+
+	cmd := newSearchCmd()
+	cmd.SetArgs([]string{"budget", "--type", "symlink"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --type")
+}
@@ -80,7 +80,7 @@ func sharedTargetInput(cmd *cobra.Command, args []string) (string, bool) {
 	const driveCommandName = "drive"
 
 	switch cmd.Name() {
-	case "stat":
+	case "stat", "cat":
 		if len(args) == 1 && isSharedTargetInput(args[0]) {
 			return args[0], true
 		}
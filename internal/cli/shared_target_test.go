@@ -133,6 +133,13 @@ func TestSharedTargetInput(t *testing.T) {
 			want: "shared:alice@example.com:drv:item",
 			ok:   true,
 		},
+		{
+			name: "cat selector",
+			cmd:  newCatCmd(),
+			args: []string{"shared:alice@example.com:drv:item"},
+			want: "shared:alice@example.com:drv:item",
+			ok:   true,
+		},
 		{
 			name: "get raw url",
 			cmd:  newGetCmd(),
@@ -0,0 +1,401 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/localpath"
+	"github.com/tonimelisma/onedrive-go/internal/sharedref"
+)
+
+// errShellExit ends the REPL from the exit and quit commands.
+var errShellExit = errors.New("shell exit")
+
+func newShellCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "shell",
+		Short: "Run file commands interactively in a remote working directory",
+		Long: `Start an interactive shell on one drive (chosen with --drive as usual).
+cd, pwd, ls, get, put, rm, mv and stat work as their onedrive-go commands,
+with remote paths relative to the shell's working directory. The drive is
+resolved and signed in once, and folder listings are cached for cd and Tab
+completion until a command changes the drive.
+
+Tab completes command names, remote paths and, for put, local paths. Ctrl-C
+abandons the line being typed or interrupts the running command; exit, quit
+or Ctrl-D leaves the shell. Lines read from a pipe run as a script that
+stops at the first failing command.`,
+		Args: cobra.NoArgs,
+		RunE: runShell,
+	}
+}
+
+// shellState is one shell session: the drive session every command shares,
+// the remote working directory, and completion names of the remote folders
+// listed so far, keyed by absolute path. The listings are dropped whenever
+// a command changes the drive.
+type shellState struct {
+	cc       *CLIContext
+	session  *driveops.MountSession
+	cwd      string
+	listings map[string][]string
+}
+
+func runShell(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Every command the shell runs reuses this session, and with it the
+	// authenticated Graph clients.
+	cc.pinnedSession = session
+
+	st := &shellState{cc: cc, session: session, cwd: "/", listings: map[string][]string{}}
+
+	var reader shellLineReader = newScriptLineReader(cmd.InOrStdin())
+	interactive := false
+	if f, ok := cmd.InOrStdin().(*os.File); ok && isatty.IsTerminal(f.Fd()) {
+		interactive = true
+		reader = newTerminalLineEditor(int(f.Fd()), f, cc.StatusWriter, func(args []string, word string) []string {
+			return st.complete(ctx, args, word)
+		})
+	}
+
+	return st.loop(ctx, reader, interactive)
+}
+
+// loop reads and runs lines until exit or the end of input. Interactive
+// errors are printed and the shell carries on; a script stops at the first.
+func (st *shellState) loop(ctx context.Context, reader shellLineReader, interactive bool) error {
+	for {
+		line, err := reader.readLine(st.prompt())
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = st.runLine(ctx, line)
+		switch {
+		case err == nil:
+		case errors.Is(err, errShellExit):
+			return nil
+		case interactive:
+			writeWarningf(st.cc.StatusWriter, "Error: %v\n", err)
+		default:
+			return err
+		}
+	}
+}
+
+func (st *shellState) prompt() string {
+	name := st.cc.Cfg.DisplayName
+	if name == "" {
+		name = st.cc.Cfg.CanonicalID.String()
+	}
+
+	return name + ":" + st.cwd + "> "
+}
+
+// runLine runs one shell line: a built-in, or one of the file commands
+// built fresh for the line so no flag value carries over to the next.
+func (st *shellState) runLine(ctx context.Context, line string) error {
+	words, err := splitShellLine(line)
+	if err != nil {
+		return err
+	}
+
+	if len(words) == 0 {
+		return nil
+	}
+
+	switch words[0] {
+	case "exit", "quit":
+		return errShellExit
+	case "pwd":
+		return writeln(st.cc.Output(), st.cwd)
+	case "cd":
+		return st.cd(ctx, words[1:])
+	case "help":
+		return st.help()
+	}
+
+	newCmd, ok := shellFileCommand(words[0])
+	if !ok {
+		return fmt.Errorf("unknown command %q; type help for the list", words[0])
+	}
+
+	return st.runFileCommand(ctx, newCmd(), words[1:])
+}
+
+// shellFileCommand returns the constructor of a file command the shell runs.
+func shellFileCommand(name string) (func() *cobra.Command, bool) {
+	switch name {
+	case "ls":
+		return newLsCmd, true
+	case "get":
+		return newGetCmd, true
+	case "put":
+		return newPutCmd, true
+	case "rm":
+		return newRmCmd, true
+	case "mv":
+		return newMvCmd, true
+	case "stat":
+		return newStatCmd, true
+	default:
+		return nil, false
+	}
+}
+
+func shellCommandNames() []string {
+	return []string{"cd", "exit", "get", "help", "ls", "mv", "put", "pwd", "quit", "rm", "stat"}
+}
+
+// runFileCommand parses flags and runs cmd the way cobra would, with its
+// remote paths made absolute against the working directory. A change to the
+// drive is refused on a read-only login, and drops the cached listings.
+// Ctrl-C cancels only this command.
+func (st *shellState) runFileCommand(ctx context.Context, cmd *cobra.Command, words []string) error {
+	cmd.InitDefaultHelpFlag()
+	cmd.SetOut(st.cc.Output())
+	cmd.SetErr(st.cc.StatusWriter)
+
+	if err := cmd.ParseFlags(words); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Name(), err)
+	}
+
+	if help, err := cmd.Flags().GetBool("help"); err == nil && help {
+		return writeln(st.cc.Output(), cmd.UsageString())
+	}
+
+	args := st.resolveArgs(cmd.Name(), cmd.Flags().Args())
+	if err := cmd.ValidateArgs(args); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Name(), err)
+	}
+
+	if err := cmd.ValidateRequiredFlags(); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Name(), err)
+	}
+
+	if err := cmd.ValidateFlagGroups(); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Name(), err)
+	}
+
+	if commandMutatesDrive(cmd) {
+		if err := requireWritableDrive(st.cc.Cfg, cmd.Name()); err != nil {
+			return err
+		}
+
+		defer clear(st.listings)
+	}
+
+	cmdCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	cmd.SetContext(cmdCtx)
+
+	return cmd.RunE(cmd, args)
+}
+
+// resolveArgs makes the remote path arguments of a file command absolute.
+// ls defaults to the working directory, put uploads into it, and the last
+// of several get arguments is the local destination.
+func (st *shellState) resolveArgs(name string, args []string) []string {
+	switch {
+	case name == "ls" && len(args) == 0:
+		return []string{st.cwd}
+	case name == "put" && len(args) == 1:
+		return []string{args[0], st.resolve(filepath.Base(args[0]))}
+	case name == "put" && len(args) > 1:
+		return append([]string{args[0]}, st.resolvePaths(args[1:])...)
+	case name == "get" && len(args) > 1:
+		return append(st.resolvePaths(args[:len(args)-1]), args[len(args)-1])
+	default:
+		return st.resolvePaths(args)
+	}
+}
+
+func (st *shellState) resolvePaths(paths []string) []string {
+	resolved := make([]string, len(paths))
+	for i, p := range paths {
+		resolved[i] = st.resolve(p)
+	}
+
+	return resolved
+}
+
+// resolve returns the absolute remote path of p, which is relative to the
+// working directory unless it starts with "/". shared: selectors are kept.
+func (st *shellState) resolve(p string) string {
+	if strings.HasPrefix(p, sharedref.Prefix) {
+		return p
+	}
+
+	if strings.HasPrefix(p, "/") {
+		return path.Clean(p)
+	}
+
+	return path.Join(st.cwd, p)
+}
+
+// cd changes the working directory, checking the target against the cached
+// listing of its parent and adopting the folder name's actual case.
+func (st *shellState) cd(ctx context.Context, args []string) error {
+	if len(args) > 1 {
+		return errors.New("cd: too many arguments")
+	}
+
+	target := "/"
+	if len(args) == 1 {
+		target = st.resolve(args[0])
+	}
+
+	if target == "/" {
+		st.cwd = target
+		return nil
+	}
+
+	parent, name := path.Split(target)
+	parent = path.Clean(parent)
+
+	names, err := st.children(ctx, parent)
+	if err != nil {
+		return fmt.Errorf("cd: %w", err)
+	}
+
+	for _, n := range names {
+		switch {
+		case strings.EqualFold(n, name+"/"):
+			st.cwd = path.Join(parent, strings.TrimSuffix(n, "/"))
+			return nil
+		case strings.EqualFold(n, name):
+			return fmt.Errorf("cd: %s is not a folder", target)
+		}
+	}
+
+	return fmt.Errorf("cd: %s: no such folder", target)
+}
+
+// children returns the completion names of a remote folder, listing it only
+// on the first request.
+func (st *shellState) children(ctx context.Context, dir string) ([]string, error) {
+	if names, ok := st.listings[dir]; ok {
+		return names, nil
+	}
+
+	items, err := st.session.ListChildren(ctx, driveops.CleanRemotePath(dir))
+	if err != nil {
+		return nil, fmt.Errorf("listing %s: %w", dir, err)
+	}
+
+	names := completionNames(items)
+	st.listings[dir] = names
+
+	return names, nil
+}
+
+// complete answers Tab: command names first, then local paths for put's
+// source and remote paths, only folders for cd, for everything else.
+func (st *shellState) complete(ctx context.Context, args []string, word string) []string {
+	if len(args) == 0 {
+		return matchCompletionNames("", word, shellCommandNames())
+	}
+
+	positional := 0
+	for _, a := range args[1:] {
+		if !strings.HasPrefix(a, "-") {
+			positional++
+		}
+	}
+
+	dir, prefix := splitCompletionWord(word)
+
+	if args[0] == "put" && positional == 0 {
+		return completeLocalPaths(dir, prefix)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	names, err := st.children(ctx, st.resolve(dir))
+	if err != nil {
+		return nil
+	}
+
+	if args[0] == "cd" {
+		var folders []string
+		for _, n := range names {
+			if strings.HasSuffix(n, "/") {
+				folders = append(folders, n)
+			}
+		}
+
+		names = folders
+	}
+
+	return matchCompletionNames(dir, prefix, names)
+}
+
+// completeLocalPaths completes a local path, with folders ending in "/".
+func completeLocalPaths(dir, prefix string) []string {
+	readDir := dir
+	if readDir == "" {
+		readDir = "."
+	}
+
+	entries, err := localpath.ReadDir(readDir)
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			name += "/"
+		}
+
+		names = append(names, name)
+	}
+
+	return matchCompletionNames(dir, prefix, names)
+}
+
+func (st *shellState) help() error {
+	w := st.cc.Output()
+	if err := writeln(w, "Commands (paths are relative to the working directory):"); err != nil {
+		return err
+	}
+
+	if err := writef(w, "  %-6s %s\n  %-6s %s\n", "cd", "Change the remote working directory", "pwd",
+		"Print the remote working directory"); err != nil {
+		return err
+	}
+
+	for _, name := range []string{"ls", "get", "put", "rm", "mv", "stat"} {
+		newCmd, _ := shellFileCommand(name)
+		if err := writef(w, "  %-6s %s\n", name, newCmd().Short); err != nil {
+			return err
+		}
+	}
+
+	return writef(w, "  %-6s %s\nRun <command> --help for its flags.\n", "exit", "Leave the shell")
+}
@@ -0,0 +1,341 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Control keys the shell's line editor handles itself.
+const (
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyBackspace = 8
+	keyTab       = '\t'
+	keyCtrlU     = 21
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// An escape sequence ends with a byte in this range, such as the "A" of
+// the up arrow's "ESC [ A".
+const (
+	escapeFinalFirst = 0x40
+	escapeFinalLast  = 0x7e
+)
+
+var errUnterminatedQuote = errors.New("unterminated quote")
+
+// shellWord is one word of a shell line: its text with quotes and escapes
+// removed, and the byte offset where it starts in the line.
+type shellWord struct {
+	text  string
+	start int
+}
+
+// scanShellWords splits a line into words the way a POSIX shell does for
+// plain words: whitespace separates, single quotes keep everything literal,
+// double quotes keep whitespace, and a backslash escapes the next character
+// outside single quotes. open reports a quote still open at the end.
+func scanShellWords(line string) (words []shellWord, open bool) {
+	var (
+		cur     strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+		start   int
+	)
+
+	for i, r := range line {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else if r == '\\' && quote == '"' {
+				escaped = true
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, shellWord{text: cur.String(), start: start})
+				cur.Reset()
+				inWord = false
+			}
+
+			continue
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '\\':
+			escaped = true
+		default:
+			cur.WriteRune(r)
+		}
+
+		if !inWord {
+			inWord = true
+			start = i
+		}
+	}
+
+	if inWord {
+		words = append(words, shellWord{text: cur.String(), start: start})
+	}
+
+	return words, quote != 0 || escaped
+}
+
+// splitShellLine returns the words of a complete shell line.
+func splitShellLine(line string) ([]string, error) {
+	words, open := scanShellWords(line)
+	if open {
+		return nil, errUnterminatedQuote
+	}
+
+	texts := make([]string, len(words))
+	for i := range words {
+		texts[i] = words[i].text
+	}
+
+	return texts, nil
+}
+
+// escapeShellWord quotes the characters scanShellWords treats specially, so
+// a completed name is read back as the same word.
+func escapeShellWord(word string) string {
+	var b strings.Builder
+	for _, r := range word {
+		switch r {
+		case ' ', '\t', '\'', '"', '\\':
+			b.WriteRune('\\')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// shellCompleter returns the completions of word, the word under the cursor,
+// given the complete words before it on the line.
+type shellCompleter func(args []string, word string) []string
+
+// shellLineReader reads the shell's input one line at a time. It returns
+// io.EOF when the input ends.
+type shellLineReader interface {
+	readLine(prompt string) (string, error)
+}
+
+// scriptLineReader reads lines from non-terminal input, such as a script
+// piped to the shell. It prints no prompt.
+type scriptLineReader struct {
+	scanner *bufio.Scanner
+}
+
+func newScriptLineReader(in io.Reader) *scriptLineReader {
+	return &scriptLineReader{scanner: bufio.NewScanner(in)}
+}
+
+func (r *scriptLineReader) readLine(string) (string, error) {
+	if r.scanner.Scan() {
+		return r.scanner.Text(), nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return "", fmt.Errorf("reading shell input: %w", err)
+	}
+
+	return "", io.EOF
+}
+
+// terminalLineEditor is a minimal line editor for an interactive terminal:
+// typing and Backspace at the end of the line, Ctrl-U to clear it, Ctrl-C to
+// abandon it, Ctrl-D on an empty line to quit, and Tab to complete. Escape
+// sequences such as arrow keys are ignored. The terminal is only raw while a
+// line is read, so commands run with normal signal handling.
+type terminalLineEditor struct {
+	fd       int
+	in       *bufio.Reader
+	out      io.Writer
+	complete shellCompleter
+	makeRaw  func(fd int) (func() error, error)
+}
+
+func newTerminalLineEditor(fd int, in io.Reader, out io.Writer, complete shellCompleter) *terminalLineEditor {
+	return &terminalLineEditor{
+		fd:       fd,
+		in:       bufio.NewReader(in),
+		out:      out,
+		complete: complete,
+		makeRaw:  makeTerminalRaw,
+	}
+}
+
+func (e *terminalLineEditor) readLine(prompt string) (line string, err error) {
+	restore, err := e.makeRaw(e.fd)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if restoreErr := restore(); restoreErr != nil && err == nil {
+			err = restoreErr
+		}
+	}()
+
+	return e.edit(prompt)
+}
+
+func (e *terminalLineEditor) edit(prompt string) (string, error) {
+	var buf []rune
+
+	if err := e.write(prompt); err != nil {
+		return "", err
+	}
+
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err //nolint:wrapcheck // io.EOF must reach the REPL unwrapped.
+		}
+
+		switch {
+		case r == '\r' || r == '\n':
+			return string(buf), e.write("\r\n")
+		case r == keyCtrlD && len(buf) == 0:
+			if err := e.write("\r\n"); err != nil {
+				return "", err
+			}
+
+			return "", io.EOF
+		case r == keyEscape:
+			if err := e.skipEscapeSequence(); err != nil {
+				return "", err
+			}
+
+			continue
+		}
+
+		var out string
+		buf, out = e.applyKey(prompt, buf, r)
+
+		if err := e.write(out); err != nil {
+			return "", err
+		}
+	}
+}
+
+// applyKey applies one key to the line and returns the new line and what
+// to write to the terminal for it.
+func (e *terminalLineEditor) applyKey(prompt string, buf []rune, r rune) ([]rune, string) {
+	switch r {
+	case keyCtrlC:
+		return nil, "^C\r\n" + prompt
+	case keyCtrlU:
+		return nil, "\r\x1b[K" + prompt
+	case keyBackspace, keyDelete:
+		if len(buf) == 0 {
+			return buf, ""
+		}
+
+		return buf[:len(buf)-1], "\b \b"
+	case keyTab:
+		return e.completeLine(prompt, buf)
+	default:
+		if r < ' ' {
+			return buf, ""
+		}
+
+		return append(buf, r), string(r)
+	}
+}
+
+// completeLine completes the word under the cursor. One completion replaces
+// the word, followed by a space unless it is a folder; several extend it to
+// their common prefix, or are listed when there is nothing to extend.
+func (e *terminalLineEditor) completeLine(prompt string, buf []rune) ([]rune, string) {
+	line := string(buf)
+
+	// A NUL marker finds the word under the cursor: it joins the last word,
+	// or starts a new empty one after a separator.
+	words, _ := scanShellWords(line + "\x00")
+	last := words[len(words)-1]
+	word, start := strings.TrimSuffix(last.text, "\x00"), last.start
+
+	args := make([]string, 0, len(words)-1)
+	for i := range words[:len(words)-1] {
+		args = append(args, words[i].text)
+	}
+
+	comps := e.complete(args, word)
+
+	var insert string
+	switch {
+	case len(comps) == 0:
+		return buf, "\a"
+	case len(comps) == 1:
+		insert = escapeShellWord(comps[0])
+		if !strings.HasSuffix(comps[0], "/") {
+			insert += " "
+		}
+	default:
+		common := commonPrefix(comps)
+		if len(common) <= len(word) {
+			return buf, "\r\n" + strings.Join(comps, "  ") + "\r\n" + prompt + line
+		}
+
+		insert = escapeShellWord(common)
+	}
+
+	completed := line[:start] + insert
+
+	return []rune(completed), "\r\x1b[K" + prompt + completed
+}
+
+// skipEscapeSequence consumes the rest of a CSI or SS3 sequence, such as
+// the one an arrow key sends.
+func (e *terminalLineEditor) skipEscapeSequence() error {
+	r, _, err := e.in.ReadRune()
+	if err != nil || (r != '[' && r != 'O') {
+		return err //nolint:wrapcheck // io.EOF must reach the REPL unwrapped.
+	}
+
+	for {
+		r, _, err = e.in.ReadRune()
+		if err != nil || (r >= escapeFinalFirst && r <= escapeFinalLast) {
+			return err //nolint:wrapcheck // io.EOF must reach the REPL unwrapped.
+		}
+	}
+}
+
+func (e *terminalLineEditor) write(s string) error {
+	if s == "" {
+		return nil
+	}
+
+	if _, err := io.WriteString(e.out, s); err != nil {
+		return fmt.Errorf("writing to terminal: %w", err)
+	}
+
+	return nil
+}
+
+// commonPrefix returns the longest prefix, in whole runes, that every value
+// starts with.
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+
+	return prefix
+}
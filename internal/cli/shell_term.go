@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// makeTerminalRaw switches the terminal on fd to byte-at-a-time input
+// without echo or signal keys, so the shell's line editor sees Tab, Ctrl-C
+// and Ctrl-D itself. Output processing stays on, so "\n" still starts a new
+// line. The returned function restores the previous mode.
+func makeTerminalRaw(fd int) (func() error, error) {
+	var saved syscall.Termios
+	if err := termiosIoctl(fd, ioctlGetTermios, &saved); err != nil {
+		return nil, fmt.Errorf("reading terminal mode: %w", err)
+	}
+
+	raw := saved
+	raw.Iflag &^= syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+
+	if err := termiosIoctl(fd, ioctlSetTermios, &raw); err != nil {
+		return nil, fmt.Errorf("setting terminal mode: %w", err)
+	}
+
+	return func() error {
+		if err := termiosIoctl(fd, ioctlSetTermios, &saved); err != nil {
+			return fmt.Errorf("restoring terminal mode: %w", err)
+		}
+
+		return nil
+	}, nil
+}
+
+func termiosIoctl(fd int, request uintptr, t *syscall.Termios) error {
+	//nolint:gosec // The ioctl reads or writes exactly one Termios owned by the caller.
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), request, uintptr(unsafe.Pointer(t))); errno != 0 {
+		return errno
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build linux
+
+package cli
+
+import "syscall"
+
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
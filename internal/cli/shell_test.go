@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// newShellTestContext serves a root holding the Docs folder, which holds
+// notes.txt.
+func newShellTestContext(t *testing.T, stdout, stderr *bytes.Buffer) *CLIContext {
+	t.Helper()
+
+	return newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch r.URL.Path {
+			case "/drives/0000000drive-123/items/root/children":
+				writeTestResponse(t, w, `{"value":[{"id":"docs","name":"Docs","folder":{"childCount":1}},
+					{"id":"top","name":"top.txt","size":1,"file":{}}]}`)
+			case "/drives/0000000drive-123/root:/Docs:/children":
+				writeTestResponse(t, w, `{"value":[{"id":"notes","name":"notes.txt","size":5,"file":{}}]}`)
+			case "/drives/0000000drive-123/root:/Docs/notes.txt:":
+				writeTestResponse(t, w, `{"id":"notes","name":"notes.txt","size":5,"file":{},
+					"parentReference":{"id":"docs"}}`)
+			default:
+				assert.Fail(t, "unexpected request", r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}), stdout, stderr)
+}
+
+func runTestShell(t *testing.T, cc *CLIContext, script string) error {
+	t.Helper()
+
+	cmd := newShellCmd()
+	cmd.SetIn(strings.NewReader(script))
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	return cmd.RunE(cmd, nil)
+}
+
+// Validates: R-1.26.1, R-1.26.2
+func TestRunShell_ScriptResolvesPathsAgainstWorkingDirectory(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newShellTestContext(t, &stdout, &stderr)
+
+	require.NoError(t, runTestShell(t, cc, "cd docs\npwd\nls\nstat notes.txt\ncd ..\npwd\n"))
+
+	out := stdout.String()
+	assert.Contains(t, out, "/Docs\n", "cd adopts the folder name's actual case")
+	assert.Contains(t, out, "notes.txt")
+	assert.True(t, strings.HasSuffix(out, "/\n"), "cd .. returns to the root")
+
+	session, err := cc.Session(t.Context())
+	require.NoError(t, err)
+	assert.Same(t, cc.pinnedSession, session, "commands share the shell's session")
+}
+
+// Validates: R-1.26.1
+func TestRunShell_ScriptStopsAtFirstError(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newShellTestContext(t, &stdout, &stderr)
+
+	err := runTestShell(t, cc, "cd top.txt\npwd\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "/top.txt is not a folder")
+	assert.Empty(t, stdout.String())
+
+	require.ErrorContains(t, runTestShell(t, cc, "frobnicate\n"), `unknown command "frobnicate"`)
+	require.NoError(t, runTestShell(t, cc, "exit\nfrobnicate\n"), "nothing runs after exit")
+}
+
+// Validates: R-1.26.1
+func TestRunShell_ReadOnlyLoginRefusesMutatingCommands(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newShellTestContext(t, &stdout, &stderr)
+	cc.Cfg.ReadOnly = true
+
+	err := runTestShell(t, cc, "rm Docs/notes.txt\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read-only")
+}
+
+// Validates: R-1.26.2
+func TestShellState_ResolveArgs(t *testing.T) {
+	st := &shellState{cwd: "/Docs"}
+
+	assert.Equal(t, []string{"/Docs"}, st.resolveArgs("ls", nil))
+	assert.Equal(t, []string{"/Docs/a.txt", "/b.txt"}, st.resolveArgs("rm", []string{"a.txt", "../b.txt"}))
+	assert.Equal(t, []string{"local/a.txt", "/Docs/a.txt"}, st.resolveArgs("put", []string{"local/a.txt"}))
+	assert.Equal(t, []string{"a.txt", "/Other/a.txt"}, st.resolveArgs("put", []string{"a.txt", "/Other/a.txt"}))
+	assert.Equal(t, []string{"/Docs/a.txt", "out"}, st.resolveArgs("get", []string{"a.txt", "out"}))
+	assert.Equal(t, []string{"shared:user@example.com:b!x:y"}, st.resolveArgs("stat", []string{"shared:user@example.com:b!x:y"}))
+}
+
+// Validates: R-1.26.3
+func TestShellState_CompleteUsesCachedListing(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newShellTestContext(t, &stdout, &stderr)
+	session, err := cc.Session(t.Context())
+	require.NoError(t, err)
+
+	st := &shellState{cc: cc, session: session, cwd: "/", listings: map[string][]string{}}
+
+	assert.Equal(t, []string{"put", "pwd"}, st.complete(t.Context(), nil, "p"))
+	assert.Equal(t, []string{"Docs/"}, st.complete(t.Context(), []string{"cd"}, ""), "cd offers folders only")
+	assert.Equal(t, []string{"Docs/", "top.txt"}, st.complete(t.Context(), []string{"stat"}, ""))
+	assert.Equal(t, []string{"Docs/notes.txt"}, st.complete(t.Context(), []string{"rm"}, "Docs/n"))
+	assert.Len(t, st.listings, 2)
+}
+
+// Validates: R-1.26.3
+func TestSplitShellLine(t *testing.T) {
+	words, err := splitShellLine(`put "my file.txt" Docs/it\'s\ here  'a "b"'`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"put", "my file.txt", "Docs/it's here", `a "b"`}, words)
+
+	_, err = splitShellLine(`cd "Docs`)
+	require.ErrorIs(t, err, errUnterminatedQuote)
+
+	name := `it's a "tab"	\ name`
+	words, err = splitShellLine("cd " + escapeShellWord(name))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cd", name}, words)
+}
+
+// Validates: R-1.26.3
+func TestTerminalLineEditor_EditsAndCompletes(t *testing.T) {
+	var out bytes.Buffer
+	var rawCalls, restores int
+
+	editor := newTerminalLineEditor(0, strings.NewReader("cd Do\tRe\t\x1b[Ax\x7f\rls\x03pwd\r\x04"), &out,
+		func(args []string, word string) []string {
+			switch {
+			case len(args) == 1 && word == "Do":
+				return []string{"Docs/"}
+			case len(args) == 1 && word == "Docs/Re":
+				return []string{"Docs/Reports/", "Docs/Resumes/"}
+			default:
+				return nil
+			}
+		})
+	editor.makeRaw = func(int) (func() error, error) {
+		rawCalls++
+		return func() error { restores++; return nil }, nil
+	}
+
+	line, err := editor.readLine("> ")
+	require.NoError(t, err)
+	assert.Equal(t, "cd Docs/Re", line, "ambiguous completions are listed, arrows ignored, Backspace erases")
+	assert.Contains(t, out.String(), "Docs/Reports/  Docs/Resumes/")
+
+	line, err = editor.readLine("> ")
+	require.NoError(t, err)
+	assert.Equal(t, "pwd", line, "Ctrl-C abandons the line")
+
+	_, err = editor.readLine("> ")
+	require.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 3, rawCalls)
+	assert.Equal(t, 3, restores)
+}
+
+// Validates: R-1.26.1
+func TestRunShell_HelpListsCommands(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newShellTestContext(t, &stdout, &stderr)
+
+	require.NoError(t, runTestShell(t, cc, "help\n"))
+	for _, name := range shellCommandNames() {
+		if name == "quit" || name == "help" {
+			continue
+		}
+
+		assert.Contains(t, stdout.String(), "  "+name+" ")
+	}
+
+	require.NoError(t, runTestShell(t, cc, ""), "end of input is a clean exit")
+}
@@ -0,0 +1,326 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+	"github.com/tonimelisma/onedrive-go/internal/sharedref"
+)
+
+func newShortcutCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shortcut",
+		Short: "Manage shortcuts to shared folders in your drive",
+		Long: `Add, list, and remove OneDrive shortcuts ("Add to My files") that link
+folders shared with you into your own drive. Sync picks up new shortcuts as
+child mounts automatically.`,
+	}
+
+	cmd.AddCommand(
+		newShortcutAddCmd(),
+		newShortcutListCmd(),
+		newShortcutRemoveCmd(),
+	)
+
+	return cmd
+}
+
+func newShortcutAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <shared-target> [remote-parent]",
+		Short: "Add a shortcut to a shared folder",
+		Long: `Add a shortcut to a shared folder under remote-parent (default: drive root).
+<shared-target> is a raw OneDrive share URL or a shared:<recipientEmail>:<remoteDriveID>:<remoteItemID>
+selector as printed by 'onedrive-go shared'.`,
+		Args:        cobra.RangeArgs(1, 2),
+		Annotations: map[string]string{mutatesDriveAnnotation: mutatesDriveValue},
+		RunE:        runShortcutAdd,
+	}
+}
+
+func newShortcutListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List shortcuts to shared folders in your drive",
+		Args:  cobra.NoArgs,
+		RunE:  runShortcutList,
+	}
+}
+
+func newShortcutRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:         "remove <path>",
+		Short:       "Remove a shortcut (the shared folder itself is not touched)",
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{mutatesDriveAnnotation: mutatesDriveValue},
+		RunE:        runShortcutRemove,
+	}
+}
+
+// shortcutJSONItem is the JSON schema for one shortcut.
+type shortcutJSONItem struct {
+	Path          string `json:"path"`
+	ID            string `json:"id"`
+	RemoteDriveID string `json:"remote_drive_id"`
+	RemoteItemID  string `json:"remote_item_id"`
+	ModifiedAt    string `json:"modified_at,omitempty"`
+}
+
+// shortcutRemoveJSONOutput is the JSON output schema for shortcut remove.
+type shortcutRemoveJSONOutput struct {
+	Removed string `json:"removed"`
+	ID      string `json:"id"`
+}
+
+// shortcutTarget is the resolved owner-side identity of a shared folder.
+type shortcutTarget struct {
+	RemoteDriveID string
+	RemoteItemID  string
+	Name          string
+}
+
+func runShortcutAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	session, err := shortcutSession(ctx, cc)
+	if err != nil {
+		return err
+	}
+
+	parentPath := ""
+	if len(args) == 2 {
+		parentPath = driveops.CleanRemotePath(args[1])
+	}
+
+	cc.Logger.Debug("shortcut add", "target", args[0], "parent", parentPath)
+
+	target, err := resolveShortcutTarget(ctx, session, args[0])
+	if err != nil {
+		return err
+	}
+
+	parent, err := session.ResolveItem(ctx, parentPath)
+	if err != nil {
+		return fmt.Errorf("resolving %q: %w", "/"+parentPath, err)
+	}
+
+	if !parent.IsFolder {
+		return fmt.Errorf("%q is not a folder", "/"+parentPath)
+	}
+
+	item, err := session.CreateShortcut(
+		ctx, parent.ID, target.Name, driveid.New(target.RemoteDriveID), target.RemoteItemID,
+	)
+	if err != nil {
+		return fmt.Errorf("adding shortcut to %q: %w", target.Name, err)
+	}
+
+	shortcutPath := joinRemotePath(parentPath, item.Name)
+
+	if cc.Flags.JSON {
+		return printShortcutJSON(cc.Output(), shortcutJSONItem{
+			Path:          shortcutPath,
+			ID:            item.ID,
+			RemoteDriveID: target.RemoteDriveID,
+			RemoteItemID:  target.RemoteItemID,
+		})
+	}
+
+	cc.Statusf("Added shortcut /%s\n", shortcutPath)
+
+	return nil
+}
+
+// resolveShortcutTarget turns a shared selector or share URL into the
+// owner-side folder identity, using the drive's own account so the shortcut
+// lands in the recipient's drive.
+func resolveShortcutTarget(ctx context.Context, session *driveops.MountSession, input string) (*shortcutTarget, error) {
+	var item *graph.Item
+
+	switch {
+	case strings.HasPrefix(input, sharedref.Prefix):
+		ref, err := sharedref.Parse(input)
+		if err != nil {
+			return nil, fmt.Errorf("parse shared target selector: %w", err)
+		}
+
+		if account := session.AccountEmail(); account != "" && !strings.EqualFold(account, ref.AccountEmail) {
+			return nil, fmt.Errorf("shared target belongs to %s, but the selected drive belongs to %s", ref.AccountEmail, account)
+		}
+
+		item, err = session.Meta.GetItem(ctx, driveid.New(ref.RemoteDriveID), ref.RemoteItemID)
+		if err != nil {
+			return nil, fmt.Errorf("loading shared item: %w", err)
+		}
+
+		if item.RemoteDriveID == "" {
+			item.RemoteDriveID = ref.RemoteDriveID
+		}
+		if item.RemoteItemID == "" {
+			item.RemoteItemID = ref.RemoteItemID
+		}
+	case isSharedTargetInput(input):
+		var err error
+
+		item, err = session.Meta.ResolveShareURL(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("resolving share URL: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("%q is not a share URL or shared: selector — run 'onedrive-go shared' to list targets", input)
+	}
+
+	if !item.IsFolder && !item.RemoteIsFolder {
+		return nil, fmt.Errorf("%q is a file — shortcuts can only point at shared folders", item.Name)
+	}
+
+	return &shortcutTarget{
+		RemoteDriveID: item.RemoteDriveID,
+		RemoteItemID:  item.RemoteItemID,
+		Name:          item.Name,
+	}, nil
+}
+
+func runShortcutList(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	session, err := shortcutSession(ctx, cc)
+	if err != nil {
+		return err
+	}
+
+	cc.Logger.Debug("shortcut list")
+
+	shortcuts, err := session.ListShortcuts(ctx)
+	if err != nil {
+		return fmt.Errorf("listing shortcuts: %w", err)
+	}
+
+	items := make([]shortcutJSONItem, 0, len(shortcuts))
+	for i := range shortcuts {
+		items = append(items, shortcutJSONItem{
+			Path:          shortcuts[i].Path,
+			ID:            shortcuts[i].Item.ID,
+			RemoteDriveID: shortcuts[i].Item.RemoteDriveID,
+			RemoteItemID:  shortcuts[i].Item.RemoteItemID,
+			ModifiedAt:    formatAPITime(shortcuts[i].Item.ModifiedAt),
+		})
+	}
+
+	if cc.Flags.JSON {
+		return printShortcutListJSON(cc.Output(), items)
+	}
+
+	return printShortcutListText(cc.Output(), items)
+}
+
+func runShortcutRemove(cmd *cobra.Command, args []string) error {
+	remotePath := driveops.CleanRemotePath(args[0])
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	session, err := shortcutSession(ctx, cc)
+	if err != nil {
+		return err
+	}
+
+	cc.Logger.Debug("shortcut remove", "path", remotePath)
+
+	item, err := session.ResolveDeleteTarget(ctx, remotePath)
+	if err != nil {
+		return fmt.Errorf("resolving %q: %w", remotePath, err)
+	}
+
+	// Refuse anything but a shortcut placeholder so a typo can never recycle
+	// real content through this command.
+	if !driveops.IsShortcut(item) {
+		return fmt.Errorf("%q is not a shortcut to a shared folder", remotePath)
+	}
+
+	if err := session.DeleteResolvedPath(ctx, remotePath, item.ID); err != nil {
+		return fmt.Errorf("removing shortcut %q: %w", remotePath, err)
+	}
+
+	if cc.Flags.JSON {
+		return printShortcutRemoveJSON(cc.Output(), shortcutRemoveJSONOutput{Removed: remotePath, ID: item.ID})
+	}
+
+	cc.Statusf("Removed shortcut /%s\n", remotePath)
+
+	return nil
+}
+
+// shortcutSession opens the drive session for shortcut commands. Shortcuts
+// live in an account's own drive, so shared-folder drives are rejected.
+func shortcutSession(ctx context.Context, cc *CLIContext) (*driveops.MountSession, error) {
+	if cc.Cfg != nil && cc.Cfg.CanonicalID.IsShared() {
+		return nil, fmt.Errorf("drive %s is a shared folder — shortcuts can only be managed in your own drive", cc.Cfg.CanonicalID)
+	}
+
+	return cc.Session(ctx)
+}
+
+// --- formatting ---
+
+func printShortcutListText(w io.Writer, items []shortcutJSONItem) error {
+	if len(items) == 0 {
+		return writeln(w, "No shortcuts found.")
+	}
+
+	headers := []string{"PATH", "MODIFIED", "REMOTE DRIVE", "REMOTE ITEM"}
+	rows := make([][]string, 0, len(items))
+
+	for i := range items {
+		rows = append(rows, []string{
+			"/" + items[i].Path,
+			items[i].ModifiedAt,
+			items[i].RemoteDriveID,
+			items[i].RemoteItemID,
+		})
+	}
+
+	return printTable(w, headers, rows)
+}
+
+func printShortcutJSON(w io.Writer, item shortcutJSONItem) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(item); err != nil {
+		return fmt.Errorf("encode shortcut output: %w", err)
+	}
+
+	return nil
+}
+
+func printShortcutListJSON(w io.Writer, items []shortcutJSONItem) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(items); err != nil {
+		return fmt.Errorf("encode shortcut list output: %w", err)
+	}
+
+	return nil
+}
+
+func printShortcutRemoveJSON(w io.Writer, out shortcutRemoveJSONOutput) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encode shortcut remove output: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+const (
+	testShortcutOwnerDrive = "abcdef0123456789"
+	testShortcutSelector   = "shared:user@example.com:" + testShortcutOwnerDrive + ":owner-folder"
+)
+
+func TestNewShortcutCmd_Structure(t *testing.T) {
+	t.Parallel()
+
+	cmd := newShortcutCmd()
+	assert.Equal(t, "shortcut", cmd.Use)
+
+	subs := make([]string, 0, len(cmd.Commands()))
+	for _, sub := range cmd.Commands() {
+		subs = append(subs, sub.Name())
+	}
+
+	assert.ElementsMatch(t, []string{"add", "list", "remove"}, subs)
+}
+
+func executeShortcutCmd(t *testing.T, cc *CLIContext, args ...string) error {
+	t.Helper()
+
+	cmd := newShortcutCmd()
+	cmd.SetArgs(args)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	return cmd.Execute()
+}
+
+// Validates: R-1.10.1
+func TestRunShortcutAdd_SelectorCreatesRemoteItemChild(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var created map[string]any
+
+	cc := newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/drives/"+testShortcutOwnerDrive+"/items/owner-folder":
+				writeTestResponse(t, w, `{"id":"owner-folder","name":"Team Docs","folder":{"childCount":2},
+					"parentReference":{"driveId":"`+testShortcutOwnerDrive+`"}}`)
+			case r.Method == http.MethodGet && r.URL.Path == "/drives/0000000drive-123/items/root":
+				writeTestResponse(t, w, `{"id":"root","name":"root","root":{},"folder":{"childCount":0}}`)
+			case r.Method == http.MethodPost && r.URL.Path == "/drives/0000000drive-123/items/root/children":
+				body, err := io.ReadAll(r.Body)
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.NoError(t, json.Unmarshal(body, &created))
+				w.WriteHeader(http.StatusCreated)
+				writeTestResponse(t, w, `{"id":"shortcut-id","name":"Team Docs",
+					"remoteItem":{"id":"owner-folder","parentReference":{"driveId":"`+testShortcutOwnerDrive+`"},"folder":{}}}`)
+			default:
+				assert.Failf(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+		&stdout,
+		&stderr,
+	)
+	cc.Flags.JSON = true
+
+	require.NoError(t, executeShortcutCmd(t, cc, "add", testShortcutSelector))
+
+	require.NotNil(t, created)
+	assert.Equal(t, "Team Docs", created["name"])
+	remoteItem, ok := created["remoteItem"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "owner-folder", remoteItem["id"])
+
+	var out shortcutJSONItem
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, "Team Docs", out.Path)
+	assert.Equal(t, "shortcut-id", out.ID)
+	assert.Equal(t, "owner-folder", out.RemoteItemID)
+}
+
+// Validates: R-1.10.1
+func TestRunShortcutAdd_RejectsSharedFile(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	cc := newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			assert.Equal(t, http.MethodGet, r.Method)
+			writeTestResponse(t, w, `{"id":"owner-folder","name":"report.docx","file":{}}`)
+		}),
+		&stdout,
+		&stderr,
+	)
+
+	err := executeShortcutCmd(t, cc, "add", testShortcutSelector)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only point at shared folders")
+}
+
+// Validates: R-1.10.1
+func TestRunShortcutAdd_RejectsOtherAccountSelector(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	cc := newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Failf(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+		}),
+		&stdout,
+		&stderr,
+	)
+
+	err := executeShortcutCmd(t, cc, "add", "shared:other@example.com:"+testShortcutOwnerDrive+":owner-folder")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "belongs to other@example.com")
+}
+
+// Validates: R-1.10.2
+func TestRunShortcutList_PrintsShortcutPaths(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	cc := newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/drives/0000000drive-123/root/delta", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			writeTestResponse(t, w, `{"value":[
+				{"id":"root","name":"root","root":{},"folder":{}},
+				{"id":"docs","name":"Docs","parentReference":{"id":"root"},"folder":{}},
+				{"id":"sc","name":"Team","parentReference":{"id":"docs"},
+				 "remoteItem":{"id":"owner-folder","parentReference":{"driveId":"`+testShortcutOwnerDrive+`"},"folder":{}}},
+				{"id":"f","name":"a.txt","parentReference":{"id":"docs"},"file":{}}
+			],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`)
+		}),
+		&stdout,
+		&stderr,
+	)
+
+	require.NoError(t, executeShortcutCmd(t, cc, "list"))
+	assert.Contains(t, stdout.String(), "/Docs/Team")
+	assert.Contains(t, stdout.String(), "owner-folder")
+	assert.NotContains(t, stdout.String(), "a.txt")
+}
+
+// Validates: R-1.10.3
+func TestRunShortcutRemove_RefusesOrdinaryItems(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	cc := newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method, "remove must not delete a non-shortcut")
+			w.Header().Set("Content-Type", "application/json")
+			writeTestResponse(t, w, `{"id":"docs","name":"Docs","folder":{"childCount":1},
+				"parentReference":{"id":"root","path":"/drive/root:"}}`)
+		}),
+		&stdout,
+		&stderr,
+	)
+
+	err := executeShortcutCmd(t, cc, "remove", "Docs")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a shortcut")
+}
+
+// Validates: R-1.10.3
+func TestRunShortcutRemove_DeletesShortcutPlaceholder(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var deleted bool
+
+	cc := newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			if r.Method == http.MethodDelete {
+				assert.Equal(t, "/drives/0000000drive-123/items/sc", r.URL.Path)
+				deleted = true
+				w.WriteHeader(http.StatusNoContent)
+
+				return
+			}
+
+			writeTestResponse(t, w, `{"id":"sc","name":"Team",
+				"parentReference":{"id":"root","path":"/drive/root:"},
+				"remoteItem":{"id":"owner-folder","parentReference":{"driveId":"`+testShortcutOwnerDrive+`"},"folder":{}}}`)
+		}),
+		&stdout,
+		&stderr,
+	)
+
+	require.NoError(t, executeShortcutCmd(t, cc, "remove", "/Team"))
+	assert.True(t, deleted)
+	assert.Contains(t, stderr.String(), "Removed shortcut /Team")
+}
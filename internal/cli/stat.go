@@ -1,30 +1,42 @@
 package cli
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"io"
 
 	"github.com/spf13/cobra"
 
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
 	"github.com/tonimelisma/onedrive-go/internal/graph"
 )
 
 func newStatCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "stat <path>",
-		Short: "Display file or folder metadata",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runStat,
+	cmd := &cobra.Command{
+		Use:               "stat <path>",
+		Short:             "Display file or folder metadata",
+		Args:              cobra.ExactArgs(1),
+		RunE:              runStat,
+		ValidArgsFunction: completeRemotePaths(remoteCompletion{maxArgs: 1}),
 	}
+
+	cmd.Flags().Bool("fields", false, "also show SharePoint document library column values")
+
+	return cmd
 }
 
 func runStat(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 	cc := mustCLIContext(ctx)
 
+	withFields, err := cmd.Flags().GetBool("fields")
+	if err != nil {
+		return fmt.Errorf("read --fields flag: %w", err)
+	}
+
 	if cc.SharedTarget != nil {
-		item, _, err := cc.resolveSharedItem(ctx)
+		item, clients, err := cc.resolveSharedItem(ctx)
 		if err != nil {
 			return err
 		}
@@ -36,6 +48,14 @@ func runStat(cmd *cobra.Command, args []string) error {
 			RemoteItemID:   cc.SharedTarget.Ref.RemoteItemID,
 		}
 
+		if withFields {
+			opts.Fields, err = clients.Meta.GetListItemFields(ctx,
+				driveid.New(cc.SharedTarget.Ref.RemoteDriveID), cc.SharedTarget.Ref.RemoteItemID)
+			if err != nil {
+				return fmt.Errorf("reading list fields: %w", err)
+			}
+		}
+
 		if cc.Flags.JSON {
 			return printStatJSONWithOptions(cc.Output(), item, opts)
 		}
@@ -57,27 +77,38 @@ func runStat(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("resolving %q: %w", remotePath, err)
 	}
 
+	var opts statPrintOptions
+	if withFields {
+		opts.Fields, err = session.ListItemFields(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+	}
+
 	if cc.Flags.JSON {
-		return printStatJSONWithOptions(cc.Output(), item, statPrintOptions{})
+		return printStatJSONWithOptions(cc.Output(), item, opts)
 	}
 
-	return printStatTextWithOptions(cc.Output(), item, statPrintOptions{})
+	return printStatTextWithOptions(cc.Output(), item, opts)
 }
 
 // statJSONOutput is the JSON output schema for the stat command.
 type statJSONOutput struct {
-	ID             string `json:"id"`
-	Name           string `json:"name"`
-	Size           int64  `json:"size"`
-	IsFolder       bool   `json:"is_folder"`
-	ModifiedAt     string `json:"modified_at"`
-	CreatedAt      string `json:"created_at"`
-	MimeType       string `json:"mime_type,omitempty"`
-	ETag           string `json:"etag"`
-	AccountEmail   string `json:"account_email,omitempty"`
-	RemoteDriveID  string `json:"remote_drive_id,omitempty"`
-	RemoteItemID   string `json:"remote_item_id,omitempty"`
-	SharedSelector string `json:"shared_selector,omitempty"`
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Size           int64          `json:"size"`
+	IsFolder       bool           `json:"is_folder"`
+	ModifiedAt     string         `json:"modified_at"`
+	CreatedAt      string         `json:"created_at"`
+	MimeType       string         `json:"mime_type,omitempty"`
+	ETag           string         `json:"etag"`
+	CheckedOut     bool           `json:"checked_out,omitempty"`
+	CheckedOutBy   string         `json:"checked_out_by,omitempty"`
+	AccountEmail   string         `json:"account_email,omitempty"`
+	RemoteDriveID  string         `json:"remote_drive_id,omitempty"`
+	RemoteItemID   string         `json:"remote_item_id,omitempty"`
+	SharedSelector string         `json:"shared_selector,omitempty"`
+	Fields         map[string]any `json:"fields,omitempty"`
 }
 
 func printStatJSON(w io.Writer, item *graph.Item) error {
@@ -89,6 +120,7 @@ type statPrintOptions struct {
 	AccountEmail   string
 	RemoteDriveID  string
 	RemoteItemID   string
+	Fields         map[string]any // list item fields, when requested
 }
 
 func printStatJSONWithOptions(w io.Writer, item *graph.Item, opts statPrintOptions) error {
@@ -101,10 +133,13 @@ func printStatJSONWithOptions(w io.Writer, item *graph.Item, opts statPrintOptio
 		CreatedAt:      formatAPITime(item.CreatedAt),
 		MimeType:       item.MimeType,
 		ETag:           item.ETag,
+		CheckedOut:     item.CheckedOut,
+		CheckedOutBy:   item.CheckedOutBy,
 		AccountEmail:   opts.AccountEmail,
 		RemoteDriveID:  opts.RemoteDriveID,
 		RemoteItemID:   opts.RemoteItemID,
 		SharedSelector: opts.SharedSelector,
+		Fields:         opts.Fields,
 	}
 
 	enc := json.NewEncoder(w)
@@ -152,11 +187,25 @@ func printStatTextWithOptions(w io.Writer, item *graph.Item, opts statPrintOptio
 		}
 	}
 
+	if item.CheckedOut {
+		if err := writef(w, "Checkout: %s\n", cmp.Or(item.CheckedOutBy, "checked out")); err != nil {
+			return err
+		}
+	}
+
 	if opts.SharedSelector != "" {
 		if err := writef(w, "Shared:   %s\n", opts.SharedSelector); err != nil {
 			return err
 		}
 	}
 
+	if len(opts.Fields) > 0 {
+		if err := writef(w, "Fields:\n"); err != nil {
+			return err
+		}
+
+		return printFieldsText(w, opts.Fields)
+	}
+
 	return nil
 }
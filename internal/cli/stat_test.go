@@ -85,6 +85,27 @@ func TestPrintStatJSON(t *testing.T) {
 	assert.Equal(t, "text/plain", parsed.MimeType)
 }
 
+// Validates: R-1.22.2
+func TestPrintStat_ShowsCheckoutHolder(t *testing.T) {
+	item := &graph.Item{ID: "plan", Name: "plan.docx", CheckedOut: true, CheckedOutBy: "Dana Lee"}
+
+	var text bytes.Buffer
+	require.NoError(t, printStatText(&text, item))
+	assert.Contains(t, text.String(), "Checkout: Dana Lee\n")
+
+	var raw bytes.Buffer
+	require.NoError(t, printStatJSON(&raw, item))
+
+	var parsed statJSONOutput
+	require.NoError(t, json.Unmarshal(raw.Bytes(), &parsed))
+	assert.True(t, parsed.CheckedOut)
+	assert.Equal(t, "Dana Lee", parsed.CheckedOutBy)
+
+	text.Reset()
+	require.NoError(t, printStatText(&text, &graph.Item{ID: "free", Name: "free.docx"}))
+	assert.NotContains(t, text.String(), "Checkout:")
+}
+
 func TestPrintStatText_ZeroTimestampsRenderUnknown(t *testing.T) {
 	item := &graph.Item{
 		ID:         "id-zero",
@@ -55,6 +55,7 @@ func TestRollbackLoginSideEffectsRemovesNewLoginArtifacts(t *testing.T) {
 		"New User",
 		"",
 		driveid.New("new-drive"),
+		config.LoginAccess{},
 	))
 	require.NoError(t, config.AppendDriveSection(cfgPath, cid, filepath.Join(t.TempDir(), "sync")))
 
@@ -105,6 +106,7 @@ func TestRollbackLoginSideEffectsRestoresExistingLoginArtifacts(t *testing.T) {
 		"New User",
 		"New Org",
 		driveid.New("new-drive"),
+		config.LoginAccess{},
 	))
 
 	require.NoError(t, rollbackLoginSideEffects(cfgPath, cid, &snapshot))
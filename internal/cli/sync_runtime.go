@@ -78,6 +78,7 @@ func standaloneMountConfigFromResolvedDrive(
 		Paused:                 rd.Paused,
 		EnableWebsocket:        rd.Websocket,
 		RemoteRootDeltaCapable: rd.RemoteRootDeltaCapable,
+		DownloadOnly:           rd.ReadOnly,
 		TransferWorkers:        rd.TransferWorkers,
 		CheckWorkers:           rd.CheckWorkers,
 		MinFreeSpaceBytes:      minFreeSpace,
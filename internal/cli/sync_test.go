@@ -327,7 +327,10 @@ func TestDryRunFlagSurfaceOnlySyncCommand(t *testing.T) {
 		}
 	})
 
-	assert.Equal(t, []string{"onedrive-go sync"}, commandsWithDryRun)
+	assert.Equal(t, []string{
+		"onedrive-go cp", "onedrive-go dupes", "onedrive-go find", "onedrive-go get",
+		"onedrive-go mirror", "onedrive-go mv", "onedrive-go rm", "onedrive-go sync",
+	}, commandsWithDryRun)
 }
 
 func walkCommandTree(cmd *cobra.Command, visit func(*cobra.Command)) {
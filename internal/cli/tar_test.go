@@ -0,0 +1,316 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/pkg/quickxorhash"
+)
+
+func tarTestHash(content string) string {
+	h := quickxorhash.New()
+	_, _ = h.Write([]byte(content))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// newGetTarTestContext serves a Proj folder with nested files. Each file's
+// metadata points its download URL back at this server under /dl/<id>.
+func newGetTarTestContext(t *testing.T, files map[string]string, badHash string, stdout, stderr *bytes.Buffer) *CLIContext {
+	t.Helper()
+
+	return newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id, ok := strings.CutPrefix(r.URL.Path, "/dl/"); ok {
+				writeTestResponse(t, w, files[id])
+				return
+			}
+
+			hash := func(id string) string {
+				if id == badHash {
+					return tarTestHash("tampered")
+				}
+
+				return tarTestHash(files[id])
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+
+			if r.URL.Path == "/drives/0000000drive-123/items/proj/delta" {
+				writeTestResponsef(t, w, `{"value":[
+					{"id":"proj","name":"Proj","folder":{},"parentReference":{"id":"root"}},
+					{"id":"src","name":"src","folder":{},"lastModifiedDateTime":"2024-02-01T10:00:00Z","parentReference":{"id":"proj"}},
+					{"id":"main","name":"main.go","size":13,"file":{"hashes":{"quickXorHash":%q}},
+					 "lastModifiedDateTime":"2024-03-01T10:00:00Z","parentReference":{"id":"src"}},
+					{"id":"util","name":"util.go","size":5,"file":{"hashes":{"quickXorHash":%q}},
+					 "lastModifiedDateTime":"2024-03-02T10:00:00Z","parentReference":{"id":"src"}},
+					{"id":"readme","name":"README","size":6,"file":{"hashes":{"quickXorHash":%q}},
+					 "lastModifiedDateTime":"2024-01-01T10:00:00Z","parentReference":{"id":"proj"}},
+					{"id":"notes","name":"Notes","package":{"type":"oneNote"},"parentReference":{"id":"proj"}}
+				],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`, hash("main"), hash("util"), hash("readme"))
+
+				return
+			}
+
+			if id, ok := strings.CutPrefix(r.URL.Path, "/drives/0000000drive-123/items/"); ok && files[id] != "" {
+				writeTestResponsef(t, w, `{"id":%q,"name":%q,"size":%d,"file":{"hashes":{"quickXorHash":%q}},
+					"@microsoft.graph.downloadUrl":"http://%s/dl/%s"}`, id, id, len(files[id]), hash(id), r.Host, id)
+
+				return
+			}
+
+			writeTestResponse(t, w, `{"id":"proj","name":"Proj","folder":{},"parentReference":{"id":"root"}}`)
+		}),
+		stdout,
+		stderr,
+	)
+}
+
+var getTarTestFiles = map[string]string{
+	"main":   "package main\n",
+	"util":   "util\n",
+	"readme": "hello\n",
+}
+
+// Validates: R-1.2.6
+func TestRunGetTar_StreamsFolderInPathOrder(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newGetTarTestContext(t, getTarTestFiles, "", &stdout, &stderr)
+
+	cmd := newGetCmd()
+	cmd.SetArgs([]string{"--tar", "/Proj"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+
+	tr := tar.NewReader(&stdout)
+
+	var names []string
+	contents := map[string]string{}
+	mtimes := map[string]time.Time{}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(tr)
+		require.NoError(t, err)
+
+		names = append(names, hdr.Name)
+		contents[hdr.Name] = string(body)
+		mtimes[hdr.Name] = hdr.ModTime.UTC()
+	}
+
+	assert.Equal(t, []string{"README", "src/", "src/main.go", "src/util.go"}, names)
+	assert.Equal(t, "package main\n", contents["src/main.go"])
+	assert.Equal(t, "hello\n", contents["README"])
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), mtimes["src/main.go"])
+	assert.Equal(t, time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC), mtimes["src/"])
+	assert.Contains(t, stderr.String(), "Archived 3 files, 1 folders")
+	assert.Contains(t, stderr.String(), "Skipped 1 items")
+}
+
+// Validates: R-1.2.6
+func TestRunGetTar_FailsOnHashMismatch(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newGetTarTestContext(t, getTarTestFiles, "util", &stdout, &stderr)
+
+	cmd := newGetCmd()
+	cmd.SetArgs([]string{"--tar", "/Proj"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "content hash mismatch")
+}
+
+// Validates: R-1.2.6
+func TestRunGetTar_RejectsLocalPath(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newGetTarTestContext(t, getTarTestFiles, "", &stdout, &stderr)
+
+	cmd := newGetCmd()
+	cmd.SetArgs([]string{"--tar", "/Proj", "out.tar"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.Error(t, cmd.Execute())
+	assert.Empty(t, stdout.String())
+}
+
+// putTarTestServer records folder creations and uploads. Folder and file IDs
+// are their names, and every item reports the hash of what was uploaded.
+type putTarTestServer struct {
+	mu       sync.Mutex
+	folders  []string
+	uploads  map[string]string
+	uploadAt map[string]string
+}
+
+func (s *putTarTestServer) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		const items = "/drives/0000000drive-123/items/"
+		rest := strings.TrimPrefix(r.URL.Path, items)
+
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(rest, "/children"):
+			var body struct {
+				Name string `json:"name"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+			parent := strings.TrimSuffix(rest, "/children")
+			s.folders = append(s.folders, parent+"/"+body.Name)
+			w.WriteHeader(http.StatusCreated)
+			writeTestResponsef(t, w, `{"id":%q,"name":%q,"folder":{}}`, body.Name, body.Name)
+		case r.Method == http.MethodPut:
+			parent, name, _ := strings.Cut(strings.TrimSuffix(rest, ":/content"), ":/")
+			data, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+
+			s.uploads[name] = string(data)
+			s.uploadAt[name] = parent
+			w.WriteHeader(http.StatusCreated)
+			writeTestResponsef(t, w, `{"id":%q,"name":%q,"file":{"hashes":{"quickXorHash":%q}}}`,
+				name, name, tarTestHash(string(data)))
+		case r.Method == http.MethodPatch:
+			writeTestResponsef(t, w, `{"id":%q,"name":%q,"file":{"hashes":{"quickXorHash":%q}}}`,
+				rest, rest, tarTestHash(s.uploads[rest]))
+		default:
+			writeTestResponse(t, w, `{"id":"foo","name":"foo","folder":{}}`)
+		}
+	}
+}
+
+func buildTestTar(t *testing.T, entries []tar.Header, bodies map[string]string) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	for i := range entries {
+		hdr := entries[i]
+		hdr.Size = int64(len(bodies[hdr.Name]))
+		require.NoError(t, tw.WriteHeader(&hdr))
+		_, err := tw.Write([]byte(bodies[hdr.Name]))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+
+	return &buf
+}
+
+// Validates: R-1.3.8
+func TestRunPutTar_ExtractsArchiveIntoRemoteFolders(t *testing.T) {
+	srv := &putTarTestServer{uploads: map[string]string{}, uploadAt: map[string]string{}}
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), srv.handler(t), &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	mtime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	archive := buildTestTar(t, []tar.Header{
+		{Name: "./", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: mtime},
+		{Name: "./docs/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: mtime},
+		{Name: "./docs/readme.md", Typeflag: tar.TypeReg, Mode: 0o644, ModTime: mtime},
+		{Name: "./nested/deep/file.txt", Typeflag: tar.TypeReg, Mode: 0o644, ModTime: mtime},
+		{Name: "./link", Typeflag: tar.TypeSymlink, Linkname: "docs"},
+	}, map[string]string{
+		"./docs/readme.md":       "# readme\n",
+		"./nested/deep/file.txt": "deep\n",
+	})
+
+	cmd := newPutCmd()
+	cmd.SetArgs([]string{"--tar", "-", "/Projects/foo"})
+	cmd.SetIn(archive)
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "# readme\n", srv.uploads["readme.md"])
+	assert.Equal(t, "docs", srv.uploadAt["readme.md"])
+	assert.Equal(t, "deep\n", srv.uploads["file.txt"])
+	assert.Equal(t, "deep", srv.uploadAt["file.txt"])
+	assert.Contains(t, srv.folders, "foo/docs")
+	assert.Contains(t, srv.folders, "foo/nested")
+	assert.Contains(t, srv.folders, "nested/deep")
+
+	var out putFolderJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out.Files, 2)
+	assert.Equal(t, "Projects/foo/docs/readme.md", out.Files[0].Path)
+	assert.Equal(t, 3, out.FoldersCreated)
+	assert.Equal(t, int64(len("# readme\n")+len("deep\n")), out.TotalSize)
+	assert.Empty(t, out.Errors)
+}
+
+// Validates: R-1.3.8
+func TestRunPutTar_RejectsEntriesOutsideDestination(t *testing.T) {
+	srv := &putTarTestServer{uploads: map[string]string{}, uploadAt: map[string]string{}}
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), srv.handler(t), &stdout, &stderr)
+
+	archive := buildTestTar(t, []tar.Header{
+		{Name: "../escape.txt", Typeflag: tar.TypeReg, Mode: 0o644},
+		{Name: "ok.txt", Typeflag: tar.TypeReg, Mode: 0o644},
+	}, map[string]string{"../escape.txt": "x", "ok.txt": "ok"})
+
+	cmd := newPutCmd()
+	cmd.SetArgs([]string{"--tar", "-", "/Projects/foo"})
+	cmd.SetIn(archive)
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 errors during tar upload")
+	assert.NotContains(t, srv.uploads, "escape.txt")
+	assert.Equal(t, "ok", srv.uploads["ok.txt"])
+}
+
+func TestCleanTarEntryName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"./a/b/", "a/b", true},
+		{"a//b", "a/b", true},
+		{".", "", true},
+		{"./", "", true},
+		{"/etc/passwd", "", false},
+		{"../x", "", false},
+		{"a/../../x", "", false},
+		{"a/../b", "b", true},
+	}
+
+	for _, tc := range tests {
+		got, ok := cleanTarEntryName(tc.in)
+		assert.Equal(t, tc.ok, ok, tc.in)
+		assert.Equal(t, tc.want, got, tc.in)
+	}
+}
@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// touchTimeLayouts are the accepted --mtime and --ctime spellings besides
+// RFC 3339. They carry no zone and are read as local time.
+var touchTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func newTouchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "touch <path>",
+		Short: "Create an empty file or set a remote item's timestamps",
+		Long: `Set the modification time of a remote file or folder without uploading
+anything. A missing file is created empty; its parent folder must exist.
+
+--mtime sets the modification time (default: now) and --ctime the creation
+time, which is otherwise left alone. Both take RFC 3339 ("2024-05-01T09:30:00Z")
+or a local "2024-05-01 09:30", "2024-05-01T09:30:00" or "2024-05-01".`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{mutatesDriveAnnotation: mutatesDriveValue},
+		RunE:        runTouch,
+	}
+
+	cmd.Flags().String("mtime", "", "modification time to set (default: now)")
+	cmd.Flags().String("ctime", "", "creation time to set")
+
+	return cmd
+}
+
+// touchJSONOutput is the JSON output schema for the touch command.
+type touchJSONOutput struct {
+	Path       string `json:"path"`
+	ID         string `json:"id"`
+	Created    bool   `json:"created"`
+	ModifiedAt string `json:"modified_at"`
+	CreatedAt  string `json:"created_at"`
+}
+
+func runTouch(cmd *cobra.Command, args []string) error {
+	remotePath := driveops.CleanRemotePath(args[0])
+	if remotePath == "" {
+		return fmt.Errorf("cannot touch the drive root")
+	}
+
+	mtime, err := touchTimeFlag(cmd, "mtime")
+	if err != nil {
+		return err
+	}
+
+	ctime, err := touchTimeFlag(cmd, "ctime")
+	if err != nil {
+		return err
+	}
+
+	if mtime.IsZero() {
+		mtime = time.Now()
+	}
+
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	session, err := cc.Session(ctx)
+	if err != nil {
+		return err
+	}
+
+	cc.Logger.Debug("touch", "path", remotePath, "mtime", mtime, "ctime", ctime)
+
+	item, created, err := touchTarget(ctx, session, remotePath)
+	if err != nil {
+		return err
+	}
+
+	patched, err := session.Meta.UpdateFileSystemTimes(ctx, session.DriveID, item.ID, mtime, ctime)
+	if err != nil {
+		return fmt.Errorf("setting timestamps on %q: %w", remotePath, err)
+	}
+
+	if cc.Flags.JSON {
+		return printTouchJSON(cc.Output(), touchJSONOutput{
+			Path:       remotePath,
+			ID:         patched.ID,
+			Created:    created,
+			ModifiedAt: formatAPITime(patched.ModifiedAt),
+			CreatedAt:  formatAPITime(patched.CreatedAt),
+		})
+	}
+
+	if created {
+		cc.Statusf("Created %s\n", remotePath)
+	} else {
+		cc.Statusf("Touched %s (modified %s)\n", remotePath, formatTime(patched.ModifiedAt))
+	}
+
+	return nil
+}
+
+// touchTimeFlag parses a --mtime or --ctime value; an empty flag yields the
+// zero time.
+func touchTimeFlag(cmd *cobra.Command, name string) (time.Time, error) {
+	raw, err := cmd.Flags().GetString(name)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("read --%s flag: %w", name, err)
+	}
+
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	if t, ok := parseUserTime(raw); ok {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --%s %q: want RFC 3339 or YYYY-MM-DD[ HH:MM[:SS]]", name, raw)
+}
+
+// parseUserTime parses an RFC 3339 timestamp or a local date and time in one
+// of touchTimeLayouts.
+func parseUserTime(raw string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, true
+	}
+
+	for _, layout := range touchTimeLayouts {
+		if t, err := time.ParseInLocation(layout, raw, time.Local); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// touchTarget returns the item at remotePath, creating an empty file when it
+// is missing. created reports whether this call made the file. The upload
+// refuses to replace, so a file created concurrently by someone else is
+// touched rather than truncated.
+func touchTarget(ctx context.Context, session *driveops.MountSession, remotePath string) (*graph.Item, bool, error) {
+	item, err := session.ResolveItem(ctx, remotePath)
+	if err == nil {
+		return item, false, nil
+	}
+
+	if !errors.Is(err, graph.ErrNotFound) {
+		return nil, false, fmt.Errorf("resolving %q: %w", remotePath, err)
+	}
+
+	parentPath, name := driveops.SplitParentAndName(remotePath)
+
+	parentID := mkdirStartParentID(session)
+	if parentPath != "" {
+		parent, err := session.ResolveItem(ctx, parentPath)
+		if err != nil {
+			return nil, false, fmt.Errorf("resolving parent %q: %w", parentPath, err)
+		}
+
+		if !parent.IsFolder {
+			return nil, false, fmt.Errorf("parent %q is not a folder", parentPath)
+		}
+
+		parentID = parent.ID
+	}
+
+	item, err = session.Meta.SimpleUpload(ctx, session.DriveID, parentID, name, bytes.NewReader(nil), 0, graph.ConflictFail)
+	if errors.Is(err, graph.ErrConflict) {
+		existing, resolveErr := session.ResolveItem(ctx, remotePath)
+		if resolveErr != nil {
+			return nil, false, fmt.Errorf("resolving %q after it appeared concurrently: %w", remotePath, resolveErr)
+		}
+
+		return existing, false, nil
+	}
+
+	if err != nil {
+		return nil, false, fmt.Errorf("creating %q: %w", remotePath, err)
+	}
+
+	if _, err := session.WaitPathVisible(ctx, remotePath); err != nil {
+		return nil, false, fmt.Errorf("confirming %q visibility: %w", remotePath, err)
+	}
+
+	return item, true, nil
+}
+
+// printTouchJSON writes the touch command's JSON output to w.
+func printTouchJSON(w io.Writer, out touchJSONOutput) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encode touch output: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// Validates: R-1.20.2
+func TestTouchTimeFlag(t *testing.T) {
+	cases := map[string]time.Time{
+		"":                     {},
+		"2024-05-01T09:30:00Z": time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC),
+		"2024-05-01 09:30":     time.Date(2024, 5, 1, 9, 30, 0, 0, time.Local),
+		"2024-05-01":           time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local),
+	}
+
+	for raw, want := range cases {
+		cmd := newTouchCmd()
+		require.NoError(t, cmd.Flags().Set("mtime", raw))
+
+		got, err := touchTimeFlag(cmd, "mtime")
+		require.NoError(t, err, raw)
+		assert.True(t, want.Equal(got), "%q: want %v, got %v", raw, want, got)
+	}
+
+	cmd := newTouchCmd()
+	require.NoError(t, cmd.Flags().Set("ctime", "yesterday"))
+
+	_, err := touchTimeFlag(cmd, "ctime")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --ctime "yesterday"`)
+}
+
+// touchTestServer fakes a Docs folder holding a.txt. uploads records the
+// conflict behavior of each PUT; conflict makes the PUT fail as if another
+// client had just created the file.
+type touchTestServer struct {
+	mu       sync.Mutex
+	created  bool
+	conflict bool
+	uploads  []string
+	patches  []string
+}
+
+func (s *touchTestServer) handler(t *testing.T) http.Handler {
+	t.Helper()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPut:
+			s.uploads = append(s.uploads, r.URL.Query().Get(conflictQueryKey))
+			s.created = true
+
+			if s.conflict {
+				w.WriteHeader(http.StatusConflict)
+				writeTestResponse(t, w, `{"error":{"code":"nameAlreadyExists"}}`)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			writeTestResponse(t, w, `{"id":"new","name":"new.txt","size":0,"file":{}}`)
+		case r.Method == http.MethodPatch:
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			s.patches = append(s.patches, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]+" "+string(body))
+			writeTestResponse(t, w, `{"id":"a","name":"a.txt","file":{},
+				"createdDateTime":"2020-02-03T04:05:06Z","lastModifiedDateTime":"2024-05-01T09:30:00Z"}`)
+		case strings.HasSuffix(r.URL.Path, "root:/Docs/a.txt:"):
+			writeTestResponse(t, w, `{"id":"a","name":"a.txt","file":{},"parentReference":{"id":"docs"}}`)
+		case strings.HasSuffix(r.URL.Path, "root:/Docs/new.txt:") && s.created:
+			writeTestResponse(t, w, `{"id":"new","name":"new.txt","file":{},"parentReference":{"id":"docs"}}`)
+		case strings.HasSuffix(r.URL.Path, "root:/Docs:"):
+			writeTestResponse(t, w, `{"id":"docs","name":"Docs","folder":{},"parentReference":{"id":"root"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			writeTestResponse(t, w, `{"error":{"code":"itemNotFound"}}`)
+		}
+	})
+}
+
+func runTouchTestCommand(t *testing.T, srv *touchTestServer, args ...string) (touchJSONOutput, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), srv.handler(t), &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newTouchCmd()
+	cmd.SetArgs(args)
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	if err := cmd.Execute(); err != nil {
+		return touchJSONOutput{}, err
+	}
+
+	var out touchJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+
+	return out, nil
+}
+
+// Validates: R-1.20, R-1.20.2
+func TestRunTouch_ExistingItemOnlyPatchesTimes(t *testing.T) {
+	srv := &touchTestServer{}
+
+	out, err := runTouchTestCommand(t, srv, "/Docs/a.txt", "--mtime", "2024-05-01T09:30:00Z", "--ctime", "2020-02-03T04:05:06Z")
+	require.NoError(t, err)
+
+	assert.Empty(t, srv.uploads)
+	assert.Equal(t, []string{
+		`a {"fileSystemInfo":{"createdDateTime":"2020-02-03T04:05:06Z","lastModifiedDateTime":"2024-05-01T09:30:00Z"}}`,
+	}, srv.patches)
+	assert.Equal(t, touchJSONOutput{
+		Path: "Docs/a.txt", ID: "a", ModifiedAt: "2024-05-01T09:30:00Z", CreatedAt: "2020-02-03T04:05:06Z",
+	}, out)
+}
+
+// Validates: R-1.20.1
+func TestRunTouch_MissingFileIsCreatedWithoutReplacing(t *testing.T) {
+	srv := &touchTestServer{}
+
+	out, err := runTouchTestCommand(t, srv, "Docs/new.txt")
+	require.NoError(t, err)
+	assert.True(t, out.Created)
+	assert.Equal(t, []string{"fail"}, srv.uploads, "touch never replaces a file that appears concurrently")
+	require.Len(t, srv.patches, 1)
+	assert.True(t, strings.HasPrefix(srv.patches[0], "new "), srv.patches[0])
+
+	raced := &touchTestServer{conflict: true}
+
+	out, err = runTouchTestCommand(t, raced, "Docs/new.txt")
+	require.NoError(t, err)
+	assert.False(t, out.Created)
+	require.Len(t, raced.patches, 1)
+
+	_, err = runTouchTestCommand(t, &touchTestServer{}, "Missing/new.txt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `resolving parent "Missing"`)
+}
@@ -0,0 +1,658 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/config"
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/fsroot"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+	"github.com/tonimelisma/onedrive-go/internal/retry"
+)
+
+// transferJournalSubdir is the data-dir subdirectory holding the journals of
+// interrupted transfers.
+const transferJournalSubdir = "transfer-journals"
+
+// transferJournalVersion is the schema version written to a journal file.
+const transferJournalVersion = 1
+
+// transferJournalExt names journal files: a header line, then one JSON
+// record per copied file.
+const transferJournalExt = ".jsonl"
+
+// Journals name the user's drives and paths, so they are owner-only.
+const (
+	transferJournalFilePerms = 0o600
+	transferJournalDirPerms  = 0o700
+)
+
+func newTransferCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer <src-drive>:<path> <dst-drive>:<path>",
+		Short: "Copy files between configured drives or accounts",
+		Long: `Copy a file or folder from one configured drive to another, including
+drives of different accounts. Each file is streamed from the source download
+straight into a destination upload session; nothing is staged on local disk.
+
+Drives are named by any --drive selector (canonical ID, display name, or a
+unique partial match) followed by ":" and the path, e.g.
+"personal:me@example.com:/Photos" or "Work:/Archive". A bare "<drive>:" is
+the drive root.
+
+Modification times are preserved and every file is checked twice: the bytes
+read must match the source QuickXorHash and the upload must match the bytes
+read. Existing destination files are replaced. If the destination is an
+existing folder the source is copied into it; otherwise it is created.
+
+Folders need --recursive. Progress is journaled under the data directory, so
+rerunning an interrupted transfer skips files already copied whose source
+content has not changed. The journal is removed once every file succeeds.
+
+Examples:
+  onedrive-go transfer personal:me@example.com:/Photos business:me@contoso.com:/ -r
+  onedrive-go transfer Home:/notes.txt Work:/Inbox/notes.txt`,
+		Args:        cobra.ExactArgs(2),
+		Annotations: map[string]string{skipConfigAnnotation: skipConfigValue},
+		RunE:        runTransfer,
+	}
+
+	cmd.Flags().BoolP("recursive", "r", false, "transfer folders and everything in them")
+
+	return cmd
+}
+
+// transferEndpoint is one parsed <drive>:<path> argument.
+type transferEndpoint struct {
+	selector string
+	path     string
+}
+
+func (e transferEndpoint) String() string {
+	return e.selector + ":/" + driveops.CleanRemotePath(e.path)
+}
+
+// transferJSONOutput is the JSON output schema for the transfer command.
+type transferJSONOutput struct {
+	Source      string             `json:"source"`
+	Destination string             `json:"destination"`
+	Files       []transferFileJSON `json:"files"`
+	Resumed     int                `json:"resumed"`
+	Errors      []string           `json:"errors"`
+}
+
+// transferFileJSON is one file copied by transfer, with its path relative to
+// the destination root.
+type transferFileJSON struct {
+	Path string `json:"path"`
+	ID   string `json:"id"`
+	Size int64  `json:"size"`
+}
+
+// transferPlan is a resolved transfer: folders to create and files to copy,
+// both relative to the destination root folder. Folders come parents first.
+type transferPlan struct {
+	src, dst  *driveops.MountSession
+	dstRootID string
+	folders   []string
+	files     []driveops.RemoteTreeEntry
+}
+
+// transferJournal records the files a transfer has already copied. An entry
+// only counts while the source item ID, size and content are unchanged. The
+// exported fields are the journal file's header line; each copied file then
+// appends one entry line, so recording a file costs one short write.
+type transferJournal struct {
+	Version     int    `json:"version"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+
+	files map[string]transferJournalEntry // by path, the last line for a path wins
+	name  string
+	log   *os.File // opened for appending on the first record
+}
+
+type transferJournalEntry struct {
+	Path          string `json:"path"`
+	SourceID      string `json:"source_id"`
+	QuickXorHash  string `json:"quick_xor_hash,omitempty"`
+	CTag          string `json:"c_tag,omitempty"`
+	ETag          string `json:"e_tag,omitempty"`
+	Size          int64  `json:"size"`
+	DestinationID string `json:"destination_id"`
+}
+
+func runTransfer(cmd *cobra.Command, args []string) error {
+	recursive, err := cmd.Flags().GetBool("recursive")
+	if err != nil {
+		return fmt.Errorf("read --recursive flag: %w", err)
+	}
+
+	src, err := parseTransferEndpoint(args[0])
+	if err != nil {
+		return err
+	}
+
+	dst, err := parseTransferEndpoint(args[1])
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	cc := mustCLIContext(ctx)
+
+	srcSession, srcCID, err := transferEndpointSession(ctx, cc, src, false)
+	if err != nil {
+		return err
+	}
+
+	dstSession, dstCID, err := transferEndpointSession(ctx, cc, dst, true)
+	if err != nil {
+		return err
+	}
+
+	plan, err := planTransfer(ctx, srcSession, dstSession, src.path, dst.path, recursive)
+	if err != nil {
+		return err
+	}
+
+	cc.Logger.Debug("transfer", "source", src.String(), "destination", dst.String(),
+		"folders", len(plan.folders), "files", len(plan.files))
+
+	journal, err := loadTransferJournal(
+		srcCID+":/"+driveops.CleanRemotePath(src.path), dstCID+":/"+driveops.CleanRemotePath(dst.path))
+	if err != nil {
+		return err
+	}
+	defer journal.close()
+
+	out := transferJSONOutput{Source: src.String(), Destination: dst.String(), Files: []transferFileJSON{}, Errors: []string{}}
+	failed := executeTransfer(ctx, cc, plan, journal, &out)
+
+	if len(failed) == 0 {
+		if err := journal.remove(); err != nil {
+			return err
+		}
+	}
+
+	if cc.Flags.JSON {
+		if err := printTransferJSON(cc.Output(), out); err != nil {
+			return err
+		}
+	} else {
+		cc.Statusf("Transferred %d file(s) from %s to %s", len(out.Files), out.Source, out.Destination)
+		if out.Resumed > 0 {
+			cc.Statusf(", %d already done", out.Resumed)
+		}
+		cc.Statusf("\n")
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d transfers failed (rerun to resume): %w",
+			len(failed), len(plan.files), errors.Join(failed...))
+	}
+
+	return nil
+}
+
+// parseTransferEndpoint splits "<drive>:<path>" at the first ":/". Drive
+// selectors may themselves contain colons ("personal:me@example.com") but
+// OneDrive names cannot, so the first ":/" always ends the selector. A
+// trailing ":" names the drive root.
+func parseTransferEndpoint(arg string) (transferEndpoint, error) {
+	if idx := strings.Index(arg, ":/"); idx > 0 {
+		return transferEndpoint{selector: arg[:idx], path: arg[idx+1:]}, nil
+	}
+
+	if selector, ok := strings.CutSuffix(arg, ":"); ok && selector != "" {
+		return transferEndpoint{selector: selector, path: "/"}, nil
+	}
+
+	return transferEndpoint{}, fmt.Errorf("%q is not <drive>:<path> (e.g. personal:me@example.com:/Docs)", arg)
+}
+
+// transferEndpointSession opens a session for the configured drive the
+// endpoint selects and returns it with the drive's canonical ID. A write
+// endpoint must not belong to an account signed in read-only.
+func transferEndpointSession(
+	ctx context.Context, cc *CLIContext, ep transferEndpoint, write bool,
+) (*driveops.MountSession, string, error) {
+	rawCfg, err := config.LoadOrDefault(cc.CfgPath, cc.Logger)
+	if err != nil {
+		return nil, "", fmt.Errorf("loading config: %w", err)
+	}
+
+	drives, err := config.ResolveDrives(rawCfg, []string{ep.selector}, true, cc.Logger)
+	if err != nil {
+		return nil, "", fmt.Errorf("resolve drive %q: %w", ep.selector, err)
+	}
+
+	if len(drives) != 1 {
+		return nil, "", fmt.Errorf("drive %q matches %d drives", ep.selector, len(drives))
+	}
+
+	if write {
+		if err := requireWritableDrive(drives[0], "transfer"); err != nil {
+			return nil, "", err
+		}
+	}
+
+	session, err := cc.sessionForDrive(ctx, drives[0])
+	if err != nil {
+		return nil, "", fmt.Errorf("drive %s: %w", drives[0].CanonicalID, err)
+	}
+
+	return session, drives[0].CanonicalID.String(), nil
+}
+
+// planTransfer resolves the source and destination. A file keeps the
+// destination name when the destination is not an existing folder; a folder
+// is copied into an existing destination folder, or becomes the destination
+// when it does not exist yet.
+func planTransfer(
+	ctx context.Context, src, dst *driveops.MountSession, srcPath, dstPath string, recursive bool,
+) (*transferPlan, error) {
+	srcItem, err := src.ResolveItem(ctx, srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolving source %q: %w", srcPath, err)
+	}
+
+	dstItem, err := dst.ResolveItem(ctx, dstPath)
+	if err != nil && !driveops.IsNotFound(err) {
+		return nil, fmt.Errorf("resolving destination %q: %w", dstPath, err)
+	}
+
+	plan := &transferPlan{src: src, dst: dst}
+
+	if !srcItem.IsFolder {
+		return planFileTransfer(ctx, plan, srcItem, dstItem, dstPath)
+	}
+
+	if !recursive {
+		return nil, fmt.Errorf("%q is a folder (use --recursive)", srcPath)
+	}
+
+	if dstItem != nil && !dstItem.IsFolder {
+		return nil, fmt.Errorf("destination %q is a file", dstPath)
+	}
+
+	_, entries, err := src.EnumerateFolder(ctx, srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("listing source %q: %w", srcPath, err)
+	}
+
+	prefix := ""
+	if dstItem != nil && driveops.CleanRemotePath(srcPath) != "" {
+		// Copy into the existing folder under the source name.
+		prefix = srcItem.Name
+		plan.folders = append(plan.folders, prefix)
+	}
+
+	if dstItem != nil {
+		plan.dstRootID = dstItem.ID
+	} else if plan.dstRootID, err = ensureRemoteFolderPath(ctx, dst, dstPath); err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		entry := entries[i]
+		entry.Path = joinRemotePath(prefix, entry.Path)
+
+		if entry.Item.IsFolder {
+			plan.folders = append(plan.folders, entry.Path)
+		} else if !entry.Item.IsPackage {
+			plan.files = append(plan.files, entry)
+		}
+	}
+
+	return plan, nil
+}
+
+func planFileTransfer(
+	ctx context.Context, plan *transferPlan, srcItem, dstItem *graph.Item, dstPath string,
+) (*transferPlan, error) {
+	if dstItem != nil && dstItem.IsFolder {
+		plan.dstRootID = dstItem.ID
+		plan.files = []driveops.RemoteTreeEntry{{Path: srcItem.Name, Item: *srcItem}}
+
+		return plan, nil
+	}
+
+	parentPath, name := driveops.SplitParentAndName(dstPath)
+	if name == "" {
+		return nil, fmt.Errorf("destination %q does not name a file", dstPath)
+	}
+
+	parent, err := resolveUploadParent(ctx, plan.dst, parentPath)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.dstRootID = parent.ID
+	plan.files = []driveops.RemoteTreeEntry{{Path: name, Item: *srcItem}}
+
+	return plan, nil
+}
+
+// executeTransfer creates the folders, then streams every file not already
+// in the journal. A failed file does not stop the others; the failures are
+// returned so a rerun can pick them up.
+func executeTransfer(
+	ctx context.Context, cc *CLIContext, plan *transferPlan, journal *transferJournal, out *transferJSONOutput,
+) []error {
+	folderIDs := map[string]string{"": plan.dstRootID}
+
+	for _, rel := range plan.folders {
+		parentRel, name := driveops.SplitParentAndName(rel)
+
+		folder, err := plan.dst.EnsureFolder(ctx, folderIDs[parentRel], name)
+		if err != nil {
+			err = fmt.Errorf("creating folder %q: %w", rel, err)
+			out.Errors = append(out.Errors, err.Error())
+
+			return []error{err}
+		}
+
+		folderIDs[rel] = folder.ID
+	}
+
+	var failed []error
+
+	for i := range plan.files {
+		entry := &plan.files[i]
+		if journal.done(entry) {
+			out.Resumed++
+			continue
+		}
+
+		parentRel, name := driveops.SplitParentAndName(entry.Path)
+
+		result, err := streamTransferFile(ctx, cc, plan, &entry.Item, folderIDs[parentRel], name)
+		if err == nil {
+			err = journal.record(entry, result.Item.ID)
+		}
+
+		if err != nil {
+			err = fmt.Errorf("%s: %w", entry.Path, err)
+			out.Errors = append(out.Errors, err.Error())
+			failed = append(failed, err)
+
+			continue
+		}
+
+		out.Files = append(out.Files, transferFileJSON{Path: entry.Path, ID: result.Item.ID, Size: result.Size})
+		if !cc.Flags.JSON {
+			cc.Statusf("Transferred %s (%s)\n", entry.Path, formatSize(result.Size))
+		}
+	}
+
+	return failed
+}
+
+// streamTransferFile pipes the source download into a destination upload.
+// StreamContent checks the bytes read against the source QuickXorHash and
+// UploadStream checks the uploaded item against the bytes sent, so a success
+// means the destination matches the source.
+func streamTransferFile(
+	ctx context.Context, cc *CLIContext, plan *transferPlan, item *graph.Item, parentID, name string,
+) (*driveops.UploadResult, error) {
+	pr, pw := io.Pipe()
+	downloaded := make(chan error, 1)
+
+	go func() {
+		_, err := driveops.StreamContent(ctx, plan.src.Transfer, driveops.StreamRequest{
+			DriveID: plan.src.DriveID,
+			Item:    item,
+			End:     -1,
+		}, pw, retry.TimeSleep, cc.Logger)
+		pw.CloseWithError(err)
+		downloaded <- err
+	}()
+
+	tm := driveops.NewTransferManager(plan.dst.Transfer, plan.dst.Transfer, nil, cc.Logger)
+	result, uploadErr := tm.UploadStream(ctx, plan.dst.DriveID, parentID, name, pr, driveops.UploadOpts{
+		Mtime: item.ModifiedAt,
+	})
+
+	// Unblock the download if the upload stopped reading early.
+	pr.CloseWithError(io.ErrClosedPipe)
+
+	// A download cut off by that close only reflects the upload's failure,
+	// so the upload error is the one worth reporting.
+	downloadErr := <-downloaded
+	if downloadErr != nil && (uploadErr == nil || !errors.Is(downloadErr, io.ErrClosedPipe)) {
+		return nil, fmt.Errorf("downloading: %w", downloadErr)
+	}
+
+	if uploadErr != nil {
+		return nil, fmt.Errorf("uploading: %w", uploadErr)
+	}
+
+	return result, nil
+}
+
+func printTransferJSON(w io.Writer, out transferJSONOutput) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encode transfer output: %w", err)
+	}
+
+	return nil
+}
+
+// loadTransferJournal reads the journal of an earlier run of the same
+// transfer, or starts an empty one, and compacts it to one line per file.
+// Unreadable lines, such as one cut off by a crash, are dropped, and a
+// journal with an unreadable header is started over: the worst case is
+// copying some files again.
+func loadTransferJournal(source, destination string) (*transferJournal, error) {
+	sum := sha256.Sum256([]byte(source + "\x00" + destination))
+	journal := &transferJournal{
+		Version:     transferJournalVersion,
+		Source:      source,
+		Destination: destination,
+		files:       map[string]transferJournalEntry{},
+		name:        hex.EncodeToString(sum[:]) + transferJournalExt,
+	}
+
+	root, err := fsroot.Open(transferJournalDir())
+	if err != nil {
+		return nil, fmt.Errorf("opening transfer journal root: %w", err)
+	}
+
+	data, err := root.ReadFile(journal.name)
+	if errors.Is(err, os.ErrNotExist) {
+		return journal, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading transfer journal: %w", err)
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+
+	var header transferJournal
+	if json.Unmarshal(lines[0], &header) == nil && header.Version == transferJournalVersion {
+		for _, line := range lines[1:] {
+			var entry transferJournalEntry
+			if json.Unmarshal(line, &entry) == nil && entry.Path != "" {
+				journal.files[entry.Path] = entry
+			}
+		}
+	}
+
+	if err := journal.compact(root); err != nil {
+		return nil, err
+	}
+
+	return journal, nil
+}
+
+func transferJournalDir() string {
+	return filepath.Join(config.DefaultDataDir(), transferJournalSubdir)
+}
+
+// compact rewrites the journal file as its header and one line per file.
+func (j *transferJournal) compact(root *fsroot.Root) error {
+	header, err := json.Marshal(j)
+	if err != nil {
+		return fmt.Errorf("encoding transfer journal: %w", err)
+	}
+
+	paths := slices.Sorted(maps.Keys(j.files))
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	buf.WriteByte('\n')
+
+	for _, p := range paths {
+		line, err := json.Marshal(j.files[p])
+		if err != nil {
+			return fmt.Errorf("encoding transfer journal: %w", err)
+		}
+
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	if err := root.AtomicWrite(j.name, buf.Bytes(), transferJournalFilePerms, transferJournalDirPerms, ".journal-*.tmp"); err != nil {
+		return fmt.Errorf("writing transfer journal: %w", err)
+	}
+
+	return nil
+}
+
+// done reports whether the entry was copied by an earlier run and its source
+// content is unchanged since: the same QuickXorHash or, for items without
+// one, the same cTag or else eTag.
+func (j *transferJournal) done(entry *driveops.RemoteTreeEntry) bool {
+	rec, ok := j.files[entry.Path]
+	if !ok || rec.SourceID != entry.Item.ID || rec.Size != entry.Item.Size {
+		return false
+	}
+
+	switch {
+	case entry.Item.QuickXorHash != "":
+		return rec.QuickXorHash == entry.Item.QuickXorHash
+	case entry.Item.CTag != "":
+		return rec.CTag == entry.Item.CTag
+	default:
+		return entry.Item.ETag != "" && rec.ETag == entry.Item.ETag
+	}
+}
+
+// record marks the entry copied and appends it to the journal file, which
+// starts with the header when this run created it.
+func (j *transferJournal) record(entry *driveops.RemoteTreeEntry, destinationID string) error {
+	rec := transferJournalEntry{
+		Path:          entry.Path,
+		SourceID:      entry.Item.ID,
+		QuickXorHash:  entry.Item.QuickXorHash,
+		CTag:          entry.Item.CTag,
+		ETag:          entry.Item.ETag,
+		Size:          entry.Item.Size,
+		DestinationID: destinationID,
+	}
+	j.files[entry.Path] = rec
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encoding transfer journal: %w", err)
+	}
+
+	if j.log == nil {
+		if err := j.openLog(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := j.log.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("writing transfer journal: %w", err)
+	}
+
+	return nil
+}
+
+// openLog opens the journal file for appending, writing the header first
+// when the file is new.
+func (j *transferJournal) openLog() error {
+	root, err := fsroot.Open(transferJournalDir())
+	if err != nil {
+		return fmt.Errorf("opening transfer journal root: %w", err)
+	}
+
+	if err := root.MkdirAll(transferJournalDirPerms); err != nil {
+		return fmt.Errorf("creating transfer journal directory: %w", err)
+	}
+
+	f, err := root.OpenFile(j.name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, transferJournalFilePerms)
+	if err != nil {
+		return fmt.Errorf("opening transfer journal: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err == nil && info.Size() == 0 {
+		var header []byte
+		if header, err = json.Marshal(j); err == nil {
+			_, err = f.Write(append(header, '\n'))
+		}
+	}
+
+	if err != nil {
+		return errors.Join(fmt.Errorf("writing transfer journal header: %w", err), f.Close())
+	}
+
+	j.log = f
+
+	return nil
+}
+
+// close closes the journal file if this run appended to it.
+func (j *transferJournal) close() error {
+	if j.log == nil {
+		return nil
+	}
+
+	err := j.log.Close()
+	j.log = nil
+
+	if err != nil {
+		return fmt.Errorf("closing transfer journal: %w", err)
+	}
+
+	return nil
+}
+
+// remove deletes the journal after a transfer finished without failures.
+func (j *transferJournal) remove() error {
+	if err := j.close(); err != nil {
+		return err
+	}
+
+	root, err := fsroot.Open(transferJournalDir())
+	if err != nil {
+		return fmt.Errorf("opening transfer journal root: %w", err)
+	}
+
+	if err := root.Remove(j.name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing transfer journal: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+	"github.com/tonimelisma/onedrive-go/internal/localpath"
+)
+
+// Reasons reported for files that get and put leave alone.
+const (
+	skipReasonExists    = "exists"
+	skipReasonSameSize  = "same_size"
+	skipReasonUnchanged = "unchanged"
+)
+
+// skippedJSONOutput is one file left alone by --update or --ignore-existing.
+type skippedJSONOutput struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// transferSkipPolicy decides whether get or put may leave an existing
+// destination file alone. The zero value transfers everything.
+type transferSkipPolicy struct {
+	ignoreExisting bool
+	update         bool
+	checksum       bool
+	sizeOnly       bool
+}
+
+// transferSide is what is known about one end of a transfer. hash is
+// computed lazily because hashing a large local file is the expensive step.
+type transferSide struct {
+	exists bool
+	size   int64
+	mtime  time.Time
+	hash   func() (string, error)
+}
+
+func addTransferSkipFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("ignore-existing", false, "skip files that already exist at the destination")
+	cmd.Flags().Bool("update", false, "skip files whose destination already has the same size and QuickXorHash")
+	cmd.Flags().Bool("checksum", false, "like --update, but never trust equal size when a hash is unavailable")
+	cmd.Flags().Bool("size-only", false, "like --update, but treat files of equal size as unchanged without hashing")
+	cmd.MarkFlagsMutuallyExclusive("ignore-existing", "update")
+	cmd.MarkFlagsMutuallyExclusive("ignore-existing", "checksum")
+	cmd.MarkFlagsMutuallyExclusive("ignore-existing", "size-only")
+	cmd.MarkFlagsMutuallyExclusive("checksum", "size-only")
+}
+
+// readTransferSkipPolicy reads the skip flags. --checksum and --size-only
+// choose how --update compares files, so either one implies --update.
+func readTransferSkipPolicy(cmd *cobra.Command) (transferSkipPolicy, error) {
+	var p transferSkipPolicy
+
+	for _, f := range []struct {
+		name string
+		dst  *bool
+	}{
+		{"ignore-existing", &p.ignoreExisting},
+		{"update", &p.update},
+		{"checksum", &p.checksum},
+		{"size-only", &p.sizeOnly},
+	} {
+		v, err := cmd.Flags().GetBool(f.name)
+		if err != nil {
+			return p, fmt.Errorf("reading --%s flag: %w", f.name, err)
+		}
+
+		*f.dst = v
+	}
+
+	p.update = p.update || p.checksum || p.sizeOnly
+
+	return p, nil
+}
+
+func (p transferSkipPolicy) active() bool {
+	return p.ignoreExisting || p.update
+}
+
+// skipReason returns why src need not be copied over dst, or "" when it must
+// be transferred. Different sizes always transfer. Equal sizes are settled by
+// QuickXorHash; when either side has no hash, --update accepts the equal size
+// and --checksum transfers anyway. The local side is only hashed once the
+// sizes match.
+func (p transferSkipPolicy) skipReason(src, dst transferSide) (string, error) {
+	switch {
+	case !dst.exists:
+		return "", nil
+	case p.ignoreExisting:
+		return skipReasonExists, nil
+	case !p.update, src.size != dst.size:
+		return "", nil
+	case p.sizeOnly:
+		return skipReasonSameSize, nil
+	}
+
+	srcHash, err := src.hash()
+	if err != nil {
+		return "", err
+	}
+
+	dstHash, err := dst.hash()
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case srcHash == "" || dstHash == "":
+		if p.checksum {
+			return "", nil
+		}
+
+		return skipReasonSameSize, nil
+	case srcHash != dstHash:
+		return "", nil
+	default:
+		return skipReasonUnchanged, nil
+	}
+}
+
+// remoteTransferSide describes a remote item. A folder never matches a file's
+// size, so --update transfers over it and the transfer reports the conflict.
+func remoteTransferSide(item *graph.Item) transferSide {
+	if item == nil {
+		return transferSide{}
+	}
+
+	side := transferSide{
+		exists: true,
+		size:   item.Size,
+		mtime:  item.ModifiedAt,
+		hash:   func() (string, error) { return item.QuickXorHash, nil },
+	}
+
+	if item.IsFolder {
+		side.size = -1
+	}
+
+	return side
+}
+
+// localTransferSide stats a local destination or source file.
+func localTransferSide(path string) (transferSide, error) {
+	info, err := localpath.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return transferSide{}, nil
+	}
+
+	if err != nil {
+		return transferSide{}, fmt.Errorf("stating %s: %w", path, err)
+	}
+
+	side := transferSide{
+		exists: true,
+		size:   info.Size(),
+		mtime:  info.ModTime(),
+		hash: func() (string, error) {
+			h, hashErr := driveops.ComputeQuickXorHash(path)
+			if hashErr != nil {
+				return "", fmt.Errorf("hashing %s: %w", path, hashErr)
+			}
+
+			return h, nil
+		},
+	}
+
+	if info.IsDir() {
+		side.size = -1
+	}
+
+	return side, nil
+}
+
+// skipDownload reports why the remote item need not be downloaded to
+// localPath, or "" when it must be.
+func (p transferSkipPolicy) skipDownload(item *graph.Item, localPath string) (string, error) {
+	if !p.active() {
+		return "", nil
+	}
+
+	dst, err := localTransferSide(localPath)
+	if err != nil {
+		return "", err
+	}
+
+	return p.skipReason(remoteTransferSide(item), dst)
+}
+
+// skipUpload reports why localPath need not be uploaded over existing, or ""
+// when it must be. existing is nil when nothing is at the destination.
+func (p transferSkipPolicy) skipUpload(localPath string, existing *graph.Item) (string, error) {
+	if !p.active() || existing == nil {
+		return "", nil
+	}
+
+	src, err := localTransferSide(localPath)
+	if err != nil {
+		return "", err
+	}
+
+	return p.skipReason(src, remoteTransferSide(existing))
+}
+
+// remoteChildIndex caches folder listings for put's existence checks, keyed
+// by folder ID and then by lowercased name because OneDrive names are
+// case-insensitive.
+type remoteChildIndex map[string]map[string]*graph.Item
+
+func (idx remoteChildIndex) lookup(children func() ([]graph.Item, error), folderID, name string) (*graph.Item, error) {
+	byName, ok := idx[folderID]
+	if !ok {
+		items, err := children()
+		if err != nil {
+			return nil, err
+		}
+
+		byName = make(map[string]*graph.Item, len(items))
+		for i := range items {
+			byName[strings.ToLower(items[i].Name)] = &items[i]
+		}
+
+		idx[folderID] = byName
+	}
+
+	return byName[strings.ToLower(name)], nil
+}
@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+func constHash(h string) func() (string, error) {
+	return func() (string, error) { return h, nil }
+}
+
+// Validates: R-1.2.8, R-1.3.9
+func TestTransferSkipPolicy_SkipReason(t *testing.T) {
+	hashCalled := errors.New("hash should not be computed")
+	noHash := func() (string, error) { return "", hashCalled }
+
+	tests := []struct {
+		name   string
+		policy transferSkipPolicy
+		src    transferSide
+		dst    transferSide
+		want   string
+	}{
+		{"no policy transfers", transferSkipPolicy{}, transferSide{exists: true, size: 1, hash: noHash},
+			transferSide{exists: true, size: 1, hash: noHash}, ""},
+		{"missing destination transfers", transferSkipPolicy{update: true}, transferSide{exists: true, size: 1, hash: noHash},
+			transferSide{}, ""},
+		{"ignore-existing skips without comparing", transferSkipPolicy{ignoreExisting: true},
+			transferSide{exists: true, size: 1, hash: noHash}, transferSide{exists: true, size: 9, hash: noHash}, skipReasonExists},
+		{"update transfers different size without hashing", transferSkipPolicy{update: true},
+			transferSide{exists: true, size: 1, hash: noHash}, transferSide{exists: true, size: 2, hash: noHash}, ""},
+		{"update skips equal hash", transferSkipPolicy{update: true},
+			transferSide{exists: true, size: 1, hash: constHash("h")}, transferSide{exists: true, size: 1, hash: constHash("h")},
+			skipReasonUnchanged},
+		{"update transfers different hash", transferSkipPolicy{update: true},
+			transferSide{exists: true, size: 1, hash: constHash("h")}, transferSide{exists: true, size: 1, hash: constHash("x")}, ""},
+		{"update trusts size without a hash", transferSkipPolicy{update: true},
+			transferSide{exists: true, size: 1, hash: constHash("")}, transferSide{exists: true, size: 1, hash: constHash("h")},
+			skipReasonSameSize},
+		{"checksum transfers without a hash", transferSkipPolicy{update: true, checksum: true},
+			transferSide{exists: true, size: 1, hash: constHash("")}, transferSide{exists: true, size: 1, hash: constHash("h")}, ""},
+		{"size-only skips equal size without hashing", transferSkipPolicy{update: true, sizeOnly: true},
+			transferSide{exists: true, size: 1, hash: noHash}, transferSide{exists: true, size: 1, hash: noHash}, skipReasonSameSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.policy.skipReason(tt.src, tt.dst)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTransferSkipPolicy_HashErrorPropagates(t *testing.T) {
+	boom := errors.New("boom")
+	p := transferSkipPolicy{update: true}
+
+	_, err := p.skipReason(
+		transferSide{exists: true, size: 1, hash: func() (string, error) { return "", boom }},
+		transferSide{exists: true, size: 1, hash: constHash("h")},
+	)
+	require.ErrorIs(t, err, boom)
+}
+
+func TestRunGet_SkipFlagsAreMutuallyExclusive(t *testing.T) {
+	cmd := newGetCmd()
+	cmd.SetArgs([]string{"--ignore-existing", "--update", "/Photos"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	require.Error(t, cmd.Execute())
+}
+
+// newSkipGetTestContext serves a Photos folder holding a.jpg and b.jpg and
+// records which items were downloaded.
+func newSkipGetTestContext(t *testing.T, files map[string]string, downloaded *[]string, stdout, stderr *bytes.Buffer) *CLIContext {
+	t.Helper()
+
+	var mu sync.Mutex
+
+	return newFileCommandTestContext(
+		t,
+		driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id, ok := strings.CutPrefix(r.URL.Path, "/dl/"); ok {
+				mu.Lock()
+				*downloaded = append(*downloaded, id)
+				mu.Unlock()
+				writeTestResponse(t, w, files[id])
+
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+
+			if strings.HasSuffix(r.URL.Path, "/children") {
+				writeTestResponsef(t, w, `{"value":[
+					{"id":"a","name":"a.jpg","size":%d,"file":{"hashes":{"quickXorHash":%q}}},
+					{"id":"b","name":"b.jpg","size":%d,"file":{"hashes":{"quickXorHash":%q}}}
+				]}`, len(files["a"]), tarTestHash(files["a"]), len(files["b"]), tarTestHash(files["b"]))
+
+				return
+			}
+
+			if id, ok := strings.CutPrefix(r.URL.Path, "/drives/0000000drive-123/items/"); ok && files[id] != "" {
+				writeTestResponsef(t, w, `{"id":%q,"name":%q,"size":%d,"file":{"hashes":{"quickXorHash":%q}},
+					"@microsoft.graph.downloadUrl":"http://%s/dl/%s"}`, id, id, len(files[id]), tarTestHash(files[id]), r.Host, id)
+
+				return
+			}
+
+			writeTestResponse(t, w, `{"id":"photos","name":"Photos","folder":{},"parentReference":{"id":"root"}}`)
+		}),
+		stdout,
+		stderr,
+	)
+}
+
+// Validates: R-1.2.8
+func TestRunGet_UpdateSkipsUnchangedFiles(t *testing.T) {
+	files := map[string]string{"a": "aaaaa", "b": "bbbbb"}
+	local := filepath.Join(t.TempDir(), "Photos")
+	require.NoError(t, os.MkdirAll(local, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "a.jpg"), []byte("aaaaa"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "b.jpg"), []byte("XXXXX"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	var downloaded []string
+	cc := newSkipGetTestContext(t, files, &downloaded, &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newGetCmd()
+	cmd.SetArgs([]string{"--update", "/Photos", local})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, []string{"b"}, downloaded)
+
+	var out getFolderJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out.Files, 1)
+	assert.Equal(t, filepath.Join(local, "b.jpg"), out.Files[0].Path)
+	assert.Equal(t, []skippedJSONOutput{{Path: filepath.Join(local, "a.jpg"), Reason: skipReasonUnchanged}}, out.Skipped)
+
+	got, err := os.ReadFile(filepath.Join(local, "b.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, "bbbbb", string(got))
+}
+
+// Validates: R-1.2.8
+func TestRunGet_IgnoreExistingSkipsSingleFile(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "a.jpg")
+	require.NoError(t, os.WriteFile(target, []byte("old"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	var downloaded []string
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/dl/") {
+				downloaded = append(downloaded, r.URL.Path)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			writeTestResponsef(t, w, `{"id":"a","name":"a.jpg","size":5,"file":{},
+				"@microsoft.graph.downloadUrl":"http://%s/dl/a"}`, r.Host)
+		}), &stdout, &stderr)
+
+	cmd := newGetCmd()
+	cmd.SetArgs([]string{"--ignore-existing", "/Photos/a.jpg", target})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	assert.Empty(t, downloaded)
+	assert.Contains(t, stderr.String(), "Skipped "+target+" (exists)")
+}
+
+// putSkipTestServer creates folders by name, lists the Docs folder with one
+// file that matches local content, and records uploaded names.
+type putSkipTestServer struct {
+	mu       sync.Mutex
+	existing string
+	uploads  []string
+	listings int
+}
+
+func (s *putSkipTestServer) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/children"):
+			w.WriteHeader(http.StatusCreated)
+			writeTestResponse(t, w, `{"id":"docs","name":"Docs","folder":{}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/drives/0000000drive-123/items/docs/children":
+			s.listings++
+			writeTestResponsef(t, w, `{"value":[{"id":"same","name":"Same.txt","size":%d,"file":{"hashes":{"quickXorHash":%q}}}]}`,
+				len(s.existing), tarTestHash(s.existing))
+		case r.Method == http.MethodPut || r.Method == http.MethodPatch:
+			_, name, _ := strings.Cut(strings.TrimSuffix(r.URL.Path, ":/content"), ":/")
+			if r.Method == http.MethodPut {
+				s.uploads = append(s.uploads, name)
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			writeTestResponse(t, w, `{"id":"new","name":"new.txt","file":{"hashes":{"quickXorHash":"AAAAAAAAAAAAAAAAAAAAAAAAAAA="}}}`)
+		default:
+			writeTestResponse(t, w, `{"id":"root","name":"root","folder":{},"root":{}}`)
+		}
+	}
+}
+
+// Validates: R-1.3.9
+func TestRunPut_UpdateSkipsFilesAlreadyUploaded(t *testing.T) {
+	local := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(local, "same.txt"), []byte("same"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "new.txt"), []byte("new!"), 0o600))
+
+	srv := &putSkipTestServer{existing: "same"}
+
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"), srv.handler(t), &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	cmd := newPutCmd()
+	cmd.SetArgs([]string{"--update", local, "/Docs"})
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, []string{"new.txt"}, srv.uploads)
+	assert.Equal(t, 1, srv.listings, "the destination folder is listed once")
+
+	var out putFolderJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out.Files, 1)
+	assert.Equal(t, "Docs/new.txt", out.Files[0].Path)
+	assert.Equal(t, []skippedJSONOutput{{Path: "Docs/same.txt", Reason: skipReasonUnchanged}}, out.Skipped)
+}
+
+func TestRunPut_SkipFlagsRejectStdin(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cc := newFileCommandTestContext(t, driveid.MustCanonicalID("personal:user@example.com"),
+		http.NotFoundHandler(), &stdout, &stderr)
+
+	cmd := newPutCmd()
+	cmd.SetArgs([]string{"--update", "-", "/Backups/db.sql"})
+	cmd.SetIn(strings.NewReader("data"))
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "do not apply to stdin")
+}
@@ -0,0 +1,341 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/config"
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// transferTestServer fakes two drives behind one Graph endpoint: "src" holds
+// Photos/a.jpg and Photos/Trip/b.jpg, "dst" is empty. Failing download IDs
+// answer 403 so a run can be interrupted part-way.
+type transferTestServer struct {
+	mu       sync.Mutex
+	url      string
+	uploads  map[string]string
+	byID     map[string]string
+	mtimes   []string
+	failing  map[string]bool
+	contents map[string]string
+
+	denyUploadSessions bool
+}
+
+func (s *transferTestServer) handler(t *testing.T) http.Handler {
+	t.Helper()
+
+	const (
+		srcDrive = "/drives/0000000000000src"
+		dstDrive = "/drives/0000000000000dst"
+	)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Answered without the lock: a download holds it while it waits for
+		// the upload to read.
+		if s.denyUploadSessions && strings.HasSuffix(r.URL.Path, "/createUploadSession") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			writeTestResponse(t, w, `{"error":{"code":"accessDenied","message":"destination is read-only"}}`)
+
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == srcDrive+"/root:/Photos:":
+			writeTestResponse(t, w, `{"id":"photos","name":"Photos","folder":{},"parentReference":{"id":"root"}}`)
+		case r.URL.Path == srcDrive+"/items/photos/delta":
+			writeTestResponse(t, w, `{"value":[
+				{"id":"photos","name":"Photos","folder":{},"parentReference":{"id":"root"}},
+				{"id":"a","name":"a.jpg","size":5,"lastModifiedDateTime":"2020-01-02T03:04:05Z",
+				 "file":{"hashes":{"quickXorHash":"`+putStdinTestHash(s.contents["a"])+`"}},"parentReference":{"id":"photos"}},
+				{"id":"trip","name":"Trip","folder":{},"parentReference":{"id":"photos"}},
+				{"id":"b","name":"b.jpg","size":5,"lastModifiedDateTime":"2021-01-02T03:04:05Z",
+				 "file":{"hashes":{"quickXorHash":"`+putStdinTestHash(s.contents["b"])+`"}},"parentReference":{"id":"trip"}}
+			],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`)
+		case strings.HasPrefix(r.URL.Path, srcDrive+"/items/"):
+			id := strings.TrimPrefix(r.URL.Path, srcDrive+"/items/")
+			writeTestResponsef(t, w, `{"id":%q,"name":"x","size":5,"@microsoft.graph.downloadUrl":%q}`, id, s.url+"/dl/"+id)
+		case strings.HasPrefix(r.URL.Path, "/dl/"):
+			id := strings.TrimPrefix(r.URL.Path, "/dl/")
+			if s.failing[id] {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			writeTestResponse(t, w, s.contents[id])
+		case r.URL.Path == dstDrive+"/root:/Archive:":
+			w.WriteHeader(http.StatusNotFound)
+			writeTestResponse(t, w, `{"error":{"code":"itemNotFound"}}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/children"):
+			var body struct {
+				Name string `json:"name"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			w.WriteHeader(http.StatusCreated)
+			writeTestResponsef(t, w, `{"id":"dst-%s","name":%q,"folder":{}}`, body.Name, body.Name)
+		case r.Method == http.MethodPut:
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			id := fmt.Sprintf("up-%d", len(s.byID)+1)
+			s.uploads[r.URL.Path] = string(content)
+			s.byID[id] = string(content)
+			w.WriteHeader(http.StatusCreated)
+			writeTestResponsef(t, w, `{"id":%q,"name":"x","size":%d,"file":{"hashes":{"quickXorHash":%q}}}`,
+				id, len(content), putStdinTestHash(string(content)))
+		case r.Method == http.MethodPatch:
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			s.mtimes = append(s.mtimes, string(body))
+			id := strings.TrimPrefix(r.URL.Path, dstDrive+"/items/")
+			writeTestResponsef(t, w, `{"id":%q,"name":"x","file":{"hashes":{"quickXorHash":%q}}}`,
+				id, putStdinTestHash(s.byID[id]))
+		default:
+			assert.Failf(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	})
+}
+
+func newTransferTestContext(t *testing.T, server *transferTestServer, stdout, stderr *bytes.Buffer) *CLIContext {
+	t.Helper()
+
+	srcCID := driveid.MustCanonicalID("personal:alice@example.com")
+	dstCID := driveid.MustCanonicalID("business:alice@contoso.com")
+
+	cc := newFileCommandTestContext(t, srcCID, server.handler(t), stdout, stderr)
+	server.url = cc.Runtime.GraphBaseURL
+
+	cc.CfgPath = filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(cc.CfgPath, []byte(`
+["personal:alice@example.com"]
+display_name = "Home"
+
+["business:alice@contoso.com"]
+display_name = "Work"
+`), 0o600))
+
+	seedCatalogDrive(t, srcCID, func(d *config.CatalogDrive) { d.RemoteDriveID = "src" })
+	seedCatalogDrive(t, dstCID, func(d *config.CatalogDrive) { d.RemoteDriveID = "dst" })
+
+	return cc
+}
+
+func runTransferTestCommand(t *testing.T, cc *CLIContext, args ...string) error {
+	t.Helper()
+
+	cmd := newTransferCmd()
+	cmd.SetArgs(args)
+	cmd.SetContext(context.WithValue(t.Context(), cliContextKey{}, cc))
+
+	return cmd.Execute()
+}
+
+// Validates: R-1.21.1, R-1.21.2
+func TestRunTransfer_StreamsFolderTreeBetweenDrives(t *testing.T) {
+	server := &transferTestServer{
+		uploads:  map[string]string{},
+		byID:     map[string]string{},
+		contents: map[string]string{"a": "AAAAA", "b": "BBBBB"},
+	}
+
+	var stdout, stderr bytes.Buffer
+	cc := newTransferTestContext(t, server, &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	require.NoError(t, runTransferTestCommand(t, cc, "Home:/Photos", "Work:/Archive", "--recursive"))
+
+	assert.Equal(t, map[string]string{
+		"/drives/0000000000000dst/items/dst-Archive:/a.jpg:/content": "AAAAA",
+		"/drives/0000000000000dst/items/dst-Trip:/b.jpg:/content":    "BBBBB",
+	}, server.uploads)
+
+	require.Len(t, server.mtimes, 2)
+	assert.Contains(t, strings.Join(server.mtimes, "\n"), `"lastModifiedDateTime":"2020-01-02T03:04:05Z"`)
+	assert.Contains(t, strings.Join(server.mtimes, "\n"), `"lastModifiedDateTime":"2021-01-02T03:04:05Z"`)
+
+	var out transferJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, "Home:/Photos", out.Source)
+	assert.Equal(t, "Work:/Archive", out.Destination)
+	require.Len(t, out.Files, 2)
+	assert.Equal(t, "Trip/b.jpg", out.Files[0].Path)
+	assert.Equal(t, "a.jpg", out.Files[1].Path)
+	assert.Empty(t, out.Errors)
+
+	entries, err := os.ReadDir(transferJournalDir())
+	if err == nil {
+		assert.Empty(t, entries, "a finished transfer leaves no journal")
+	}
+}
+
+// Validates: R-1.21.3
+func TestRunTransfer_RerunResumesFromJournal(t *testing.T) {
+	server := &transferTestServer{
+		uploads:  map[string]string{},
+		byID:     map[string]string{},
+		contents: map[string]string{"a": "AAAAA", "b": "BBBBB"},
+		failing:  map[string]bool{"b": true},
+	}
+
+	var stdout, stderr bytes.Buffer
+	cc := newTransferTestContext(t, server, &stdout, &stderr)
+
+	err := runTransferTestCommand(t, cc, "Home:/Photos", "Work:/Archive", "-r")
+	require.ErrorContains(t, err, "1 of 2 transfers failed")
+	assert.Len(t, server.uploads, 1)
+
+	entries, err := os.ReadDir(transferJournalDir())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	server.failing = nil
+	server.uploads = map[string]string{}
+	stderr.Reset()
+
+	require.NoError(t, runTransferTestCommand(t, cc, "Home:/Photos", "Work:/Archive", "-r"))
+	assert.Equal(t, map[string]string{
+		"/drives/0000000000000dst/items/dst-Trip:/b.jpg:/content": "BBBBB",
+	}, server.uploads, "the file copied by the first run is not sent again")
+	assert.Contains(t, stderr.String(), "1 already done")
+
+	entries, err = os.ReadDir(transferJournalDir())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+// Validates: R-1.21.2
+func TestRunTransfer_ReportsUploadErrorWhenDestinationRefuses(t *testing.T) {
+	// Larger than one upload fragment, so the upload asks for a session
+	// while the download still has bytes to write.
+	big := strings.Repeat("A", graph.ChunkedUploadChunkSize+1)
+
+	server := &transferTestServer{
+		uploads:            map[string]string{},
+		byID:               map[string]string{},
+		contents:           map[string]string{"a": big, "b": "BBBBB"},
+		denyUploadSessions: true,
+	}
+
+	var stdout, stderr bytes.Buffer
+	cc := newTransferTestContext(t, server, &stdout, &stderr)
+	cc.Flags.JSON = true
+
+	err := runTransferTestCommand(t, cc, "Home:/Photos", "Work:/Archive", "-r")
+	require.ErrorContains(t, err, "1 of 2 transfers failed")
+
+	var out transferJSONOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out.Errors, 1)
+	assert.Contains(t, out.Errors[0], "a.jpg: uploading:")
+	assert.NotContains(t, out.Errors[0], "closed pipe")
+}
+
+// Validates: R-1.21.1
+func TestParseTransferEndpoint(t *testing.T) {
+	for _, tc := range []struct {
+		arg, selector, path string
+	}{
+		{"personal:me@example.com:/Docs/a.txt", "personal:me@example.com", "/Docs/a.txt"},
+		{"Work:/", "Work", "/"},
+		{"personal:me@example.com:", "personal:me@example.com", "/"},
+	} {
+		ep, err := parseTransferEndpoint(tc.arg)
+		require.NoError(t, err, tc.arg)
+		assert.Equal(t, tc.selector, ep.selector, tc.arg)
+		assert.Equal(t, tc.path, ep.path, tc.arg)
+	}
+
+	for _, arg := range []string{"/Docs", ":/Docs", "Work"} {
+		_, err := parseTransferEndpoint(arg)
+		require.Error(t, err, arg)
+	}
+}
+
+// Validates: R-1.21.3
+func TestTransferJournal_AppendsRecordsAndCompactsOnLoad(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	journal, err := loadTransferJournal("src:/Photos", "dst:/Archive")
+	require.NoError(t, err)
+
+	a := driveops.RemoteTreeEntry{Path: "a.jpg", Item: graph.Item{ID: "a", Size: 5, QuickXorHash: "hash-a"}}
+	b := driveops.RemoteTreeEntry{Path: "b.jpg", Item: graph.Item{ID: "b", Size: 5, CTag: "ctag-b"}}
+	require.NoError(t, journal.record(&a, "dst-a"))
+	require.NoError(t, journal.record(&b, "dst-b"))
+	require.NoError(t, journal.record(&b, "dst-b2"))
+	require.NoError(t, journal.close())
+
+	path := filepath.Join(transferJournalDir(), journal.name)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 4, "a header, then one line per record")
+
+	// A line cut off by a crash is dropped on load.
+	require.NoError(t, os.WriteFile(path, append(data, `{"path":"c.jp`...), 0o600))
+
+	reloaded, err := loadTransferJournal("src:/Photos", "dst:/Archive")
+	require.NoError(t, err)
+	assert.True(t, reloaded.done(&a))
+	assert.True(t, reloaded.done(&b))
+	assert.Equal(t, "dst-b2", reloaded.files["b.jpg"].DestinationID, "the last record for a path wins")
+
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 3, "loading compacts to one line per file")
+
+	c := driveops.RemoteTreeEntry{Path: "c.jpg", Item: graph.Item{ID: "c", Size: 1, QuickXorHash: "hash-c"}}
+	require.NoError(t, reloaded.record(&c, "dst-c"))
+	require.NoError(t, reloaded.remove())
+
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// Validates: R-1.21.3
+func TestTransferJournal_DoneFallsBackToTagsWithoutHash(t *testing.T) {
+	journal := &transferJournal{files: map[string]transferJournalEntry{
+		"hash.jpg": {SourceID: "h", Size: 5, QuickXorHash: "hash"},
+		"ctag.jpg": {SourceID: "c", Size: 5, CTag: "ctag-1", ETag: "etag-1"},
+		"etag.jpg": {SourceID: "e", Size: 5, ETag: "etag-1"},
+		"none.jpg": {SourceID: "n", Size: 5},
+	}}
+
+	for _, tc := range []struct {
+		item graph.Item
+		path string
+		want bool
+	}{
+		{path: "hash.jpg", item: graph.Item{ID: "h", Size: 5, QuickXorHash: "hash"}, want: true},
+		{path: "hash.jpg", item: graph.Item{ID: "h", Size: 5, QuickXorHash: "other"}},
+		{path: "ctag.jpg", item: graph.Item{ID: "c", Size: 5, CTag: "ctag-1", ETag: "etag-2"}, want: true},
+		{path: "ctag.jpg", item: graph.Item{ID: "c", Size: 5, CTag: "ctag-2", ETag: "etag-1"}},
+		{path: "ctag.jpg", item: graph.Item{ID: "c", Size: 6, CTag: "ctag-1"}},
+		{path: "etag.jpg", item: graph.Item{ID: "e", Size: 5, ETag: "etag-1"}, want: true},
+		{path: "etag.jpg", item: graph.Item{ID: "e", Size: 5, ETag: "etag-2"}},
+		{path: "none.jpg", item: graph.Item{ID: "n", Size: 5}},
+	} {
+		entry := driveops.RemoteTreeEntry{Path: tc.path, Item: tc.item}
+		assert.Equal(t, tc.want, journal.done(&entry), "%s %+v", tc.path, tc.item)
+	}
+}
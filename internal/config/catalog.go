@@ -38,6 +38,7 @@ type CatalogAccount struct {
 	PrimaryDriveID        string           `json:"primary_drive_id,omitempty"`
 	PrimaryDriveCanonical string           `json:"primary_drive_canonical_id,omitempty"`
 	AuthRequirementReason authstate.Reason `json:"auth_requirement_reason,omitempty"`
+	LoginScope            string           `json:"login_scope,omitempty"` // empty = full read/write access
 }
 
 type CatalogDrive struct {
@@ -54,6 +55,7 @@ type CatalogDrive struct {
 	RetainedStatePresent  bool   `json:"retained_state_present,omitempty"`
 	RemoteDriveID         string `json:"remote_drive_id,omitempty"`
 	SiteID                string `json:"site_id,omitempty"`
+	RootItemID            string `json:"root_item_id,omitempty"` // app folder root after login --app-folder
 }
 
 func DefaultCatalog() *Catalog {
@@ -11,9 +11,25 @@ import (
 	"github.com/tonimelisma/onedrive-go/internal/driveid"
 )
 
+// Login scopes recorded on a catalog account. The empty scope is full
+// read/write access.
+const (
+	LoginScopeReadOnly  = "read_only"
+	LoginScopeAppFolder = "app_folder"
+)
+
+// LoginAccess is the file access a login was granted. The zero value is full
+// read/write access to the whole drive.
+type LoginAccess struct {
+	Scope      string // "", LoginScopeReadOnly or LoginScopeAppFolder
+	RootItemID string // app folder item ID under LoginScopeAppFolder
+}
+
 // RecordLogin persists the durable account and primary-drive inventory facts
 // discovered during login. CLI owns the Graph flow; config owns how those
-// durable facts are represented in the catalog.
+// durable facts are represented in the catalog. Each login replaces the
+// recorded access, so a plain login after a restricted one restores full
+// access.
 func RecordLogin(
 	dataDir string,
 	accountCID driveid.CanonicalID,
@@ -21,6 +37,7 @@ func RecordLogin(
 	displayName string,
 	orgName string,
 	primaryDriveID driveid.ID,
+	access LoginAccess,
 ) error {
 	if accountCID.IsZero() {
 		return nil
@@ -36,6 +53,7 @@ func RecordLogin(
 			OrgName:               orgName,
 			PrimaryDriveID:        primaryDriveID.String(),
 			PrimaryDriveCanonical: accountCID.String(),
+			LoginScope:            access.Scope,
 		}
 		if existing, found := catalog.AccountByCanonicalID(accountCID); found {
 			account.AuthRequirementReason = existing.AuthRequirementReason
@@ -61,6 +79,7 @@ func RecordLogin(
 				drive.DisplayName = DefaultDisplayName(accountCID)
 			}
 		}
+		drive.RootItemID = access.RootItemID
 
 		catalog.UpsertAccount(&account)
 		catalog.UpsertDrive(&drive)
@@ -35,6 +35,7 @@ func TestRecordLogin_UpsertsAccountAndPrimaryDrive(t *testing.T) {
 		catalogLifecycleDisplayName,
 		catalogLifecycleOrgName,
 		driveid.New(catalogLifecyclePrimaryID),
+		LoginAccess{},
 	))
 
 	account, found := loadCatalogAccount(t, accountCID)
@@ -27,6 +27,7 @@ type ResolvedDrive struct {
 	DriveID                driveid.ID
 	RemoteRootItemID       string // configured remote root item for standalone mount-root drives; empty = drive root
 	RemoteRootDeltaCapable bool   // true when folder delta is supported for the configured remote root
+	ReadOnly               bool   // owning account signed in with login --read-only
 
 	TransfersConfig
 	SafetyConfig
@@ -200,10 +201,17 @@ func buildResolvedDrive(cfg *Config, canonicalID driveid.CanonicalID, drive *Dri
 		)
 	}
 
-	if canonicalID.IsShared() {
+	// login --app-folder roots the primary drive at its app folder.
+	if drive, found := catalogDriveRecord(catalog, canonicalID); found && drive.RootItemID != "" && !canonicalID.IsShared() {
+		resolved.RemoteRootItemID = drive.RootItemID
+	}
+
+	if resolved.RemoteRootItemID != "" {
 		resolved.RemoteRootDeltaCapable = remoteRootDeltaCapable(canonicalID, catalog, logger)
 	}
 
+	resolved.ReadOnly = accountLoginScope(catalog, canonicalID) == LoginScopeReadOnly
+
 	// Compute runtime default sync_dir when the drive has none configured.
 	if resolved.SyncDir == "" {
 		orgName, displayName := ResolveAccountNames(canonicalID, logger)
@@ -238,6 +246,24 @@ func catalogDriveRecord(catalog *Catalog, canonicalID driveid.CanonicalID) (Cata
 	return catalog.DriveByCanonicalID(canonicalID)
 }
 
+// accountLoginScope returns the login scope recorded on the account that owns
+// the drive's token.
+func accountLoginScope(catalog *Catalog, canonicalID driveid.CanonicalID) string {
+	ownerCID := accountCIDForDrive(canonicalID)
+	if drive, found := catalogDriveRecord(catalog, canonicalID); found && drive.OwnerAccountCanonical != "" {
+		if parsed, err := driveid.NewCanonicalID(drive.OwnerAccountCanonical); err == nil {
+			ownerCID = parsed
+		}
+	}
+
+	account, found := catalog.AccountByCanonicalID(ownerCID)
+	if !found {
+		return ""
+	}
+
+	return account.LoginScope
+}
+
 func remoteRootDeltaCapable(
 	canonicalID driveid.CanonicalID,
 	catalog *Catalog,
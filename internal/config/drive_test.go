@@ -248,6 +248,38 @@ func TestBuildResolvedDrive_SharedCatalogDrivePreservesRootItem(t *testing.T) {
 	assert.True(t, resolved.RemoteRootDeltaCapable)
 }
 
+// Validates: R-3.1.9, R-3.1.10
+func TestBuildResolvedDrive_LoginAccessFromCatalog(t *testing.T) {
+	dataDir := setTestDataDir(t)
+	personalCID := driveid.MustCanonicalID("personal:kiosk@example.com")
+	businessCID := driveid.MustCanonicalID("business:ci@example.com")
+	sharePointCID := driveid.MustCanonicalID("sharepoint:ci@example.com:site-1:Docs")
+
+	require.NoError(t, RecordLogin(dataDir, personalCID, "u1", "Kiosk", "", driveid.New("d1"),
+		LoginAccess{Scope: LoginScopeAppFolder, RootItemID: "approot-1"}))
+	require.NoError(t, RecordLogin(dataDir, businessCID, "u2", "CI", "Contoso", driveid.New("d2"),
+		LoginAccess{Scope: LoginScopeReadOnly}))
+	require.NoError(t, RegisterDrive(dataDir, sharePointCID, "Docs"))
+
+	cfg := DefaultConfig()
+
+	appFolder := buildResolvedDrive(cfg, personalCID, &Drive{SyncDir: "~/Kiosk"}, testLogger(t))
+	assert.Equal(t, "approot-1", appFolder.RemoteRootItemID)
+	assert.True(t, appFolder.RemoteRootDeltaCapable)
+	assert.False(t, appFolder.ReadOnly)
+
+	readOnly := buildResolvedDrive(cfg, businessCID, &Drive{SyncDir: "~/CI"}, testLogger(t))
+	assert.Empty(t, readOnly.RemoteRootItemID)
+	assert.True(t, readOnly.ReadOnly)
+
+	library := buildResolvedDrive(cfg, sharePointCID, &Drive{SyncDir: "~/Docs"}, testLogger(t))
+	assert.True(t, library.ReadOnly, "drives share the access of the account that owns their token")
+
+	require.NoError(t, RecordLogin(dataDir, personalCID, "u1", "Kiosk", "", driveid.New("d1"), LoginAccess{}))
+	appFolder = buildResolvedDrive(cfg, personalCID, &Drive{SyncDir: "~/Kiosk"}, testLogger(t))
+	assert.Empty(t, appFolder.RemoteRootItemID, "a plain login restores the whole drive")
+}
+
 func assertRemoteRootDeltaCapabilityForOwner(t *testing.T, ownerCanonical string, expected bool) {
 	t.Helper()
 
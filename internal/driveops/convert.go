@@ -0,0 +1,28 @@
+package driveops
+
+import (
+	"context"
+	"io"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// convertingDownloader adapts a ConvertDownloader to Downloader for one
+// target format. It deliberately does not implement RangeDownloader:
+// converted output is produced per request, so a .partial from an earlier
+// attempt cannot be resumed and segmenting does not apply.
+type convertingDownloader struct {
+	client ConvertDownloader
+	format string
+}
+
+// NewConvertingDownloader returns a Downloader that fetches every item
+// converted to format, for use with TransferManager.DownloadToFile and
+// DownloadOpts.Converted.
+func NewConvertingDownloader(client ConvertDownloader, format string) Downloader {
+	return &convertingDownloader{client: client, format: format}
+}
+
+func (d *convertingDownloader) Download(ctx context.Context, driveID driveid.ID, itemID string, w io.Writer) (int64, error) {
+	return d.client.DownloadConverted(ctx, driveID, itemID, d.format, w)
+}
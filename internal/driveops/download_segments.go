@@ -0,0 +1,320 @@
+package driveops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+	"github.com/tonimelisma/onedrive-go/internal/localpath"
+)
+
+// Segmented downloads split one large file into fixed-size byte ranges that
+// are fetched concurrently and written at their own offsets into a .partial
+// file preallocated to the full size. A sidecar state file records which
+// segments are complete, so an interrupted download fetches only the rest.
+const (
+	defaultDownloadSegmentSize    = 64 << 20
+	defaultDownloadSegmentWorkers = 4
+
+	// downloadSegmentStateMarker turns a partial name into its sidecar name.
+	// OneDrive rejects ':' in item names, so the sidecar can never collide
+	// with the partial of a real remote file.
+	downloadSegmentStateMarker = ":segments"
+	downloadSegmentStateTemp   = downloadPartialPrefix + "*" + downloadPartialSuffix
+	currentSegmentStateVersion = 1
+	downloadSegmentMinSegments = 2
+)
+
+// remoteContent is what the caller knows about the file being downloaded.
+// The zero value (unknown size) always selects a single-stream download.
+type remoteContent struct {
+	Size int64
+	Hash string
+}
+
+// segmentState is the persisted progress of one segmented download. It is
+// only trusted when every identifying field matches the current request.
+type segmentState struct {
+	Version     int    `json:"version"`
+	ItemID      string `json:"item_id"`
+	RemoteHash  string `json:"remote_hash,omitempty"`
+	Size        int64  `json:"size"`
+	SegmentSize int64  `json:"segment_size"`
+	Done        []bool `json:"done"`
+}
+
+// WithSegmentedDownloads configures parallel multi-range downloads. Files of
+// at least two segments are fetched by up to workers concurrent Range
+// requests of segmentSize bytes each. workers <= 1 disables segmenting.
+func WithSegmentedDownloads(workers int, segmentSize int64) Option {
+	return func(tm *TransferManager) {
+		tm.segmentWorkers = workers
+		tm.segmentSize = segmentSize
+	}
+}
+
+// segmentedDownloader returns the range reader for remote when the file is
+// large enough to be split, or nil when it should be streamed whole.
+func (tm *TransferManager) segmentedDownloader(remote remoteContent) ByteRangeDownloader {
+	if tm.segmentWorkers <= 1 || tm.segmentSize <= 0 || remote.Size < downloadSegmentMinSegments*tm.segmentSize {
+		return nil
+	}
+
+	rd, ok := tm.downloads.(ByteRangeDownloader)
+	if !ok {
+		return nil
+	}
+
+	return rd
+}
+
+func segmentStatePath(partialPath string) string {
+	return strings.TrimSuffix(partialPath, downloadPartialSuffix) + downloadSegmentStateMarker + downloadPartialSuffix
+}
+
+// segmentedDownload fills partialPath from concurrent byte-range reads and
+// hashes the finished file from byte 0. On failure the partial and its state
+// are kept whatever the cause: completed segments are only marked done after
+// their bytes were written, and the whole-file hash check still guards
+// anything a crash left behind.
+func (tm *TransferManager) segmentedDownload(
+	ctx context.Context, rd ByteRangeDownloader, driveID driveid.ID, itemID, partialPath string, remote remoteContent,
+) (string, int64, error) {
+	statePath := segmentStatePath(partialPath)
+
+	f, state, err := tm.openSegmentedPartial(itemID, partialPath, statePath, remote)
+	if err != nil {
+		return "", 0, err
+	}
+
+	fetchErr := tm.fetchSegments(ctx, rd, driveID, itemID, filepath.Base(partialPath), f, state, statePath)
+
+	if closeErr := f.Close(); closeErr != nil {
+		tm.discardSegmentedPartial(partialPath)
+
+		return "", 0, errors.Join(fetchErr, fmt.Errorf("closing partial file %s: %w", partialPath, closeErr))
+	}
+
+	if fetchErr != nil {
+		return "", 0, fmt.Errorf("downloading to %s: %w", partialPath, fetchErr)
+	}
+
+	localHash, err := tm.hashFunc(partialPath)
+	if err != nil {
+		return "", 0, fmt.Errorf("hashing segmented partial file %s: %w", partialPath, err)
+	}
+
+	if removeErr := localpath.Remove(statePath); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
+		tm.logger.Warn("failed to remove segment state",
+			slog.String("path", statePath), slog.String("error", removeErr.Error()))
+	}
+
+	return localHash, remote.Size, nil
+}
+
+// openSegmentedPartial resumes from a matching state file and partial, or
+// starts over with a partial truncated to the full size and fresh state.
+func (tm *TransferManager) openSegmentedPartial(
+	itemID, partialPath, statePath string, remote remoteContent,
+) (*os.File, *segmentState, error) {
+	if state := tm.loadSegmentState(statePath, itemID, remote, tm.segmentSize); state != nil {
+		f, err := localpath.OpenFile(partialPath, os.O_WRONLY, downloadTempFilePerms)
+		if err == nil {
+			info, statErr := f.Stat()
+			if statErr == nil && info.Size() == remote.Size {
+				tm.logger.Debug("resuming segmented download",
+					slog.String("path", partialPath),
+					slog.Int("segments_done", state.doneCount()),
+					slog.Int("segments", len(state.Done)),
+				)
+
+				return f, state, nil
+			}
+
+			if closeErr := f.Close(); closeErr != nil {
+				tm.logger.Warn("failed to close partial file before fresh download",
+					slog.String("path", partialPath), slog.String("error", closeErr.Error()))
+			}
+		}
+	}
+
+	f, err := localpath.OpenFile(partialPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, downloadTempFilePerms)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating partial file %s: %w", partialPath, err)
+	}
+
+	if err := f.Truncate(remote.Size); err != nil {
+		if closeErr := f.Close(); closeErr != nil {
+			tm.logger.Warn("failed to close partial file after preallocation error",
+				slog.String("path", partialPath), slog.String("error", closeErr.Error()))
+		}
+
+		if removeErr := localpath.Remove(partialPath); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
+			tm.logger.Warn("failed to remove partial file after preallocation error",
+				slog.String("path", partialPath), slog.String("error", removeErr.Error()))
+		}
+
+		return nil, nil, fmt.Errorf("preallocating partial file %s: %w", partialPath, err)
+	}
+
+	count := (remote.Size + tm.segmentSize - 1) / tm.segmentSize
+	state := &segmentState{
+		Version:     currentSegmentStateVersion,
+		ItemID:      itemID,
+		RemoteHash:  remote.Hash,
+		Size:        remote.Size,
+		SegmentSize: tm.segmentSize,
+		Done:        make([]bool, count),
+	}
+
+	if err := writeSegmentState(statePath, state); err != nil {
+		tm.logger.Warn("failed to persist segment state",
+			slog.String("path", statePath), slog.String("error", err.Error()))
+	}
+
+	return f, state, nil
+}
+
+// fetchSegments downloads every segment not yet done. Each segment reuses
+// StreamContent, so a transient mid-segment failure resumes from the next
+// unwritten byte of that segment before the whole download gives up.
+func (tm *TransferManager) fetchSegments(
+	ctx context.Context, rd ByteRangeDownloader, driveID driveid.ID, itemID, name string,
+	f *os.File, state *segmentState, statePath string,
+) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(tm.segmentWorkers)
+
+	var mu sync.Mutex
+
+	for i := range state.Done {
+		if state.Done[i] {
+			continue
+		}
+
+		start := int64(i) * state.SegmentSize
+		end := min(start+state.SegmentSize, state.Size) - 1
+
+		g.Go(func() error {
+			result, err := StreamContent(gctx, rd, StreamRequest{
+				DriveID: driveID,
+				Item:    &graph.Item{ID: itemID, Name: name, Size: state.Size},
+				Start:   start,
+				End:     end,
+			}, io.NewOffsetWriter(f, start), tm.sleep, tm.logger)
+			if err != nil {
+				return fmt.Errorf("segment %d: %w", i, err)
+			}
+
+			// A short segment means the remote content is smaller than the
+			// caller believed; nothing later would notice the zero-filled gap.
+			if want := end - start + 1; result.Bytes != want {
+				return fmt.Errorf("segment %d: got %d of %d bytes", i, result.Bytes, want)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			state.Done[i] = true
+			if writeErr := writeSegmentState(statePath, state); writeErr != nil {
+				tm.logger.Warn("failed to persist segment state",
+					slog.String("path", statePath), slog.String("error", writeErr.Error()))
+			}
+
+			return nil
+		})
+	}
+
+	return g.Wait() //nolint:wrapcheck // segment errors are wrapped where they are created
+}
+
+// loadSegmentState returns the saved state for statePath when it describes
+// exactly this download, or nil when it is missing, unreadable, or stale.
+func (tm *TransferManager) loadSegmentState(
+	statePath, itemID string, remote remoteContent, segmentSize int64,
+) *segmentState {
+	data, err := localpath.ReadFile(statePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			tm.logger.Warn("cannot read segment state, starting fresh",
+				slog.String("path", statePath), slog.String("error", err.Error()))
+		}
+
+		return nil
+	}
+
+	var state segmentState
+	if err := json.Unmarshal(data, &state); err != nil {
+		tm.logger.Warn("corrupt segment state, starting fresh",
+			slog.String("path", statePath), slog.String("error", err.Error()))
+
+		return nil
+	}
+
+	count := (remote.Size + segmentSize - 1) / segmentSize
+	if state.Version != currentSegmentStateVersion || state.ItemID != itemID || state.RemoteHash != remote.Hash ||
+		state.Size != remote.Size || state.SegmentSize != segmentSize || int64(len(state.Done)) != count {
+		tm.logger.Debug("segment state does not match download, starting fresh", slog.String("path", statePath))
+
+		return nil
+	}
+
+	return &state
+}
+
+func writeSegmentState(statePath string, state *segmentState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshaling segment state: %w", err)
+	}
+
+	if err := localpath.AtomicWrite(statePath, data, downloadTempFilePerms, downloadTempDirPerms, downloadSegmentStateTemp); err != nil {
+		return fmt.Errorf("writing segment state: %w", err)
+	}
+
+	return nil
+}
+
+// discardSegmentedPartial removes a segment state file together with the
+// partial it describes. A preallocated partial is full-size from the start,
+// so appending to it as a single-stream resume would corrupt the file.
+func (tm *TransferManager) discardSegmentedPartial(partialPath string) {
+	statePath := segmentStatePath(partialPath)
+
+	if err := localpath.Remove(statePath); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			tm.logger.Warn("failed to remove segment state",
+				slog.String("path", statePath), slog.String("error", err.Error()))
+		}
+
+		return
+	}
+
+	if err := localpath.Remove(partialPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		tm.logger.Warn("failed to remove segmented partial file",
+			slog.String("path", partialPath), slog.String("error", err.Error()))
+	}
+}
+
+func (s *segmentState) doneCount() int {
+	n := 0
+
+	for _, done := range s.Done {
+		if done {
+			n++
+		}
+	}
+
+	return n
+}
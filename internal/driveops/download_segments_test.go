@@ -0,0 +1,193 @@
+package driveops
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// segmentDownloader serves whole-file and byte-range reads from memory.
+// Range reads return rangeContent when set, and a read starting at a byte
+// listed in failAt fails with a terminal 403 before writing anything.
+type segmentDownloader struct {
+	mu            sync.Mutex
+	content       []byte
+	rangeContent  []byte
+	failAt        map[int64]bool
+	ranges        []string
+	downloadCalls int
+}
+
+func (d *segmentDownloader) Download(_ context.Context, _ driveid.ID, _ string, w io.Writer) (int64, error) {
+	d.mu.Lock()
+	d.downloadCalls++
+	d.mu.Unlock()
+
+	n, err := w.Write(d.content)
+
+	return int64(n), err
+}
+
+func (d *segmentDownloader) DownloadByteRange(
+	_ context.Context, _ driveid.ID, _ string, w io.Writer, start, end int64,
+) (int64, error) {
+	d.mu.Lock()
+	d.ranges = append(d.ranges, fmt.Sprintf("%d-%d", start, end))
+	fail := d.failAt[start]
+	d.mu.Unlock()
+
+	if fail {
+		return 0, &graph.GraphError{StatusCode: http.StatusForbidden, Err: graph.ErrForbidden}
+	}
+
+	src := d.content
+	if d.rangeContent != nil {
+		src = d.rangeContent
+	}
+
+	n, err := w.Write(src[start : end+1])
+
+	return int64(n), err
+}
+
+func (d *segmentDownloader) sortedRanges() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	out := append([]string(nil), d.ranges...)
+	sort.Strings(out)
+
+	return out
+}
+
+func newSegmentTestTM(t *testing.T, dl Downloader) *TransferManager {
+	t.Helper()
+
+	return NewTransferManager(dl, nil, nil, testLogger(t), WithSegmentedDownloads(3, 4))
+}
+
+// Validates: R-1.2.7
+func TestDownloadToFile_SegmentsLargeFile(t *testing.T) {
+	content := []byte("0123456789")
+	dl := &segmentDownloader{content: content}
+	tm := newSegmentTestTM(t, dl)
+	target := filepath.Join(t.TempDir(), "big.bin")
+
+	result, err := tm.DownloadToFile(t.Context(), driveid.New("d"), "item", target, DownloadOpts{
+		RemoteHash: tmHashBytes(content),
+		RemoteSize: int64(len(content)),
+	})
+	require.NoError(t, err)
+	assert.True(t, result.HashVerified)
+	assert.Equal(t, int64(len(content)), result.Size)
+	assert.Equal(t, []string{"0-3", "4-7", "8-9"}, dl.sortedRanges())
+	assert.Zero(t, dl.downloadCalls)
+
+	got, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	assert.NoFileExists(t, downloadPartialPath(target))
+	assert.NoFileExists(t, segmentStatePath(downloadPartialPath(target)))
+}
+
+// Validates: R-1.2.7
+func TestDownloadToFile_SegmentedResumeFetchesOnlyMissingSegments(t *testing.T) {
+	content := []byte("0123456789")
+	dl := &segmentDownloader{content: content, failAt: map[int64]bool{4: true}}
+	tm := newSegmentTestTM(t, dl)
+	target := filepath.Join(t.TempDir(), "big.bin")
+	opts := DownloadOpts{RemoteHash: tmHashBytes(content), RemoteSize: int64(len(content))}
+
+	_, err := tm.DownloadToFile(t.Context(), driveid.New("d"), "item", target, opts)
+	require.Error(t, err)
+
+	partial := downloadPartialPath(target)
+	info, statErr := os.Stat(partial)
+	require.NoError(t, statErr, "partial should survive a failed segment")
+	assert.Equal(t, int64(len(content)), info.Size(), "partial is preallocated to the full size")
+	assert.FileExists(t, segmentStatePath(partial))
+
+	dl.mu.Lock()
+	dl.failAt = nil
+	dl.ranges = nil
+	dl.mu.Unlock()
+
+	result, err := tm.DownloadToFile(t.Context(), driveid.New("d"), "item", target, opts)
+	require.NoError(t, err)
+	assert.True(t, result.HashVerified)
+	assert.Equal(t, []string{"4-7"}, dl.sortedRanges())
+
+	got, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+}
+
+// Validates: R-1.2.7
+func TestDownloadToFile_SegmentedStateForOtherItemIsDiscarded(t *testing.T) {
+	content := []byte("0123456789")
+	dl := &segmentDownloader{content: content, failAt: map[int64]bool{4: true}}
+	tm := newSegmentTestTM(t, dl)
+	target := filepath.Join(t.TempDir(), "big.bin")
+	opts := DownloadOpts{RemoteHash: tmHashBytes(content), RemoteSize: int64(len(content))}
+
+	_, err := tm.DownloadToFile(t.Context(), driveid.New("d"), "old-item", target, opts)
+	require.Error(t, err)
+
+	dl.mu.Lock()
+	dl.failAt = nil
+	dl.ranges = nil
+	dl.mu.Unlock()
+
+	_, err = tm.DownloadToFile(t.Context(), driveid.New("d"), "new-item", target, opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0-3", "4-7", "8-9"}, dl.sortedRanges())
+}
+
+// Validates: R-1.2.7
+func TestDownloadToFile_SegmentedHashMismatchRetriesSingleStream(t *testing.T) {
+	content := []byte("0123456789")
+	dl := &segmentDownloader{content: content, rangeContent: []byte("xxxxxxxxxx")}
+	tm := newSegmentTestTM(t, dl)
+	target := filepath.Join(t.TempDir(), "big.bin")
+
+	result, err := tm.DownloadToFile(t.Context(), driveid.New("d"), "item", target, DownloadOpts{
+		RemoteHash: tmHashBytes(content),
+		RemoteSize: int64(len(content)),
+	})
+	require.NoError(t, err)
+	assert.True(t, result.HashVerified)
+	assert.Equal(t, 1, dl.downloadCalls)
+
+	got, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+	assert.NoFileExists(t, segmentStatePath(downloadPartialPath(target)))
+}
+
+func TestDownloadToFile_SmallFileUsesSingleStream(t *testing.T) {
+	content := []byte("0123456")
+	dl := &segmentDownloader{content: content}
+	tm := newSegmentTestTM(t, dl)
+	target := filepath.Join(t.TempDir(), "small.bin")
+
+	_, err := tm.DownloadToFile(t.Context(), driveid.New("d"), "item", target, DownloadOpts{
+		RemoteHash: tmHashBytes(content),
+		RemoteSize: int64(len(content)),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, dl.sortedRanges())
+	assert.Equal(t, 1, dl.downloadCalls)
+}
@@ -0,0 +1,145 @@
+package driveops
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DuplicateCandidate is one enumerated item offered for duplicate detection.
+// Drive is an opaque caller label (e.g. a canonical drive ID) so copies can
+// be grouped across drives and later acted on through the right session.
+type DuplicateCandidate struct {
+	Drive string
+	Entry RemoteTreeEntry
+}
+
+// DuplicateGroup is a set of two or more files with identical size and
+// content hash. Copies are ordered by drive, then path.
+type DuplicateGroup struct {
+	Hash   string // "<algorithm>:<value>", e.g. "quickXorHash:AAAA..."
+	Size   int64
+	Copies []DuplicateCandidate
+}
+
+// WastedBytes is the space that would be freed by keeping a single copy.
+func (g *DuplicateGroup) WastedBytes() int64 {
+	return g.Size * int64(len(g.Copies)-1)
+}
+
+// KeepRule selects which copy of a duplicate group survives a cleanup.
+type KeepRule string
+
+const (
+	KeepOldest       KeepRule = "oldest"
+	KeepNewest       KeepRule = "newest"
+	KeepShortestPath KeepRule = "shortest-path"
+)
+
+// ParseKeepRule validates a --keep value.
+func ParseKeepRule(raw string) (KeepRule, error) {
+	switch rule := KeepRule(raw); rule {
+	case KeepOldest, KeepNewest, KeepShortestPath:
+		return rule, nil
+	default:
+		return "", fmt.Errorf("invalid keep rule %q: must be %s, %s, or %s", raw, KeepOldest, KeepNewest, KeepShortestPath)
+	}
+}
+
+// FindDuplicates groups files by size and content hash without downloading
+// anything. Folders, files smaller than minSize, empty files, and files
+// without any hash are ignored. QuickXorHash is preferred because every
+// drive type reports it; SHA256 and SHA1 are fallbacks. Groups are ordered
+// by wasted bytes, largest first.
+func FindDuplicates(candidates []DuplicateCandidate, minSize int64) []DuplicateGroup {
+	byKey := make(map[string]*DuplicateGroup)
+
+	for i := range candidates {
+		item := &candidates[i].Entry.Item
+		if item.IsFolder || item.RemoteItemID != "" || item.Size == 0 || item.Size < minSize {
+			continue
+		}
+
+		hash := duplicateHash(candidates[i].Entry)
+		if hash == "" {
+			continue
+		}
+
+		key := fmt.Sprintf("%d/%s", item.Size, hash)
+		group, ok := byKey[key]
+		if !ok {
+			group = &DuplicateGroup{Hash: hash, Size: item.Size}
+			byKey[key] = group
+		}
+
+		group.Copies = append(group.Copies, candidates[i])
+	}
+
+	groups := make([]DuplicateGroup, 0)
+	for _, group := range byKey {
+		if len(group.Copies) < 2 {
+			continue
+		}
+
+		slices.SortFunc(group.Copies, func(a, b DuplicateCandidate) int {
+			return cmp.Or(strings.Compare(a.Drive, b.Drive), strings.Compare(a.Entry.Path, b.Entry.Path))
+		})
+		groups = append(groups, *group)
+	}
+
+	slices.SortFunc(groups, func(a, b DuplicateGroup) int {
+		return cmp.Or(cmp.Compare(b.WastedBytes(), a.WastedBytes()), strings.Compare(a.Hash, b.Hash))
+	})
+
+	return groups
+}
+
+func duplicateHash(entry RemoteTreeEntry) string {
+	switch {
+	case entry.Item.QuickXorHash != "":
+		return "quickXorHash:" + entry.Item.QuickXorHash
+	case entry.Item.SHA256Hash != "":
+		return "sha256:" + strings.ToLower(entry.Item.SHA256Hash)
+	case entry.Item.SHA1Hash != "":
+		return "sha1:" + strings.ToLower(entry.Item.SHA1Hash)
+	default:
+		return ""
+	}
+}
+
+// Keeper returns the index of the copy that rule keeps. "oldest" and
+// "newest" compare creation time (falling back to modification time when
+// Graph omits it); ties and "shortest-path" prefer the shortest path, then
+// the first in group order, so the choice is deterministic.
+func (g *DuplicateGroup) Keeper(rule KeepRule) int {
+	keep := 0
+
+	for i := 1; i < len(g.Copies); i++ {
+		if keepBefore(&g.Copies[i], &g.Copies[keep], rule) {
+			keep = i
+		}
+	}
+
+	return keep
+}
+
+func keepBefore(a, b *DuplicateCandidate, rule KeepRule) bool {
+	if rule != KeepShortestPath {
+		at, bt := copyTime(a), copyTime(b)
+		if !at.Equal(bt) {
+			return (rule == KeepOldest) == at.Before(bt)
+		}
+	}
+
+	return len(a.Entry.Path) < len(b.Entry.Path)
+}
+
+func copyTime(c *DuplicateCandidate) time.Time {
+	if !c.Entry.Item.CreatedAt.IsZero() {
+		return c.Entry.Item.CreatedAt
+	}
+
+	return c.Entry.Item.ModifiedAt
+}
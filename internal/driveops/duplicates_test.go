@@ -0,0 +1,86 @@
+package driveops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+func dupeCandidate(drive, itemPath string, item graph.Item) DuplicateCandidate {
+	return DuplicateCandidate{Drive: drive, Entry: RemoteTreeEntry{Path: itemPath, Item: item}}
+}
+
+// Validates: R-1.14
+func TestFindDuplicates_GroupsBySizeAndHashAcrossDrives(t *testing.T) {
+	t.Parallel()
+
+	groups := FindDuplicates([]DuplicateCandidate{
+		dupeCandidate("b-drive", "copy.bin", graph.Item{ID: "2", Size: 100, QuickXorHash: "big"}),
+		dupeCandidate("a-drive", "Docs/orig.bin", graph.Item{ID: "1", Size: 100, QuickXorHash: "big"}),
+		dupeCandidate("a-drive", "small1.txt", graph.Item{ID: "3", Size: 10, SHA1Hash: "ABC"}),
+		dupeCandidate("a-drive", "small2.txt", graph.Item{ID: "4", Size: 10, SHA1Hash: "abc"}),
+		dupeCandidate("a-drive", "same-hash-other-size", graph.Item{ID: "5", Size: 11, QuickXorHash: "big"}),
+		dupeCandidate("a-drive", "empty1", graph.Item{ID: "6", QuickXorHash: "AAAA"}),
+		dupeCandidate("a-drive", "empty2", graph.Item{ID: "7", QuickXorHash: "AAAA"}),
+		dupeCandidate("a-drive", "nohash1", graph.Item{ID: "8", Size: 50}),
+		dupeCandidate("a-drive", "nohash2", graph.Item{ID: "9", Size: 50}),
+		dupeCandidate("a-drive", "Folder", graph.Item{ID: "10", IsFolder: true, Size: 100, QuickXorHash: "big"}),
+	}, 0)
+
+	require.Len(t, groups, 2)
+	assert.Equal(t, "quickXorHash:big", groups[0].Hash)
+	assert.Equal(t, int64(100), groups[0].WastedBytes())
+	require.Len(t, groups[0].Copies, 2)
+	assert.Equal(t, "a-drive", groups[0].Copies[0].Drive)
+	assert.Equal(t, "b-drive", groups[0].Copies[1].Drive)
+	assert.Equal(t, "sha1:abc", groups[1].Hash)
+}
+
+// Validates: R-1.14
+func TestFindDuplicates_MinSize(t *testing.T) {
+	t.Parallel()
+
+	groups := FindDuplicates([]DuplicateCandidate{
+		dupeCandidate("d", "a", graph.Item{ID: "1", Size: 10, QuickXorHash: "h"}),
+		dupeCandidate("d", "b", graph.Item{ID: "2", Size: 10, QuickXorHash: "h"}),
+	}, 11)
+
+	assert.Empty(t, groups)
+}
+
+// Validates: R-1.14.1
+func TestDuplicateGroup_Keeper(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	group := DuplicateGroup{Size: 1, Copies: []DuplicateCandidate{
+		dupeCandidate("d", "Archive/2023/deep/report.pdf", graph.Item{CreatedAt: base}),
+		dupeCandidate("d", "report.pdf", graph.Item{CreatedAt: base.Add(2 * time.Hour)}),
+		dupeCandidate("d", "Docs/report.pdf", graph.Item{ModifiedAt: base.Add(time.Hour)}),
+	}}
+
+	assert.Equal(t, 0, group.Keeper(KeepOldest))
+	assert.Equal(t, 1, group.Keeper(KeepNewest))
+	assert.Equal(t, 1, group.Keeper(KeepShortestPath))
+
+	tied := DuplicateGroup{Size: 1, Copies: []DuplicateCandidate{
+		dupeCandidate("d", "long/path.txt", graph.Item{CreatedAt: base}),
+		dupeCandidate("d", "path.txt", graph.Item{CreatedAt: base}),
+	}}
+	assert.Equal(t, 1, tied.Keeper(KeepOldest), "time ties fall back to the shortest path")
+}
+
+func TestParseKeepRule(t *testing.T) {
+	t.Parallel()
+
+	rule, err := ParseKeepRule("shortest-path")
+	require.NoError(t, err)
+	assert.Equal(t, KeepShortestPath, rule)
+
+	_, err = ParseKeepRule("largest")
+	require.Error(t, err)
+}
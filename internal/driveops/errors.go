@@ -23,6 +23,11 @@ var ErrFileExceedsOneDriveLimit = errors.New("file exceeds OneDrive 250 GB limit
 // no scope escalation — smaller files may still fit (R-2.10.44).
 var ErrFileTooLargeForSpace = errors.New("insufficient disk space for file")
 
+// ErrContentHashMismatch is returned when a streamed whole-file read does not
+// match the item's QuickXorHash. Unlike file downloads there is nothing to
+// discard and retry: the bytes have already been written to the stream.
+var ErrContentHashMismatch = errors.New("content hash mismatch")
+
 // ErrPathNotVisible is returned when Graph acknowledged a metadata-changing
 // mutation, but the destination path still is not readable after the bounded
 // post-success visibility wait. Callers surface this as a concrete degraded
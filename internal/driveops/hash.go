@@ -1,9 +1,14 @@
 package driveops
 
 import (
+	"crypto/sha1" //nolint:gosec // SHA1 is what Graph reports, not a security boundary
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
+	"strings"
 
 	"github.com/tonimelisma/onedrive-go/internal/graph"
 	"github.com/tonimelisma/onedrive-go/internal/localpath"
@@ -33,19 +38,62 @@ func SelectHash(item *graph.Item) string {
 	return item.SHA1Hash
 }
 
+// Content hash algorithms Graph reports for a file.
+const (
+	HashQuickXor = "quickxor"
+	HashSHA1     = "sha1"
+	HashSHA256   = "sha256"
+)
+
+// ItemHash returns the item's hash for algorithm as Graph reported it, or ""
+// when the server has none (SHA1 is Personal-only, SHA256 is occasional).
+func ItemHash(item *graph.Item, algorithm string) string {
+	switch algorithm {
+	case HashSHA1:
+		return item.SHA1Hash
+	case HashSHA256:
+		return item.SHA256Hash
+	default:
+		return item.QuickXorHash
+	}
+}
+
 // ComputeQuickXorHash computes the QuickXorHash of a file and returns the
 // base64-encoded digest. Uses streaming I/O (constant memory).
 func ComputeQuickXorHash(fsPath string) (string, error) {
+	return ComputeHash(fsPath, HashQuickXor)
+}
+
+// ComputeHash computes a file's hash in the encoding Graph uses: base64 for
+// QuickXorHash and uppercase hex for SHA1 and SHA256, so results compare
+// directly with ItemHash. Uses streaming I/O (constant memory).
+func ComputeHash(fsPath, algorithm string) (string, error) {
+	var h hash.Hash
+
+	switch algorithm {
+	case HashQuickXor:
+		h = quickxorhash.New()
+	case HashSHA1:
+		h = sha1.New() //nolint:gosec // SHA1 is what Graph reports, not a security boundary
+	case HashSHA256:
+		h = sha256.New()
+	default:
+		return "", fmt.Errorf("unknown hash algorithm %q", algorithm)
+	}
+
 	f, err := localpath.Open(fsPath)
 	if err != nil {
 		return "", fmt.Errorf("opening %s for hashing: %w", fsPath, err)
 	}
 	defer f.Close()
 
-	h := quickxorhash.New()
 	if _, err := io.Copy(h, f); err != nil {
 		return "", fmt.Errorf("hashing %s: %w", fsPath, err)
 	}
 
-	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+	if algorithm == HashQuickXor {
+		return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+	}
+
+	return strings.ToUpper(hex.EncodeToString(h.Sum(nil))), nil
 }
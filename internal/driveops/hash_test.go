@@ -89,3 +89,39 @@ func TestHasHash(t *testing.T) {
 		})
 	}
 }
+
+// Validates: R-1.18.1
+func TestComputeHash_GraphEncodings(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "test.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello world"), 0o600))
+
+	tests := []struct {
+		algorithm string
+		want      string
+	}{
+		{HashQuickXor, hashContent(t, "hello world")},
+		{HashSHA1, "2AAE6C35C94FCFB415DBE95F408B9CE91EE846ED"},
+		{HashSHA256, "B94D27B9934D3E08A52E52D7DA7DABFAC484EFE37A5380EE9088F7ACE2EFCDE9"},
+	}
+
+	for _, tt := range tests {
+		got, err := ComputeHash(path, tt.algorithm)
+		require.NoError(t, err, tt.algorithm)
+		assert.Equal(t, tt.want, got, tt.algorithm)
+	}
+
+	_, err := ComputeHash(path, "md5")
+	require.Error(t, err)
+}
+
+func TestItemHash(t *testing.T) {
+	t.Parallel()
+
+	item := &graph.Item{QuickXorHash: "qx", SHA1Hash: "S1"}
+
+	assert.Equal(t, "qx", ItemHash(item, HashQuickXor))
+	assert.Equal(t, "S1", ItemHash(item, HashSHA1))
+	assert.Empty(t, ItemHash(item, HashSHA256), "missing server hashes are empty")
+}
@@ -16,13 +16,25 @@ type Downloader interface {
 
 // Uploader uploads a local file, encapsulating the simple-vs-chunked decision
 // and upload session lifecycle. content must be an io.ReaderAt for retry safety.
+// An empty conflict behavior means replace.
 type Uploader interface {
 	Upload(
 		ctx context.Context, driveID driveid.ID, parentID, name string,
 		content io.ReaderAt, size int64, mtime time.Time, progress graph.ProgressFunc,
+		conflict graph.ConflictBehavior,
 	) (*graph.Item, error)
 }
 
+// StreamUploader uploads content of unknown length read sequentially from
+// r, returning the created item and the number of bytes read. Satisfied by
+// *graph.Client. Type-asserted at runtime, like SessionUploader.
+type StreamUploader interface {
+	UploadStream(
+		ctx context.Context, driveID driveid.ID, parentID, name string,
+		r io.Reader, mtime time.Time, progress graph.ProgressFunc, conflict graph.ConflictBehavior,
+	) (*graph.Item, int64, error)
+}
+
 // ItemUploader overwrites an existing item by item ID.
 type ItemUploader interface {
 	UploadToItem(
@@ -39,7 +51,7 @@ type ItemUploader interface {
 type SessionUploader interface {
 	CreateUploadSession(
 		ctx context.Context, driveID driveid.ID, parentID, name string,
-		size int64, mtime time.Time,
+		size int64, mtime time.Time, conflict graph.ConflictBehavior,
 	) (*graph.UploadSession, error)
 	UploadFromSession(
 		ctx context.Context, session *graph.UploadSession,
@@ -78,6 +90,24 @@ type RangeDownloader interface {
 	) (int64, error)
 }
 
+// ByteRangeDownloader streams an inclusive byte range of an item. It reports
+// the bytes already written even when it fails, so callers can resume a
+// stream that cannot be rewound. Satisfied by *graph.Client.
+type ByteRangeDownloader interface {
+	DownloadByteRange(
+		ctx context.Context, driveID driveid.ID, itemID string,
+		w io.Writer, start, end int64,
+	) (int64, error)
+}
+
+// ConvertDownloader streams a remote file converted to another format.
+// Satisfied by *graph.Client.
+type ConvertDownloader interface {
+	DownloadConverted(
+		ctx context.Context, driveID driveid.ID, itemID, format string, w io.Writer,
+	) (int64, error)
+}
+
 // PathConvergence owns post-success path settling for one mounted drive/root
 // session. Callers use it after successful mutations when Graph can lag on
 // follow-on path reads or path-authoritative delete routes.
@@ -0,0 +1,161 @@
+package driveops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// RemoteTreeEntry is one live item from a delta enumeration together with
+// its slash-separated path relative to the enumeration root.
+type RemoteTreeEntry struct {
+	Path string
+	Item graph.Item
+}
+
+// EnumerateDrive returns every live item in the session drive from one full
+// delta pass. Delta pages do not carry parentReference.path, so paths are
+// rebuilt from parent IDs. The root itself is not included. Entries are
+// sorted by path.
+func (s *Session) EnumerateDrive(ctx context.Context) ([]RemoteTreeEntry, error) {
+	items, _, err := s.Meta.DeltaAll(ctx, s.DriveID, "")
+	if err != nil {
+		return nil, fmt.Errorf("enumerate drive: %w", err)
+	}
+
+	return BuildRemoteTree(items, ""), nil
+}
+
+// EnumerateFolder resolves remotePath to a folder and returns the folder item
+// plus every live descendant with paths relative to that folder. It uses one
+// folder-scoped delta enumeration; where Graph does not support folder delta
+// (Business/SharePoint), it falls back to a recursive children listing.
+func (s *MountSession) EnumerateFolder(ctx context.Context, remotePath string) (*graph.Item, []RemoteTreeEntry, error) {
+	folder, err := s.ResolveItem(ctx, remotePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !folder.IsFolder {
+		return nil, nil, fmt.Errorf("enumerate %q: not a folder", CleanRemotePath(remotePath))
+	}
+
+	items, _, err := s.Meta.DeltaFolderAll(ctx, s.DriveID, folder.ID, "")
+	if err != nil {
+		if !errors.Is(err, graph.ErrMethodNotAllowed) && !errors.Is(err, graph.ErrNotFound) {
+			return nil, nil, fmt.Errorf("enumerate folder %q: %w", folder.ID, err)
+		}
+
+		items, err = s.Meta.ListChildrenRecursive(ctx, s.DriveID, folder.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("enumerate folder %q: %w", folder.ID, err)
+		}
+	}
+
+	return folder, BuildRemoteTree(items, folder.ID), nil
+}
+
+// ListShortcuts returns the shortcut placeholders ("Add to My files" links
+// to shared folders) that live in the session drive.
+func (s *Session) ListShortcuts(ctx context.Context) ([]RemoteTreeEntry, error) {
+	entries, err := s.EnumerateDrive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shortcuts := make([]RemoteTreeEntry, 0)
+	for i := range entries {
+		if IsShortcut(&entries[i].Item) {
+			shortcuts = append(shortcuts, entries[i])
+		}
+	}
+
+	return shortcuts, nil
+}
+
+// IsShortcut reports whether item is an own-drive placeholder whose
+// remoteItem points at a shared folder.
+func IsShortcut(item *graph.Item) bool {
+	return item != nil && item.RemoteItemID != "" && item.RemoteIsFolder
+}
+
+// BuildRemoteTree turns a flat delta item list into path-addressed entries
+// below rootID. An empty rootID selects the item flagged IsRoot. Deleted
+// items, later duplicates and items whose ancestry does not reach the root
+// are dropped.
+func BuildRemoteTree(items []graph.Item, rootID string) []RemoteTreeEntry {
+	byID := make(map[string]graph.Item, len(items))
+	order := make([]string, 0, len(items))
+
+	for i := range items {
+		item := items[i]
+		if rootID == "" && item.IsRoot {
+			rootID = item.ID
+		}
+
+		if item.IsDeleted {
+			delete(byID, item.ID)
+			continue
+		}
+
+		if _, seen := byID[item.ID]; !seen {
+			order = append(order, item.ID)
+		}
+
+		byID[item.ID] = item
+	}
+
+	paths := map[string]string{rootID: ""}
+	entries := make([]RemoteTreeEntry, 0, len(order))
+
+	for _, id := range order {
+		item, ok := byID[id]
+		if !ok || id == rootID {
+			continue
+		}
+
+		itemPath, ok := remoteTreePath(id, byID, paths)
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, RemoteTreeEntry{Path: itemPath, Item: item})
+	}
+
+	slices.SortFunc(entries, func(a, b RemoteTreeEntry) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return entries
+}
+
+// remoteTreePath resolves id to a root-relative path, memoizing every
+// ancestor along the way. Cycles and orphans resolve to false.
+func remoteTreePath(id string, byID map[string]graph.Item, paths map[string]string) (string, bool) {
+	var chain []string
+
+	current := id
+	for {
+		if known, ok := paths[current]; ok {
+			for i := len(chain) - 1; i >= 0; i-- {
+				known = path.Join(known, byID[chain[i]].Name)
+				paths[chain[i]] = known
+			}
+
+			return paths[id], true
+		}
+
+		item, ok := byID[current]
+		if !ok || item.ParentID == "" || slices.Contains(chain, current) {
+			return "", false
+		}
+
+		chain = append(chain, current)
+		current = item.ParentID
+	}
+}
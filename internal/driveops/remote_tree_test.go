@@ -0,0 +1,146 @@
+package driveops
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+func remoteTreePaths(entries []RemoteTreeEntry) []string {
+	paths := make([]string, 0, len(entries))
+	for i := range entries {
+		paths = append(paths, entries[i].Path)
+	}
+
+	return paths
+}
+
+func TestBuildRemoteTree_RebuildsPathsFromParentIDs(t *testing.T) {
+	t.Parallel()
+
+	items := []graph.Item{
+		{ID: "root", IsRoot: true, IsFolder: true},
+		{ID: "c", Name: "c.txt", ParentID: "b"},
+		{ID: "a", Name: "Docs", ParentID: "root", IsFolder: true},
+		{ID: "b", Name: "Sub", ParentID: "a", IsFolder: true},
+		{ID: "gone", Name: "old.txt", ParentID: "a"},
+		{ID: "gone", IsDeleted: true},
+		{ID: "orphan", Name: "lost.txt", ParentID: "missing"},
+	}
+
+	entries := BuildRemoteTree(items, "")
+
+	assert.Equal(t, []string{"Docs", "Docs/Sub", "Docs/Sub/c.txt"}, remoteTreePaths(entries))
+}
+
+func TestBuildRemoteTree_ExplicitRootScopesSubtree(t *testing.T) {
+	t.Parallel()
+
+	items := []graph.Item{
+		{ID: "root", IsRoot: true, IsFolder: true},
+		{ID: "a", Name: "Docs", ParentID: "root", IsFolder: true},
+		{ID: "b", Name: "b.txt", ParentID: "a"},
+		{ID: "x", Name: "x.txt", ParentID: "root"},
+	}
+
+	entries := BuildRemoteTree(items, "a")
+
+	assert.Equal(t, []string{"b.txt"}, remoteTreePaths(entries))
+}
+
+func TestBuildRemoteTree_LaterDuplicateWins(t *testing.T) {
+	t.Parallel()
+
+	items := []graph.Item{
+		{ID: "root", IsRoot: true, IsFolder: true},
+		{ID: "a", Name: "before.txt", ParentID: "root"},
+		{ID: "a", Name: "after.txt", ParentID: "root"},
+	}
+
+	entries := BuildRemoteTree(items, "")
+
+	require.Len(t, entries, 1)
+	assert.Equal(t, "after.txt", entries[0].Path)
+}
+
+// Validates: R-1.10.2
+func TestSession_ListShortcuts(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/drives/abcdef0123456789/root/delta", r.URL.Path)
+		writeTestResponsef(t, w, `{"value":[
+			{"id":"root","name":"root","root":{},"folder":{}},
+			{"id":"docs","name":"Docs","parentReference":{"id":"root"},"folder":{}},
+			{"id":"sc","name":"Team","parentReference":{"id":"docs"},
+			 "remoteItem":{"id":"owner-folder","parentReference":{"driveId":"owner-drive"},"folder":{}}},
+			{"id":"file","name":"a.txt","parentReference":{"id":"docs"},"file":{}}
+		],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`)
+	}))
+
+	shortcuts, err := s.ListShortcuts(t.Context())
+	require.NoError(t, err)
+	require.Len(t, shortcuts, 1)
+	assert.Equal(t, "Docs/Team", shortcuts[0].Path)
+	assert.Equal(t, "owner-folder", shortcuts[0].Item.RemoteItemID)
+}
+
+// Validates: R-1.12
+func TestMountSession_EnumerateFolder_UsesFolderDelta(t *testing.T) {
+	t.Parallel()
+
+	s := newTestDriveRootMountSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/drives/abcdef0123456789/root:/Docs:":
+			writeTestResponsef(t, w, `{"id":"docs","name":"Docs","folder":{},"parentReference":{"id":"root","path":"/drive/root:"}}`)
+		case "/drives/abcdef0123456789/items/docs/delta":
+			writeTestResponsef(t, w, `{"value":[
+				{"id":"docs","name":"Docs","folder":{},"parentReference":{"id":"root"}},
+				{"id":"sub","name":"Sub","folder":{},"parentReference":{"id":"docs"}},
+				{"id":"f","name":"a.txt","file":{},"parentReference":{"id":"sub"}}
+			],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/delta?token=t"}`)
+		default:
+			assert.Failf(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	folder, entries, err := s.EnumerateFolder(t.Context(), "/Docs")
+	require.NoError(t, err)
+	assert.Equal(t, "docs", folder.ID)
+	assert.Equal(t, []string{"Sub", "Sub/a.txt"}, remoteTreePaths(entries))
+}
+
+// Validates: R-1.12
+func TestMountSession_EnumerateFolder_FallsBackToRecursiveListing(t *testing.T) {
+	t.Parallel()
+
+	s := newTestDriveRootMountSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/drives/abcdef0123456789/root:/Docs:":
+			writeTestResponsef(t, w, `{"id":"docs","name":"Docs","folder":{},"parentReference":{"id":"root","path":"/drive/root:"}}`)
+		case "/drives/abcdef0123456789/items/docs/delta":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeTestResponsef(t, w, `{"error":{"code":"notSupported"}}`)
+		case "/drives/abcdef0123456789/items/docs/children":
+			writeTestResponsef(t, w, `{"value":[
+				{"id":"sub","name":"Sub","folder":{"childCount":1},"parentReference":{"id":"docs"}}
+			]}`)
+		case "/drives/abcdef0123456789/items/sub/children":
+			writeTestResponsef(t, w, `{"value":[
+				{"id":"f","name":"a.txt","file":{},"parentReference":{"id":"sub"}}
+			]}`)
+		default:
+			assert.Failf(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	_, entries, err := s.EnumerateFolder(t.Context(), "/Docs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Sub", "Sub/a.txt"}, remoteTreePaths(entries))
+}
@@ -0,0 +1,155 @@
+package driveops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// maxSearchParentDepth bounds the parent-ID walk used to place one search hit
+// so a malformed parent chain cannot loop forever.
+const maxSearchParentDepth = 256
+
+// errOutsideMountRoot marks a search hit whose parent chain never reaches the
+// mount root; such hits are dropped rather than reported.
+var errOutsideMountRoot = errors.New("driveops: item is outside the mount root")
+
+// SearchOptions scopes a server-side drive search.
+type SearchOptions struct {
+	Query     string
+	ScopePath string // mount-relative folder to search below; "" = mount root
+	Limit     int    // max hits returned; 0 = no limit
+	Keep      func(*graph.Item) bool
+}
+
+// Search runs Graph drive search below the scope folder and returns hits with
+// mount-relative paths reconstructed from parentReference. Hits from other
+// drives (shared items surfaced by search) and hits outside the mount root
+// are dropped.
+func (s *MountSession) Search(ctx context.Context, opts SearchOptions) ([]RemoteTreeEntry, error) {
+	mountRoot, err := s.Meta.GetItem(ctx, s.DriveID, s.remoteRootItemID())
+	if err != nil {
+		return nil, fmt.Errorf("resolve mount root: %w", err)
+	}
+
+	folderID := mountRoot.ID
+	if scope := CleanRemotePath(opts.ScopePath); scope != "" {
+		scopeItem, scopeErr := s.ResolveItem(ctx, scope)
+		if scopeErr != nil {
+			return nil, scopeErr
+		}
+
+		if !scopeItem.IsFolder {
+			return nil, fmt.Errorf("search scope %q is not a folder", scope)
+		}
+
+		folderID = scopeItem.ID
+	}
+
+	if !s.hasMountRoot() {
+		folderID = searchFolderID(folderID, mountRoot)
+	}
+
+	items, err := s.Meta.SearchDrive(ctx, s.DriveID, folderID, opts.Query, opts.Limit, func(item *graph.Item) bool {
+		if item.IsRoot || item.ID == mountRoot.ID {
+			return false
+		}
+		if !item.ParentDriveID.IsZero() && !item.ParentDriveID.Equal(s.DriveID) {
+			return false
+		}
+
+		return opts.Keep == nil || opts.Keep(item)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search %q: %w", opts.Query, err)
+	}
+
+	resolver := &searchPathResolver{
+		session:  s,
+		paths:    map[string]string{mountRoot.ID: ""},
+		useGraph: !s.hasMountRoot(),
+	}
+
+	hits := make([]RemoteTreeEntry, 0, len(items))
+	for i := range items {
+		hitPath, pathErr := resolver.itemPath(ctx, &items[i])
+		if errors.Is(pathErr, errOutsideMountRoot) {
+			continue
+		}
+		if pathErr != nil {
+			return nil, pathErr
+		}
+
+		hits = append(hits, RemoteTreeEntry{Path: hitPath, Item: items[i]})
+	}
+
+	return hits, nil
+}
+
+// searchFolderID keeps the cheaper drive-root search route when the scope is
+// the drive root itself.
+func searchFolderID(folderID string, driveRoot *graph.Item) string {
+	if folderID == driveRoot.ID {
+		return graphDriveRootItemID
+	}
+
+	return folderID
+}
+
+// searchPathResolver places search hits relative to the mount root. Drive-root
+// mounts trust Graph's decoded parentReference.path when present; otherwise it
+// walks parentReference.id upward with GetItem, memoizing every folder seen.
+type searchPathResolver struct {
+	session  *MountSession
+	paths    map[string]string
+	useGraph bool
+}
+
+func (r *searchPathResolver) itemPath(ctx context.Context, item *graph.Item) (string, error) {
+	if r.useGraph && (item.ParentPathKnown || item.ParentPath != "") {
+		return path.Join(item.ParentPath, item.Name), nil
+	}
+
+	parentPath, err := r.folderPath(ctx, item.ParentID, 0)
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(parentPath, item.Name), nil
+}
+
+func (r *searchPathResolver) folderPath(ctx context.Context, folderID string, depth int) (string, error) {
+	if known, ok := r.paths[folderID]; ok {
+		return known, nil
+	}
+
+	if folderID == "" || depth >= maxSearchParentDepth {
+		return "", errOutsideMountRoot
+	}
+
+	folder, err := r.session.Meta.GetItem(ctx, r.session.DriveID, folderID)
+	if err != nil {
+		if errors.Is(err, graph.ErrNotFound) || errors.Is(err, graph.ErrForbidden) {
+			return "", errOutsideMountRoot
+		}
+
+		return "", fmt.Errorf("resolve search hit parent %q: %w", folderID, err)
+	}
+
+	if folder.IsRoot {
+		return "", errOutsideMountRoot
+	}
+
+	parentPath, err := r.folderPath(ctx, folder.ParentID, depth+1)
+	if err != nil {
+		return "", err
+	}
+
+	folderPath := path.Join(parentPath, folder.Name)
+	r.paths[folderID] = folderPath
+
+	return folderPath, nil
+}
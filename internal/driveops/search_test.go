@@ -0,0 +1,69 @@
+package driveops
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Validates: R-1.11
+func TestMountSession_Search_ResolvesHitPaths(t *testing.T) {
+	t.Parallel()
+
+	s := newTestDriveRootMountSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/drives/abcdef0123456789/items/root":
+			writeTestResponsef(t, w, `{"id":"root-real","name":"root","root":{},"folder":{}}`)
+		case "/drives/abcdef0123456789/root/search(q='plan')":
+			writeTestResponsef(t, w, `{"value":[
+				{"id":"a","name":"plan.txt","file":{},
+				 "parentReference":{"id":"docs","driveId":"abcdef0123456789","path":"/drive/root:/Docs"}},
+				{"id":"b","name":"plan.md","file":{},
+				 "parentReference":{"id":"sub","driveId":"abcdef0123456789"}},
+				{"id":"c","name":"plan.xlsx","file":{},
+				 "parentReference":{"id":"x","driveId":"ffffffffffffffff"}}
+			]}`)
+		case "/drives/abcdef0123456789/items/sub":
+			writeTestResponsef(t, w, `{"id":"sub","name":"Sub","folder":{},"parentReference":{"id":"root-real"}}`)
+		default:
+			assert.Failf(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	hits, err := s.Search(t.Context(), SearchOptions{Query: "plan"})
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+	assert.Equal(t, "Docs/plan.txt", hits[0].Path)
+	assert.Equal(t, "Sub/plan.md", hits[1].Path)
+}
+
+// Validates: R-1.11
+func TestMountSession_Search_MountRootDropsHitsOutsideRoot(t *testing.T) {
+	t.Parallel()
+
+	s := NewMountSession(newTestSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/drives/abcdef0123456789/items/mount":
+			writeTestResponsef(t, w, `{"id":"mount","name":"Shared","folder":{}}`)
+		case "/drives/abcdef0123456789/items/mount/search(q='plan')":
+			writeTestResponsef(t, w, `{"value":[
+				{"id":"a","name":"plan.txt","file":{},"parentReference":{"id":"mount"}},
+				{"id":"b","name":"plan.md","file":{},"parentReference":{"id":"elsewhere"}}
+			]}`)
+		case "/drives/abcdef0123456789/items/elsewhere":
+			w.WriteHeader(http.StatusForbidden)
+			writeTestResponsef(t, w, `{"error":{"code":"accessDenied"}}`)
+		default:
+			assert.Failf(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})), "mount")
+
+	hits, err := s.Search(t.Context(), SearchOptions{Query: "plan"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "plan.txt", hits[0].Path)
+}
@@ -640,6 +640,36 @@ func (s *MountSession) DeleteResolvedPath(ctx context.Context, remotePath, itemI
 	return s.deleteResolvedPath(ctx, remotePath, itemID, s.DeleteItem)
 }
 
+// DeleteResolvedPathsBatch deletes several resolved items through Graph JSON
+// batching, to the recycle bin or permanently. An item the batch reports
+// missing goes through the same convergence wait as DeleteResolvedPath. The
+// result holds one error per path, nil where the delete succeeded.
+func (s *MountSession) DeleteResolvedPathsBatch(
+	ctx context.Context, remotePaths, itemIDs []string, permanent bool,
+) ([]error, error) {
+	batchDelete, deleteByID := s.Meta.DeleteItemsBatch, s.DeleteItem
+	if permanent {
+		batchDelete, deleteByID = s.Meta.PermanentDeleteItemsBatch, s.PermanentDeleteItem
+	}
+
+	errs, err := batchDelete(ctx, s.DriveID, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("batch delete: %w", err)
+	}
+
+	for i := range errs {
+		switch {
+		case errs[i] == nil:
+		case errors.Is(errs[i], graph.ErrNotFound):
+			errs[i] = s.deleteResolvedPath(ctx, remotePaths[i], itemIDs[i], deleteByID)
+		default:
+			errs[i] = fmt.Errorf("delete item %q: %w", itemIDs[i], errs[i])
+		}
+	}
+
+	return errs, nil
+}
+
 // PermanentDeleteItem permanently deletes an item (Business/SharePoint only).
 func (s *Session) PermanentDeleteItem(ctx context.Context, itemID string) error {
 	if err := s.Meta.PermanentDeleteItem(ctx, s.DriveID, itemID); err != nil {
@@ -665,9 +695,12 @@ func (s *Session) CreateFolder(ctx context.Context, parentID, name string) (*gra
 	return item, nil
 }
 
-// MoveItem moves and/or renames an item.
-func (s *Session) MoveItem(ctx context.Context, itemID, newParentID, newName string) (*graph.Item, error) {
-	item, err := s.Meta.MoveItem(ctx, s.DriveID, itemID, newParentID, newName)
+// MoveItem moves and/or renames an item. An empty conflict behavior keeps
+// the service default, which fails on a name conflict.
+func (s *Session) MoveItem(
+	ctx context.Context, itemID, newParentID, newName string, conflict graph.ConflictBehavior,
+) (*graph.Item, error) {
+	item, err := s.Meta.MoveItem(ctx, s.DriveID, itemID, newParentID, newName, conflict)
 	if err != nil {
 		return nil, fmt.Errorf("move item %q: %w", itemID, err)
 	}
@@ -676,8 +709,12 @@ func (s *Session) MoveItem(ctx context.Context, itemID, newParentID, newName str
 }
 
 // CopyItem starts an async copy operation. Returns a monitor URL for polling.
-func (s *Session) CopyItem(ctx context.Context, itemID, destParentID, newName string) (*graph.CopyResult, error) {
-	result, err := s.Meta.CopyItem(ctx, s.DriveID, itemID, destParentID, newName)
+// An empty conflict behavior keeps the service default, which fails on a
+// name conflict.
+func (s *Session) CopyItem(
+	ctx context.Context, itemID, destParentID, newName string, conflict graph.ConflictBehavior,
+) (*graph.CopyResult, error) {
+	result, err := s.Meta.CopyItem(ctx, s.DriveID, itemID, destParentID, newName, conflict)
 	if err != nil {
 		return nil, fmt.Errorf("copy item %q: %w", itemID, err)
 	}
@@ -685,6 +722,18 @@ func (s *Session) CopyItem(ctx context.Context, itemID, destParentID, newName st
 	return result, nil
 }
 
+// CreateShortcut adds a shortcut to a shared folder under the given parent.
+func (s *Session) CreateShortcut(
+	ctx context.Context, parentID, name string, remoteDriveID driveid.ID, remoteItemID string,
+) (*graph.Item, error) {
+	item, err := s.Meta.CreateShortcut(ctx, s.DriveID, parentID, name, remoteDriveID, remoteItemID)
+	if err != nil {
+		return nil, fmt.Errorf("create shortcut %q: %w", name, err)
+	}
+
+	return item, nil
+}
+
 // ListRecycleBinItems returns all items in the drive's recycle bin.
 func (s *Session) ListRecycleBinItems(ctx context.Context) ([]graph.Item, error) {
 	items, err := s.Meta.ListRecycleBinItems(ctx, s.DriveID)
@@ -705,6 +754,103 @@ func (s *Session) RestoreItem(ctx context.Context, itemID string) (*graph.Item,
 	return item, nil
 }
 
+// RestoreItemsBatch restores several recycle-bin items through Graph JSON
+// batching, one result per item ID.
+func (s *Session) RestoreItemsBatch(ctx context.Context, itemIDs []string) ([]graph.BatchItemResult, error) {
+	results, err := s.Meta.RestoreItemsBatch(ctx, s.DriveID, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("restore items: %w", err)
+	}
+
+	for i := range results {
+		if results[i].Err != nil {
+			results[i].Err = fmt.Errorf("restore item %q: %w", itemIDs[i], results[i].Err)
+		}
+	}
+
+	return results, nil
+}
+
+// CheckoutItem checks out a file in a SharePoint document library.
+func (s *Session) CheckoutItem(ctx context.Context, itemID string) error {
+	if err := s.Meta.CheckoutItem(ctx, s.DriveID, itemID); err != nil {
+		return fmt.Errorf("check out item %q: %w", itemID, err)
+	}
+
+	return nil
+}
+
+// CheckinItem checks a file back in with an optional version comment.
+func (s *Session) CheckinItem(ctx context.Context, itemID, comment string) error {
+	if err := s.Meta.CheckinItem(ctx, s.DriveID, itemID, comment); err != nil {
+		return fmt.Errorf("check in item %q: %w", itemID, err)
+	}
+
+	return nil
+}
+
+// DiscardCheckout releases a checkout without publishing its changes.
+func (s *Session) DiscardCheckout(ctx context.Context, itemID string) error {
+	if err := s.Meta.DiscardCheckout(ctx, s.DriveID, itemID); err != nil {
+		return fmt.Errorf("discard checkout of item %q: %w", itemID, err)
+	}
+
+	return nil
+}
+
+// ListItemFields returns the document library column values of an item.
+func (s *Session) ListItemFields(ctx context.Context, itemID string) (map[string]any, error) {
+	fields, err := s.Meta.GetListItemFields(ctx, s.DriveID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("read list fields of item %q: %w", itemID, err)
+	}
+
+	return fields, nil
+}
+
+// UpdateListItemFields sets document library column values on an item and
+// returns the updated fields.
+func (s *Session) UpdateListItemFields(ctx context.Context, itemID string, fields map[string]any) (map[string]any, error) {
+	updated, err := s.Meta.UpdateListItemFields(ctx, s.DriveID, itemID, fields)
+	if err != nil {
+		return nil, fmt.Errorf("update list fields of item %q: %w", itemID, err)
+	}
+
+	return updated, nil
+}
+
+// ListDriveColumns returns the column definitions of the drive's document
+// library.
+func (s *Session) ListDriveColumns(ctx context.Context) ([]graph.ColumnDefinition, error) {
+	columns, err := s.Meta.ListDriveColumns(ctx, s.DriveID)
+	if err != nil {
+		return nil, fmt.Errorf("list drive columns: %w", err)
+	}
+
+	return columns, nil
+}
+
+// LookupPathsBatch looks up several mount-relative paths through Graph JSON
+// batching, one result per path. Missing paths carry graph.ErrNotFound.
+func (s *MountSession) LookupPathsBatch(ctx context.Context, remotePaths []string) ([]graph.BatchItemResult, error) {
+	base := graphDriveRootItemID
+	if s.hasMountRoot() {
+		base = s.RemoteRootItemID
+	}
+
+	paths := make([]string, 0, len(remotePaths))
+	for _, p := range remotePaths {
+		paths = append(paths, CleanRemotePath(p))
+	}
+
+	results, err := s.Meta.GetItemsByPathBatch(ctx, s.DriveID, base, paths)
+	if err != nil {
+		return nil, fmt.Errorf("look up paths: %w", err)
+	}
+
+	return results, nil
+}
+
 const graphDriveRootItemID = "root"
 
 func (s *MountSession) hasMountRoot() bool {
@@ -863,6 +1009,8 @@ func SplitParentAndName(path string) (string, string) {
 }
 
 // EnsureFolder creates a folder, returning the existing folder on 409 conflict.
+// OneDrive names are case-insensitive, so a folder created concurrently as
+// "Docs" satisfies a request for "docs"; a file holding the name is an error.
 func (s *Session) EnsureFolder(ctx context.Context, parentID, name string) (*graph.Item, error) {
 	item, err := s.CreateFolder(ctx, parentID, name)
 	if err != nil {
@@ -872,13 +1020,16 @@ func (s *Session) EnsureFolder(ctx context.Context, parentID, name string) (*gra
 				return nil, fmt.Errorf("resolving existing folder %q: %w", name, listErr)
 			}
 
-			for i := range children {
-				if children[i].IsFolder && children[i].Name == name {
-					return &children[i], nil
-				}
+			existing, ok := matchChildByName(children, name)
+			if !ok {
+				return nil, fmt.Errorf("folder %q reported as existing but not found in parent", name)
+			}
+
+			if !existing.IsFolder {
+				return nil, fmt.Errorf("%q already exists and is not a folder", existing.Name)
 			}
 
-			return nil, fmt.Errorf("folder %q reported as existing but not found in parent", name)
+			return &existing, nil
 		}
 
 		return nil, err
@@ -1002,6 +1002,30 @@ func TestEnsureFolder_Conflict(t *testing.T) {
 	assert.Equal(t, "existing-id", item.ID)
 }
 
+// Validates: R-1.5.3
+func TestEnsureFolder_ConflictMatchesNameWithoutCase(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusConflict)
+			writeTestResponsef(t, w, `{"error":{"code":"nameAlreadyExists"}}`)
+
+			return
+		}
+
+		writeTestResponsef(t, w, `{"value":[{"id":"file-id","name":"notes","file":{}},{"id":"existing-id","name":"Docs","folder":{}}]}`)
+	}))
+
+	item, err := s.EnsureFolder(t.Context(), "parent-id", "docs")
+	require.NoError(t, err)
+	assert.Equal(t, "existing-id", item.ID)
+
+	_, err = s.EnsureFolder(t.Context(), "parent-id", "notes")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"notes" already exists and is not a folder`)
+}
+
 func TestEnsureFolder_ConflictNotFound(t *testing.T) {
 	t.Parallel()
 
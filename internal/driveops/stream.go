@@ -0,0 +1,146 @@
+package driveops
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+	"github.com/tonimelisma/onedrive-go/internal/retry"
+	"github.com/tonimelisma/onedrive-go/pkg/quickxorhash"
+)
+
+// StreamRequest describes one content stream of a remote file. End is
+// inclusive; a negative End streams to the end of the file.
+type StreamRequest struct {
+	DriveID driveid.ID
+	Item    *graph.Item
+	Start   int64
+	End     int64
+}
+
+// StreamResult reports what StreamContent wrote.
+type StreamResult struct {
+	Bytes        int64
+	Resumes      int  // Range requests issued after transient mid-stream failures
+	HashVerified bool // true only for whole-file reads that matched QuickXorHash
+}
+
+// WholeFile reports whether the request covers the entire file content.
+func (r *StreamRequest) WholeFile() bool {
+	return r.Start == 0 && (r.End < 0 || r.End >= r.Item.Size-1)
+}
+
+// StreamContent writes a remote file's content (or a byte range of it) to w.
+// A destination like stdout cannot be rewound, so a transient failure after
+// some bytes were written resumes with a Range request from the next byte
+// instead of starting over, bounded by retry.StreamResumePolicy. Failures
+// writing to w are never retried. When the whole file was read, the stream
+// is checked against the item's QuickXorHash and a mismatch returns
+// ErrContentHashMismatch.
+func StreamContent(
+	ctx context.Context,
+	dl ByteRangeDownloader,
+	req StreamRequest,
+	w io.Writer,
+	sleep retry.SleepFunc,
+	logger *slog.Logger,
+) (StreamResult, error) {
+	var result StreamResult
+	if req.Item.Size == 0 {
+		return result, nil
+	}
+
+	out := &streamWriter{w: w}
+	dst := io.Writer(out)
+
+	var hasher hash.Hash
+	if req.WholeFile() && req.Item.QuickXorHash != "" {
+		hasher = quickxorhash.New()
+		dst = io.MultiWriter(out, hasher)
+	}
+
+	policy := retry.StreamResumePolicy()
+	pos := req.Start
+
+	for attempt := 0; ; attempt++ {
+		n, err := dl.DownloadByteRange(ctx, req.DriveID, req.Item.ID, dst, pos, req.End)
+		pos += n
+		result.Bytes += n
+
+		if err == nil {
+			break
+		}
+
+		if out.err != nil {
+			return result, fmt.Errorf("writing stream: %w", out.err)
+		}
+
+		if !isResumableStreamError(ctx, err) || attempt+1 >= policy.MaxAttempts {
+			return result, fmt.Errorf("stream %q at byte %d: %w", req.Item.Name, pos, err)
+		}
+
+		logger.Warn("content stream interrupted, resuming",
+			slog.String("item_id", req.Item.ID),
+			slog.Int64("offset", pos),
+			slog.Int("attempt", attempt+1),
+			slog.String("error", err.Error()),
+		)
+
+		result.Resumes++
+		if sleepErr := sleep(ctx, policy.Delay(attempt)); sleepErr != nil {
+			return result, fmt.Errorf("stream %q: %w", req.Item.Name, sleepErr)
+		}
+	}
+
+	if hasher == nil {
+		return result, nil
+	}
+
+	got := base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+	if got != req.Item.QuickXorHash {
+		return result, fmt.Errorf("%w: %q expected %s, got %s", ErrContentHashMismatch, req.Item.Name, req.Item.QuickXorHash, got)
+	}
+
+	result.HashVerified = true
+
+	return result, nil
+}
+
+// isResumableStreamError reports whether a failed range read is worth
+// resuming: network and mid-body failures, throttling, and server errors.
+// Client errors, missing download URLs, and cancellation are terminal.
+func isResumableStreamError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, graph.ErrNoDownloadURL) {
+		return false
+	}
+
+	var graphErr *graph.GraphError
+	if errors.As(err, &graphErr) {
+		return graphErr.StatusCode == http.StatusTooManyRequests || graphErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
+
+// streamWriter records the destination's first write error so stream
+// failures can be told apart from download failures.
+type streamWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	n, err := s.w.Write(p)
+	if err != nil && s.err == nil {
+		s.err = err
+	}
+
+	return n, err //nolint:wrapcheck // io.Writer contract: callers inspect the raw destination error
+}
@@ -0,0 +1,165 @@
+package driveops
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+	"github.com/tonimelisma/onedrive-go/pkg/quickxorhash"
+)
+
+// flakyRangeDownloader serves content from memory and cuts each of the
+// first failCount reads short after chunk bytes.
+type flakyRangeDownloader struct {
+	content   []byte
+	chunk     int
+	failCount int
+	failErr   error
+	starts    []int64
+}
+
+func (f *flakyRangeDownloader) DownloadByteRange(
+	_ context.Context, _ driveid.ID, _ string, w io.Writer, start, end int64,
+) (int64, error) {
+	f.starts = append(f.starts, start)
+
+	stop := int64(len(f.content))
+	if end >= 0 && end+1 < stop {
+		stop = end + 1
+	}
+
+	data := f.content[start:stop]
+	if f.failCount > 0 {
+		f.failCount--
+		if len(data) > f.chunk {
+			data = data[:f.chunk]
+		}
+
+		n, err := w.Write(data)
+		if err != nil {
+			return int64(n), err
+		}
+
+		return int64(n), f.failErr
+	}
+
+	n, err := w.Write(data)
+
+	return int64(n), err
+}
+
+func noSleep(context.Context, time.Duration) error { return nil }
+
+func streamTestItem(content []byte) *graph.Item {
+	h := quickxorhash.New()
+	_, _ = h.Write(content)
+
+	return &graph.Item{
+		ID: "item", Name: "log.txt", Size: int64(len(content)),
+		QuickXorHash: base64.StdEncoding.EncodeToString(h.Sum(nil)),
+	}
+}
+
+// Validates: R-1.15.2
+func TestStreamContent_ResumesAfterMidStreamFailure(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("line one\nline two\nline three\n")
+	dl := &flakyRangeDownloader{content: content, chunk: 7, failCount: 2, failErr: io.ErrUnexpectedEOF}
+
+	var out bytes.Buffer
+	result, err := StreamContent(t.Context(), dl, StreamRequest{Item: streamTestItem(content), End: -1},
+		&out, noSleep, slog.Default())
+	require.NoError(t, err)
+	assert.Equal(t, string(content), out.String())
+	assert.Equal(t, []int64{0, 7, 14}, dl.starts)
+	assert.Equal(t, 2, result.Resumes)
+	assert.True(t, result.HashVerified)
+}
+
+// Validates: R-1.15.2
+func TestStreamContent_DetectsHashMismatch(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("actual content")
+	item := streamTestItem([]byte("expected content"))
+	item.Size = int64(len(content))
+
+	var out bytes.Buffer
+	_, err := StreamContent(t.Context(), &flakyRangeDownloader{content: content}, StreamRequest{Item: item, End: -1},
+		&out, noSleep, slog.Default())
+	require.ErrorIs(t, err, ErrContentHashMismatch)
+}
+
+// Validates: R-1.15.1
+func TestStreamContent_RangeSkipsHashVerification(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("0123456789")
+	item := streamTestItem(content)
+	item.QuickXorHash = "bogus"
+
+	var out bytes.Buffer
+	result, err := StreamContent(t.Context(), &flakyRangeDownloader{content: content}, StreamRequest{Item: item, Start: 2, End: 4},
+		&out, noSleep, slog.Default())
+	require.NoError(t, err)
+	assert.Equal(t, "234", out.String())
+	assert.False(t, result.HashVerified)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("broken pipe") }
+
+func TestStreamContent_DoesNotRetryWriterFailures(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("data")
+	dl := &flakyRangeDownloader{content: content}
+
+	_, err := StreamContent(t.Context(), dl, StreamRequest{Item: streamTestItem(content), End: -1},
+		failingWriter{}, noSleep, slog.Default())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken pipe")
+	assert.Len(t, dl.starts, 1)
+}
+
+func TestStreamContent_DoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("data")
+	dl := &flakyRangeDownloader{
+		content: content, chunk: 0, failCount: 5,
+		failErr: &graph.GraphError{StatusCode: http.StatusForbidden, Err: graph.ErrForbidden},
+	}
+
+	_, err := StreamContent(t.Context(), dl, StreamRequest{Item: streamTestItem(content), End: -1},
+		io.Discard, noSleep, slog.Default())
+	require.ErrorIs(t, err, graph.ErrForbidden)
+	assert.Len(t, dl.starts, 1)
+}
+
+func TestStreamContent_GivesUpAfterResumeBudget(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("0123456789")
+	dl := &flakyRangeDownloader{content: content, chunk: 1, failCount: 100, failErr: io.ErrUnexpectedEOF}
+
+	var out bytes.Buffer
+	result, err := StreamContent(t.Context(), dl, StreamRequest{Item: streamTestItem(content), End: -1},
+		&out, noSleep, slog.Default())
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, "01234", out.String())
+	assert.Equal(t, 4, result.Resumes)
+}
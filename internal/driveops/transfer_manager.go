@@ -16,6 +16,7 @@ import (
 	"github.com/tonimelisma/onedrive-go/internal/graph"
 	"github.com/tonimelisma/onedrive-go/internal/localpath"
 	"github.com/tonimelisma/onedrive-go/internal/perf"
+	"github.com/tonimelisma/onedrive-go/internal/retry"
 	"github.com/tonimelisma/onedrive-go/pkg/quickxorhash"
 )
 
@@ -70,12 +71,14 @@ type DownloadOpts struct {
 	RemoteSize                 int64  // expected size; 0 = don't validate
 	MaxHashRetries             int    // 0 = use default (2 retries, meaning 3 total download attempts)
 	ValidateTargetBeforeRename func() error
+	Converted                  bool // output is converted content with no remote hash to check
 }
 
 // UploadOpts configures a single upload operation.
 type UploadOpts struct {
 	Mtime                    time.Time
 	Progress                 graph.ProgressFunc
+	ConflictBehavior         graph.ConflictBehavior // name conflict in the parent; empty = replace
 	ExpectedHash             string
 	HashMismatchError        func(localPath, expectedHash, actualHash string) error
 	ValidateSourceBeforeRead func() error
@@ -130,6 +133,12 @@ type TransferManager struct {
 	sessionMountID   string
 	sessionLocalRoot string
 
+	// segmentWorkers and segmentSize control parallel multi-range downloads
+	// of large files. Configured via WithSegmentedDownloads.
+	segmentWorkers int
+	segmentSize    int64
+	sleep          retry.SleepFunc
+
 	// minFreeSpace is the minimum free disk space (bytes) required before
 	// downloads. Zero disables the check (R-6.4.7). Configured via WithDiskCheck.
 	minFreeSpace int64
@@ -197,6 +206,11 @@ func NewTransferManager(
 		sessionStore: store,
 		logger:       logger,
 		hashFunc:     ComputeQuickXorHash,
+		// Segmenting is on by default; it only engages for downloaders that
+		// can read byte ranges and files of at least two segments.
+		segmentWorkers: defaultDownloadSegmentWorkers,
+		segmentSize:    defaultDownloadSegmentSize,
+		sleep:          retry.TimeSleep,
 	}
 
 	for _, opt := range opts {
@@ -312,18 +326,21 @@ func (tm *TransferManager) downloadToVerifiedPartial(
 
 	// Fast path: no remote hash means no verification — download once, skip retry loop.
 	if remoteHash == "" {
-		localHash, size, err = tm.downloadToPartial(ctx, driveID, itemID, partialPath)
+		localHash, size, err = tm.downloadToPartial(ctx, driveID, itemID, partialPath, remoteContent{Size: opts.RemoteSize})
 		if err != nil {
 			return "", 0, "", false, err
 		}
 
-		tm.logger.Warn("remote item has no content hash, skipping verification",
-			slog.String("target", targetPath),
-		)
+		if !opts.Converted {
+			tm.logger.Warn("remote item has no content hash, skipping verification",
+				slog.String("target", targetPath),
+			)
+		}
 		return localHash, size, remoteHash, hashVerified, nil
 	}
 
-	return tm.downloadWithHashRetry(ctx, driveID, itemID, partialPath, targetPath, remoteHash, opts.MaxHashRetries)
+	return tm.downloadWithHashRetry(ctx, driveID, itemID, partialPath, targetPath,
+		remoteContent{Size: opts.RemoteSize, Hash: remoteHash}, opts.MaxHashRetries)
 }
 
 func downloadPartialPath(targetPath string) string {
@@ -344,12 +361,14 @@ func IsOwnedTransferArtifactName(name string) bool {
 // downloadWithHashRetry downloads a file and retries on hash mismatch. On
 // mismatch we discard and re-download the entire file. If the first attempt
 // was a resume, the resume bytes are wasted — acceptable because mismatches
-// are rare and correctness trumps transfer savings.
+// are rare and correctness trumps transfer savings. Only the first attempt may
+// be segmented: a segmented read trusts the caller's size, so retries stream
+// the file whole and cannot be misled by stale size metadata.
 func (tm *TransferManager) downloadWithHashRetry(
-	ctx context.Context, driveID driveid.ID, itemID, partialPath, targetPath, remoteHash string,
-	maxHashRetries int,
+	ctx context.Context, driveID driveid.ID, itemID, partialPath, targetPath string,
+	remote remoteContent, maxHashRetries int,
 ) (localHash string, size int64, effectiveRemoteHash string, hashVerified bool, err error) {
-	effectiveRemoteHash = remoteHash
+	effectiveRemoteHash = remote.Hash
 	hashVerified = true
 
 	// Go 1.22 range-over-int: `range N` iterates 0..N-1, so `range maxRetries+1`
@@ -357,7 +376,8 @@ func (tm *TransferManager) downloadWithHashRetry(
 	maxRetries := resolveMaxRetries(maxHashRetries)
 
 	for attempt := range maxRetries + 1 {
-		localHash, size, err = tm.downloadToPartial(ctx, driveID, itemID, partialPath)
+		localHash, size, err = tm.downloadToPartial(ctx, driveID, itemID, partialPath, remote)
+		remote = remoteContent{}
 		if err != nil {
 			return "", 0, "", false, err
 		}
@@ -399,14 +419,21 @@ func (tm *TransferManager) downloadWithHashRetry(
 
 // downloadToPartial streams a remote file to a .partial file while computing
 // the QuickXorHash. If a .partial file already exists and the downloader
-// supports range requests, it resumes from the existing file.
+// supports range requests, it resumes from the existing file. Files large
+// enough to split are fetched as concurrent segments instead.
 //
 // The .partial file is opened before stat to avoid a TOCTOU race where the
 // file could be deleted between stat and open (B-211). If open fails with
 // ErrNotExist, we fall through to a fresh download.
 func (tm *TransferManager) downloadToPartial(
-	ctx context.Context, driveID driveid.ID, itemID, partialPath string,
+	ctx context.Context, driveID driveid.ID, itemID, partialPath string, remote remoteContent,
 ) (string, int64, error) {
+	if rd := tm.segmentedDownloader(remote); rd != nil {
+		return tm.segmentedDownload(ctx, rd, driveID, itemID, partialPath, remote)
+	}
+
+	tm.discardSegmentedPartial(partialPath)
+
 	// Attempt resume: open existing .partial, then stat the handle.
 	if rd, ok := tm.downloads.(RangeDownloader); ok {
 		f, openErr := localpath.OpenFile(partialPath, os.O_APPEND|os.O_WRONLY, downloadTempFilePerms)
@@ -609,12 +636,13 @@ func (tm *TransferManager) uploadGraphItem(
 	size int64,
 	mtime time.Time,
 	progress graph.ProgressFunc,
+	conflict graph.ConflictBehavior,
 ) (*graph.Item, error) {
 	if size > graph.SimpleUploadMaxSize && tm.sessionStore != nil && hasSessionUploader {
-		return tm.sessionUpload(ctx, su, content, driveID, parentID, name, localPath, localHash, size, mtime, progress)
+		return tm.sessionUpload(ctx, su, content, driveID, parentID, name, localPath, localHash, size, mtime, progress, conflict)
 	}
 
-	item, err := tm.uploads.Upload(ctx, driveID, parentID, name, content, size, mtime, progress)
+	item, err := tm.uploads.Upload(ctx, driveID, parentID, name, content, size, mtime, progress, conflict)
 	if err != nil {
 		return nil, fmt.Errorf("uploading %s: %w", localPath, err)
 	}
@@ -706,6 +734,7 @@ func (tm *TransferManager) UploadFile(
 		size,
 		mtime,
 		progress,
+		opts.ConflictBehavior,
 	)
 	if err != nil {
 		return nil, err
@@ -879,7 +908,7 @@ func (tm *TransferManager) uploadExistingItem(
 func (tm *TransferManager) sessionUpload(
 	ctx context.Context, su SessionUploader, content io.ReaderAt,
 	driveID driveid.ID, parentID, name, localPath, localHash string,
-	size int64, mtime time.Time, progress graph.ProgressFunc,
+	size int64, mtime time.Time, progress graph.ProgressFunc, conflict graph.ConflictBehavior,
 ) (*graph.Item, error) {
 	tm.logger.Debug("sessionUpload",
 		slog.String("path", localPath),
@@ -920,7 +949,7 @@ func (tm *TransferManager) sessionUpload(
 	}
 
 	// Fresh session-based upload.
-	session, err := su.CreateUploadSession(ctx, driveID, parentID, name, size, mtime)
+	session, err := su.CreateUploadSession(ctx, driveID, parentID, name, size, mtime, conflict)
 	if err != nil {
 		return nil, fmt.Errorf("creating upload session for %s: %w", localPath, err)
 	}
@@ -66,7 +66,7 @@ var (
 	_ SessionUploader = (*tmMockUploader)(nil)
 )
 
-func (m *tmMockUploader) Upload(ctx context.Context, driveID driveid.ID, parentID, name string, content io.ReaderAt, size int64, mtime time.Time, progress graph.ProgressFunc) (*graph.Item, error) {
+func (m *tmMockUploader) Upload(ctx context.Context, driveID driveid.ID, parentID, name string, content io.ReaderAt, size int64, mtime time.Time, progress graph.ProgressFunc, _ graph.ConflictBehavior) (*graph.Item, error) {
 	if m.uploadFn != nil {
 		return m.uploadFn(ctx, driveID, parentID, name, content, size, mtime, progress)
 	}
@@ -74,7 +74,7 @@ func (m *tmMockUploader) Upload(ctx context.Context, driveID driveid.ID, parentI
 	return nil, fmt.Errorf("Upload not mocked")
 }
 
-func (m *tmMockUploader) CreateUploadSession(ctx context.Context, driveID driveid.ID, parentID, name string, size int64, mtime time.Time) (*graph.UploadSession, error) {
+func (m *tmMockUploader) CreateUploadSession(ctx context.Context, driveID driveid.ID, parentID, name string, size int64, mtime time.Time, _ graph.ConflictBehavior) (*graph.UploadSession, error) {
 	if m.createUploadSessionFn != nil {
 		return m.createUploadSessionFn(ctx, driveID, parentID, name, size, mtime)
 	}
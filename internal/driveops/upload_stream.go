@@ -0,0 +1,103 @@
+package driveops
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/pkg/quickxorhash"
+)
+
+// errStreamUploadUnsupported is returned when the configured uploader cannot
+// accept content of unknown length.
+var errStreamUploadUnsupported = errors.New("upload: uploader does not support streamed content")
+
+// UploadStream uploads content of unknown length, such as stdin, to
+// parentID/name. The QuickXorHash is computed while the stream is read and
+// checked against the hash the server reports for the finished item; a
+// mismatch returns ErrContentHashMismatch. Streams have no local file to
+// resume from, so no upload session is persisted. opts.Mtime defaults to
+// the current time.
+func (tm *TransferManager) UploadStream(
+	ctx context.Context, driveID driveid.ID, parentID, name string, r io.Reader, opts UploadOpts,
+) (*UploadResult, error) {
+	startedAt := time.Now()
+
+	if err := validateUploadParams(parentID, name, "-"); err != nil {
+		return nil, err
+	}
+
+	su, ok := tm.uploads.(StreamUploader)
+	if !ok {
+		return nil, errStreamUploadUnsupported
+	}
+
+	mtime := opts.Mtime
+	if mtime.IsZero() {
+		mtime = startedAt
+	}
+
+	tm.logger.Debug("UploadStream",
+		slog.String("drive_id", driveID.String()),
+		slog.String("parent_id", parentID),
+		slog.String("name", name),
+	)
+
+	h := quickxorhash.New()
+	src := io.TeeReader(&maxSizeReader{r: r, remaining: MaxOneDriveFileSize}, h)
+
+	item, size, err := su.UploadStream(ctx, driveID, parentID, name, src, mtime, opts.Progress, opts.ConflictBehavior)
+	if err != nil {
+		return nil, fmt.Errorf("uploading stream to %s: %w", name, err)
+	}
+
+	if item == nil {
+		return nil, fmt.Errorf("upload of stream to %s returned nil item", name)
+	}
+
+	localHash := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	switch {
+	case item.QuickXorHash == "":
+		tm.logger.Warn("server reported no QuickXorHash for streamed upload; content not verified",
+			slog.String("item_id", item.ID),
+			slog.String("name", name),
+		)
+	case item.QuickXorHash != localHash:
+		return nil, fmt.Errorf("%w: streamed upload %s: sent %s, server reports %s",
+			ErrContentHashMismatch, name, localHash, item.QuickXorHash)
+	}
+
+	tm.logger.Debug("stream upload complete",
+		slog.String("item_id", item.ID),
+		slog.Int64("size", size),
+	)
+	recordUploadPerf(ctx, size, startedAt)
+
+	return &UploadResult{Item: item, LocalHash: localHash, Size: size, Mtime: mtime}, nil
+}
+
+// maxSizeReader fails with ErrFileExceedsOneDriveLimit once more than
+// remaining bytes have been read, so an oversized stream stops before the
+// server rejects it at the end.
+type maxSizeReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (m *maxSizeReader) Read(p []byte) (int, error) {
+	n, err := m.r.Read(p)
+	m.remaining -= int64(n)
+
+	if m.remaining < 0 {
+		return n, fmt.Errorf("%w: stream exceeds OneDrive 250 GB limit (%d bytes)",
+			ErrFileExceedsOneDriveLimit, MaxOneDriveFileSize)
+	}
+
+	return n, err //nolint:wrapcheck // io.Reader contract: io.EOF must reach callers unwrapped
+}
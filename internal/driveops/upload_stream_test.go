@@ -0,0 +1,111 @@
+package driveops
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// tmMockStreamUploader implements Uploader + StreamUploader by draining the
+// stream and reporting remoteHash for the created item.
+type tmMockStreamUploader struct {
+	tmMockUploader
+	remoteHash string
+	received   string
+	mtime      time.Time
+	conflict   graph.ConflictBehavior
+}
+
+var _ StreamUploader = (*tmMockStreamUploader)(nil)
+
+func (m *tmMockStreamUploader) UploadStream(
+	_ context.Context, _ driveid.ID, _, name string, r io.Reader, mtime time.Time, _ graph.ProgressFunc, conflict graph.ConflictBehavior,
+) (*graph.Item, int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	m.received = string(data)
+	m.mtime = mtime
+	m.conflict = conflict
+
+	return &graph.Item{ID: "streamed", Name: name, QuickXorHash: m.remoteHash}, int64(len(data)), nil
+}
+
+// Validates: R-1.3.7
+func TestUploadStream_VerifiesServerHash(t *testing.T) {
+	t.Parallel()
+
+	content := "pg_dump output"
+	ul := &tmMockStreamUploader{remoteHash: tmHashBytes([]byte(content))}
+	tm := newTestTM(nil, ul, nil)
+
+	result, err := tm.UploadStream(t.Context(), driveid.New("d"), "parent", "db.sql", strings.NewReader(content), UploadOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, "streamed", result.Item.ID)
+	assert.Equal(t, int64(len(content)), result.Size)
+	assert.Equal(t, ul.remoteHash, result.LocalHash)
+	assert.Equal(t, content, ul.received)
+	assert.False(t, ul.mtime.IsZero(), "stdin uploads default mtime to now")
+}
+
+// Validates: R-1.3.10
+func TestUploadStream_PassesConflictBehavior(t *testing.T) {
+	t.Parallel()
+
+	ul := &tmMockStreamUploader{}
+	tm := newTestTM(nil, ul, nil)
+
+	_, err := tm.UploadStream(t.Context(), driveid.New("d"), "parent", "db.sql", strings.NewReader("x"),
+		UploadOpts{ConflictBehavior: graph.ConflictRename})
+	require.NoError(t, err)
+	assert.Equal(t, graph.ConflictRename, ul.conflict)
+}
+
+// Validates: R-1.3.7
+func TestUploadStream_HashMismatchFails(t *testing.T) {
+	t.Parallel()
+
+	ul := &tmMockStreamUploader{remoteHash: tmHashBytes([]byte("other"))}
+	tm := newTestTM(nil, ul, nil)
+
+	_, err := tm.UploadStream(t.Context(), driveid.New("d"), "parent", "db.sql", strings.NewReader("content"), UploadOpts{})
+	require.ErrorIs(t, err, ErrContentHashMismatch)
+}
+
+func TestUploadStream_MissingServerHashIsNotAnError(t *testing.T) {
+	t.Parallel()
+
+	tm := newTestTM(nil, &tmMockStreamUploader{}, nil)
+
+	result, err := tm.UploadStream(t.Context(), driveid.New("d"), "parent", "db.sql", strings.NewReader("content"), UploadOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("content")), result.Size)
+}
+
+func TestUploadStream_RequiresStreamUploader(t *testing.T) {
+	t.Parallel()
+
+	tm := newTestTM(nil, &tmMockUploader{}, nil)
+
+	_, err := tm.UploadStream(t.Context(), driveid.New("d"), "parent", "db.sql", strings.NewReader("x"), UploadOpts{})
+	require.ErrorIs(t, err, errStreamUploadUnsupported)
+}
+
+func TestMaxSizeReader_FailsPastLimit(t *testing.T) {
+	t.Parallel()
+
+	r := &maxSizeReader{r: strings.NewReader("0123456789"), remaining: 4}
+
+	_, err := io.ReadAll(r)
+	require.ErrorIs(t, err, ErrFileExceedsOneDriveLimit)
+}
@@ -0,0 +1,130 @@
+package driveops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+// FolderUsage is the aggregated storage of one folder in a usage report.
+// Path is relative to the enumeration root; "" is the root itself.
+type FolderUsage struct {
+	Path        string
+	Depth       int
+	Size        int64 // bytes of current file content below the folder
+	VersionSize int64 // bytes held by older file versions below the folder
+	Files       int
+	Folders     int
+}
+
+// SummarizeUsage rolls file sizes from a remote tree up into every ancestor
+// folder and returns the root plus every folder no deeper than maxDepth
+// (negative = no limit), sorted by path. Folder sizes are summed from files
+// rather than taken from the folder facet, so they always agree with the
+// enumeration. versionSizes maps file IDs to bytes held by older versions and
+// may be nil.
+func SummarizeUsage(entries []RemoteTreeEntry, maxDepth int, versionSizes map[string]int64) []FolderUsage {
+	byPath := map[string]*FolderUsage{"": {}}
+
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Item.IsFolder {
+			folderUsage(byPath, entry.Path)
+		}
+
+		for _, ancestor := range usageAncestors(entry.Path) {
+			usage := folderUsage(byPath, ancestor)
+			if entry.Item.IsFolder {
+				usage.Folders++
+				continue
+			}
+
+			usage.Files++
+			usage.Size += entry.Item.Size
+			usage.VersionSize += versionSizes[entry.Item.ID]
+		}
+	}
+
+	out := make([]FolderUsage, 0, len(byPath))
+	for _, usage := range byPath {
+		if maxDepth < 0 || usage.Depth <= maxDepth {
+			out = append(out, *usage)
+		}
+	}
+
+	slices.SortFunc(out, func(a, b FolderUsage) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return out
+}
+
+func folderUsage(byPath map[string]*FolderUsage, folderPath string) *FolderUsage {
+	usage, ok := byPath[folderPath]
+	if !ok {
+		usage = &FolderUsage{Path: folderPath, Depth: strings.Count(folderPath, "/") + 1}
+		byPath[folderPath] = usage
+	}
+
+	return usage
+}
+
+// usageAncestors returns every folder path strictly above itemPath, from the
+// immediate parent up to the root ("").
+func usageAncestors(itemPath string) []string {
+	var ancestors []string
+
+	for dir := path.Dir(itemPath); ; dir = path.Dir(dir) {
+		if dir == "." {
+			return append(ancestors, "")
+		}
+
+		ancestors = append(ancestors, dir)
+	}
+}
+
+// VersionUsage returns, per file ID, the bytes held by versions older than
+// the current content. It issues one versions request per file, so callers
+// should only ask for it explicitly. Shortcut placeholders and files deleted
+// since the enumeration are skipped.
+func (s *Session) VersionUsage(ctx context.Context, entries []RemoteTreeEntry) (map[string]int64, error) {
+	sizes := make(map[string]int64)
+
+	for i := range entries {
+		item := &entries[i].Item
+		if item.IsFolder || item.RemoteItemID != "" {
+			continue
+		}
+
+		versions, err := s.Meta.ListItemVersions(ctx, s.DriveID, item.ID)
+		if err != nil {
+			if errors.Is(err, graph.ErrNotFound) {
+				continue
+			}
+
+			return nil, fmt.Errorf("list versions of %q: %w", entries[i].Path, err)
+		}
+
+		// The first entry is the current version, already counted as Size.
+		for j := 1; j < len(versions); j++ {
+			sizes[item.ID] += versions[j].Size
+		}
+	}
+
+	return sizes, nil
+}
+
+// Drive returns the session drive's metadata, including its quota.
+func (s *Session) Drive(ctx context.Context) (*graph.Drive, error) {
+	drive, err := s.Meta.Drive(ctx, s.DriveID)
+	if err != nil {
+		return nil, fmt.Errorf("get drive: %w", err)
+	}
+
+	return drive, nil
+}
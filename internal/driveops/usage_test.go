@@ -0,0 +1,74 @@
+package driveops
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/graph"
+)
+
+func usageTestEntries() []RemoteTreeEntry {
+	return []RemoteTreeEntry{
+		{Path: "Docs", Item: graph.Item{ID: "docs", IsFolder: true, Size: 999}},
+		{Path: "Docs/Empty", Item: graph.Item{ID: "empty", IsFolder: true}},
+		{Path: "Docs/Sub", Item: graph.Item{ID: "sub", IsFolder: true}},
+		{Path: "Docs/Sub/b.bin", Item: graph.Item{ID: "b", Size: 200}},
+		{Path: "Docs/a.txt", Item: graph.Item{ID: "a", Size: 100}},
+		{Path: "top.txt", Item: graph.Item{ID: "top", Size: 5}},
+	}
+}
+
+// Validates: R-1.13
+func TestSummarizeUsage_RollsFileSizesUpToAncestors(t *testing.T) {
+	t.Parallel()
+
+	usage := SummarizeUsage(usageTestEntries(), -1, map[string]int64{"b": 50})
+
+	require.Len(t, usage, 4)
+	assert.Equal(t, FolderUsage{Path: "", Size: 305, VersionSize: 50, Files: 3, Folders: 3}, usage[0])
+	assert.Equal(t, FolderUsage{Path: "Docs", Depth: 1, Size: 300, VersionSize: 50, Files: 2, Folders: 2}, usage[1])
+	assert.Equal(t, FolderUsage{Path: "Docs/Empty", Depth: 2}, usage[2])
+	assert.Equal(t, FolderUsage{Path: "Docs/Sub", Depth: 2, Size: 200, VersionSize: 50, Files: 1}, usage[3])
+}
+
+// Validates: R-1.13.1
+func TestSummarizeUsage_DepthLimitsRowsNotTotals(t *testing.T) {
+	t.Parallel()
+
+	usage := SummarizeUsage(usageTestEntries(), 1, nil)
+
+	require.Len(t, usage, 2)
+	assert.Equal(t, int64(305), usage[0].Size)
+	assert.Equal(t, "Docs", usage[1].Path)
+	assert.Equal(t, int64(300), usage[1].Size)
+}
+
+// Validates: R-1.13.2
+func TestSession_VersionUsage_SumsOlderVersions(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/drives/abcdef0123456789/items/a/versions":
+			writeTestResponsef(t, w, `{"value":[{"id":"2.0","size":100},{"id":"1.0","size":40}]}`)
+		case "/drives/abcdef0123456789/items/b/versions":
+			w.WriteHeader(http.StatusNotFound)
+			writeTestResponsef(t, w, `{"error":{"code":"itemNotFound"}}`)
+		default:
+			assert.Failf(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	sizes, err := s.VersionUsage(t.Context(), []RemoteTreeEntry{
+		{Path: "Docs", Item: graph.Item{ID: "docs", IsFolder: true}},
+		{Path: "Docs/a.txt", Item: graph.Item{ID: "a", Size: 100}},
+		{Path: "Docs/b.txt", Item: graph.Item{ID: "b", Size: 10}},
+		{Path: "Team", Item: graph.Item{ID: "sc", RemoteItemID: "remote", RemoteIsFolder: true}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a": 40}, sizes)
+}
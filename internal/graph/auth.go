@@ -9,10 +9,36 @@ import (
 // Azure AD application registered for onedrive-go (public client, multi-tenant + personal).
 const defaultClientID = "8efac532-bbe7-4bc5-919c-1443ccab860a"
 
+// LoginAccess selects the file permission a login asks for.
+type LoginAccess int
+
+const (
+	// LoginAccessFull asks for read/write access to every file the user can reach.
+	LoginAccessFull LoginAccess = iota
+	// LoginAccessReadOnly asks for read access only (Files.Read.All).
+	LoginAccessReadOnly
+	// LoginAccessAppFolder asks for read/write access to the app's own folder
+	// (special/approot) and nothing else.
+	LoginAccessAppFolder
+)
+
 func defaultScopes() []string {
+	return loginScopes(LoginAccessFull)
+}
+
+func loginScopes(access LoginAccess) []string {
+	files := "Files.ReadWrite.All"
+	switch access {
+	case LoginAccessReadOnly:
+		files = "Files.Read.All"
+	case LoginAccessAppFolder:
+		files = "Files.ReadWrite.AppFolder"
+	case LoginAccessFull:
+	}
+
 	return []string{
 		"offline_access",
-		"Files.ReadWrite.All",
+		files,
 		"User.Read",
 	}
 }
@@ -35,14 +61,17 @@ type DeviceAuth struct {
 // will fail. Callers should pass context.Background() for long-lived sessions.
 //
 // The caller is responsible for computing tokenPath (via config.DriveTokenPath).
-// This decouples graph/ from config/ — graph/ has no config import.
+// This decouples graph/ from config/ — graph/ has no config import. access
+// selects the scopes requested; refreshes reuse whatever was granted.
 func Login(
 	ctx context.Context,
 	tokenPath string,
+	access LoginAccess,
 	display func(DeviceAuth),
 	logger *slog.Logger,
 ) (TokenSource, error) {
 	cfg := oauthConfig(tokenPath, logger)
+	cfg.Scopes = loginScopes(access)
 
 	return doLogin(ctx, tokenPath, cfg, display, logger)
 }
@@ -80,10 +109,12 @@ type callbackResult struct {
 func LoginWithBrowser(
 	ctx context.Context,
 	tokenPath string,
+	access LoginAccess,
 	openURL func(context.Context, string) error,
 	logger *slog.Logger,
 ) (TokenSource, error) {
 	cfg := oauthConfig(tokenPath, logger)
+	cfg.Scopes = loginScopes(access)
 
 	return doAuthCodeLogin(ctx, tokenPath, cfg, openURL, logger)
 }
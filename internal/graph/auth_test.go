@@ -495,6 +495,13 @@ func TestOAuthConfig_Defaults(t *testing.T) {
 	assert.NotEmpty(t, cfg.Endpoint.TokenURL)
 }
 
+// Validates: R-3.1.9
+func TestLoginScopes_LeastPrivilegeModes(t *testing.T) {
+	assert.Equal(t, []string{"offline_access", "Files.ReadWrite.All", "User.Read"}, loginScopes(LoginAccessFull))
+	assert.Equal(t, []string{"offline_access", "Files.Read.All", "User.Read"}, loginScopes(LoginAccessReadOnly))
+	assert.Equal(t, []string{"offline_access", "Files.ReadWrite.AppFolder", "User.Read"}, loginScopes(LoginAccessAppFolder))
+}
+
 func TestTokenPath_UsesDriveName(t *testing.T) {
 	// Verify that oauthConfig passes through the token path correctly
 	// by checking that OnTokenChange writes to the expected location.
@@ -0,0 +1,357 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/tonimelisma/onedrive-go/internal/retry"
+)
+
+// maxBatchRequests is Graph's limit on sub-requests per JSON batch POST.
+const maxBatchRequests = 20
+
+// BatchRequest is one sub-request of a JSON batch. URL is relative to the
+// API version root, e.g. "/drives/{driveId}/items/{itemId}". Body, when set,
+// is sent as JSON. DependsOn names sub-requests that must finish first; a
+// dependency chain always travels in the same POST.
+type BatchRequest struct {
+	ID        string
+	Method    string
+	URL       string
+	Headers   map[string]string
+	Body      any
+	DependsOn []string
+}
+
+// BatchResponse is the outcome of one sub-request. Err is a *GraphError for
+// non-2xx statuses, so errors.Is works against the usual sentinels.
+type BatchResponse struct {
+	ID     string
+	Status int
+	Body   json.RawMessage
+	Err    error
+}
+
+type batchRequestJSON struct {
+	ID        string            `json:"id"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Body      any               `json:"body,omitempty"`
+	DependsOn []string          `json:"dependsOn,omitempty"`
+}
+
+type batchEnvelope struct {
+	Requests []batchRequestJSON `json:"requests"`
+}
+
+type batchResponseJSON struct {
+	ID      string            `json:"id"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+type batchResultEnvelope struct {
+	Responses []batchResponseJSON `json:"responses"`
+}
+
+// Batch sends reqs through Graph JSON batching, at most 20 sub-requests per
+// POST, and returns one response per request in request order. Sub-requests
+// that come back throttled (429/503), and those that failed only because a
+// throttled dependency did, are resent on their own after the longest
+// Retry-After; whatever is still throttled after the last attempt is
+// returned with its error. The returned error covers invalid requests and
+// failures of a batch POST itself.
+func (c *Client) Batch(ctx context.Context, reqs []BatchRequest) ([]BatchResponse, error) {
+	index, err := indexBatchRequests(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchResponse, len(reqs))
+	pending := make([]int, len(reqs))
+
+	for i := range reqs {
+		pending[i] = i
+	}
+
+	policy := c.batchThrottlePolicy
+
+	for attempt := 0; ; attempt++ {
+		chunks, err := chunkBatchRequests(reqs, index, pending)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, chunk := range chunks {
+			if err := c.sendBatchChunk(ctx, reqs, chunk, results); err != nil {
+				return nil, err
+			}
+		}
+
+		var retryAfter time.Duration
+		pending, retryAfter = throttledBatchRequests(reqs, index, results)
+
+		if len(pending) == 0 || attempt+1 >= policy.MaxAttempts {
+			return results, nil
+		}
+
+		wait := policy.Delay(attempt)
+		if retryAfter > wait {
+			wait = min(retryAfter, policy.Max)
+		}
+
+		c.logger.Debug("retrying throttled batch sub-requests",
+			slog.Int("count", len(pending)),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("backoff", wait),
+		)
+
+		if err := retry.TimeSleep(ctx, wait); err != nil {
+			return nil, fmt.Errorf("graph: batch retry canceled: %w", err)
+		}
+	}
+}
+
+// indexBatchRequests maps request IDs to positions and rejects duplicate or
+// empty IDs and dependencies on requests that come later or do not exist.
+func indexBatchRequests(reqs []BatchRequest) (map[string]int, error) {
+	index := make(map[string]int, len(reqs))
+
+	for i := range reqs {
+		if reqs[i].ID == "" {
+			return nil, fmt.Errorf("graph: batch request %d has no id", i)
+		}
+
+		if _, dup := index[reqs[i].ID]; dup {
+			return nil, fmt.Errorf("graph: duplicate batch request id %q", reqs[i].ID)
+		}
+
+		for _, dep := range reqs[i].DependsOn {
+			if _, ok := index[dep]; !ok {
+				return nil, fmt.Errorf("graph: batch request %q depends on unknown or later request %q", reqs[i].ID, dep)
+			}
+		}
+
+		index[reqs[i].ID] = i
+	}
+
+	return index, nil
+}
+
+// chunkBatchRequests packs the pending requests into POST-sized chunks,
+// keeping every dependsOn chain in one chunk and request order within it.
+func chunkBatchRequests(reqs []BatchRequest, index map[string]int, pending []int) ([][]int, error) {
+	parent := make(map[int]int, len(pending))
+	for _, i := range pending {
+		parent[i] = i
+	}
+
+	find := func(i int) int {
+		for parent[i] != i {
+			i = parent[i]
+		}
+
+		return i
+	}
+
+	for _, i := range pending {
+		for _, dep := range reqs[i].DependsOn {
+			if _, ok := parent[index[dep]]; !ok {
+				continue
+			}
+
+			a, b := find(i), find(index[dep])
+			parent[max(a, b)] = min(a, b)
+		}
+	}
+
+	var roots []int
+
+	chains := make(map[int][]int, len(pending))
+
+	for _, i := range pending {
+		root := find(i)
+		if _, seen := chains[root]; !seen {
+			roots = append(roots, root)
+		}
+
+		chains[root] = append(chains[root], i)
+	}
+
+	var chunks [][]int
+
+	var current []int
+
+	for _, root := range roots {
+		chain := chains[root]
+		if len(chain) > maxBatchRequests {
+			return nil, fmt.Errorf("graph: batch dependency chain of %d requests exceeds the limit of %d",
+				len(chain), maxBatchRequests)
+		}
+
+		if len(current)+len(chain) > maxBatchRequests {
+			chunks = append(chunks, current)
+			current = nil
+		}
+
+		current = append(current, chain...)
+	}
+
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+
+	return chunks, nil
+}
+
+// sendBatchChunk POSTs one chunk and stores each sub-response in results.
+// Dependencies outside the chunk already succeeded on an earlier attempt, so
+// they are dropped from the wire request.
+func (c *Client) sendBatchChunk(ctx context.Context, reqs []BatchRequest, chunk []int, results []BatchResponse) error {
+	inChunk := make(map[string]int, len(chunk))
+	envelope := batchEnvelope{Requests: make([]batchRequestJSON, 0, len(chunk))}
+
+	for _, i := range chunk {
+		inChunk[reqs[i].ID] = i
+	}
+
+	for _, i := range chunk {
+		envelope.Requests = append(envelope.Requests, newBatchRequestJSON(&reqs[i], inChunk))
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("graph: encoding batch request: %w", err)
+	}
+
+	c.logger.Debug("sending batch", slog.Int("requests", len(chunk)))
+
+	resp, err := c.do(ctx, http.MethodPost, "/$batch", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var decoded batchResultEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return fmt.Errorf("graph: decoding batch response: %w", err)
+	}
+
+	for i := range decoded.Responses {
+		sub := &decoded.Responses[i]
+
+		pos, ok := inChunk[sub.ID]
+		if !ok {
+			continue
+		}
+
+		results[pos] = newBatchResponse(sub)
+		delete(inChunk, sub.ID)
+	}
+
+	if len(inChunk) > 0 {
+		return fmt.Errorf("graph: batch response is missing %d of %d sub-responses", len(inChunk), len(chunk))
+	}
+
+	return nil
+}
+
+func newBatchRequestJSON(req *BatchRequest, inChunk map[string]int) batchRequestJSON {
+	out := batchRequestJSON{
+		ID:      req.ID,
+		Method:  req.Method,
+		URL:     req.URL,
+		Headers: req.Headers,
+		Body:    req.Body,
+	}
+
+	for _, dep := range req.DependsOn {
+		if _, ok := inChunk[dep]; ok {
+			out.DependsOn = append(out.DependsOn, dep)
+		}
+	}
+
+	// Graph rejects a sub-request body without an explicit Content-Type.
+	if req.Body != nil && batchHeader(req.Headers, "Content-Type") == "" {
+		out.Headers = make(map[string]string, len(req.Headers)+1)
+		for k, v := range req.Headers {
+			out.Headers[k] = v
+		}
+
+		out.Headers["Content-Type"] = "application/json"
+	}
+
+	return out
+}
+
+func newBatchResponse(sub *batchResponseJSON) BatchResponse {
+	out := BatchResponse{ID: sub.ID, Status: sub.Status, Body: sub.Body}
+	if sub.Status >= http.StatusOK && sub.Status < http.StatusMultipleChoices {
+		return out
+	}
+
+	header := make(http.Header, len(sub.Headers))
+	for k, v := range sub.Headers {
+		header.Set(k, v)
+	}
+
+	retryAfter := parseRetryAfter(&http.Response{StatusCode: sub.Status, Header: header})
+	out.Err = buildGraphError(sub.Status, header.Get("request-id"), retryAfter, sub.Body)
+
+	return out
+}
+
+// throttledBatchRequests returns the requests to resend, in request order,
+// and the longest Retry-After among them. A 424 counts when one of its
+// dependencies is being resent.
+func throttledBatchRequests(reqs []BatchRequest, index map[string]int, results []BatchResponse) ([]int, time.Duration) {
+	var (
+		pending    []int
+		retryAfter time.Duration
+	)
+
+	resend := make(map[int]bool)
+
+	for i := range results {
+		switch results[i].Status {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			resend[i] = true
+
+			var ge *GraphError
+			if errors.As(results[i].Err, &ge) && ge.RetryAfter > retryAfter {
+				retryAfter = ge.RetryAfter
+			}
+		case http.StatusFailedDependency:
+			for _, dep := range reqs[i].DependsOn {
+				if resend[index[dep]] {
+					resend[i] = true
+					break
+				}
+			}
+		}
+
+		if resend[i] {
+			pending = append(pending, i)
+		}
+	}
+
+	return pending, retryAfter
+}
+
+func batchHeader(headers map[string]string, name string) string {
+	for k, v := range headers {
+		if http.CanonicalHeaderKey(k) == http.CanonicalHeaderKey(name) {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,256 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// batchTestServer answers $batch POSTs through respond, which sees each
+// sub-request and the 1-based POST number, and records every POST.
+type batchTestServer struct {
+	mu    sync.Mutex
+	posts [][]batchRequestJSON
+}
+
+func (s *batchTestServer) start(
+	t *testing.T, respond func(post int, req batchRequestJSON) (status int, headers map[string]string, body string),
+) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/$batch", r.URL.Path)
+
+		var env batchEnvelope
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&env))
+
+		s.mu.Lock()
+		s.posts = append(s.posts, env.Requests)
+		post := len(s.posts)
+		s.mu.Unlock()
+
+		// Answer in reverse order: Graph does not promise request order.
+		out := batchResultEnvelope{}
+		for i := len(env.Requests) - 1; i >= 0; i-- {
+			status, headers, body := respond(post, env.Requests[i])
+			sub := batchResponseJSON{ID: env.Requests[i].ID, Status: status, Headers: headers}
+			if body != "" {
+				sub.Body = json.RawMessage(body)
+			}
+
+			out.Responses = append(out.Responses, sub)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(out))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func batchTestRequests(n int) []BatchRequest {
+	reqs := make([]BatchRequest, 0, n)
+	for i := 1; i <= n; i++ {
+		reqs = append(reqs, BatchRequest{ID: strconv.Itoa(i), Method: http.MethodGet, URL: fmt.Sprintf("/drives/d/items/i%d", i)})
+	}
+
+	return reqs
+}
+
+// Validates: R-6.8.17
+func TestBatch_SplitsIntoPostsOfTwentyAndMapsResponsesBack(t *testing.T) {
+	var server batchTestServer
+	srv := server.start(t, func(_ int, req batchRequestJSON) (int, map[string]string, string) {
+		return http.StatusOK, nil, fmt.Sprintf(`{"url":%q}`, req.URL)
+	})
+
+	client := newTestClient(t, srv.URL)
+	resps, err := client.Batch(t.Context(), batchTestRequests(45))
+	require.NoError(t, err)
+
+	require.Len(t, server.posts, 3)
+	assert.Len(t, server.posts[0], 20)
+	assert.Len(t, server.posts[1], 20)
+	assert.Len(t, server.posts[2], 5)
+
+	require.Len(t, resps, 45)
+	for i := range resps {
+		require.NoError(t, resps[i].Err)
+		assert.Equal(t, strconv.Itoa(i+1), resps[i].ID)
+		assert.JSONEq(t, fmt.Sprintf(`{"url":"/drives/d/items/i%d"}`, i+1), string(resps[i].Body))
+	}
+}
+
+// Validates: R-6.8.17
+func TestBatch_KeepsDependencyChainsInOnePost(t *testing.T) {
+	var server batchTestServer
+	srv := server.start(t, func(int, batchRequestJSON) (int, map[string]string, string) {
+		return http.StatusNoContent, nil, ""
+	})
+
+	reqs := batchTestRequests(22)
+	// 18 -> 19 -> 20 -> 21 would straddle the 20-request boundary.
+	for i := 18; i <= 20; i++ {
+		reqs[i].DependsOn = []string{strconv.Itoa(i)}
+	}
+
+	client := newTestClient(t, srv.URL)
+	_, err := client.Batch(t.Context(), reqs)
+	require.NoError(t, err)
+
+	require.Len(t, server.posts, 2)
+	assert.Len(t, server.posts[0], 17)
+	require.Len(t, server.posts[1], 5)
+	assert.Equal(t, "18", server.posts[1][0].ID)
+	assert.Equal(t, []string{"20"}, server.posts[1][3].DependsOn)
+	assert.Equal(t, "22", server.posts[1][4].ID)
+}
+
+// Validates: R-6.8.17
+func TestBatch_RetriesThrottledSubRequestsWithTheirDependents(t *testing.T) {
+	var server batchTestServer
+	srv := server.start(t, func(post int, req batchRequestJSON) (int, map[string]string, string) {
+		switch {
+		case post == 1 && req.ID == "2":
+			return http.StatusTooManyRequests, map[string]string{"Retry-After": "1"},
+				`{"error":{"code":"activityLimitReached"}}`
+		case post == 1 && req.ID == "3":
+			return http.StatusFailedDependency, nil, `{"error":{"code":"failedDependency"}}`
+		case req.ID == "4":
+			return http.StatusNotFound, map[string]string{"request-id": "req-4"}, `{"error":{"code":"itemNotFound"}}`
+		default:
+			return http.StatusOK, nil, `{}`
+		}
+	})
+
+	reqs := batchTestRequests(4)
+	reqs[1].DependsOn = []string{"1"}
+	reqs[2].DependsOn = []string{"2"}
+
+	client := newTestClient(t, srv.URL)
+	resps, err := client.Batch(t.Context(), reqs)
+	require.NoError(t, err)
+
+	require.Len(t, server.posts, 2)
+	require.Len(t, server.posts[1], 2, "only the throttled request and its dependent are resent")
+	assert.Equal(t, "2", server.posts[1][0].ID)
+	assert.Empty(t, server.posts[1][0].DependsOn, "a dependency that already succeeded is dropped")
+	assert.Equal(t, []string{"2"}, server.posts[1][1].DependsOn)
+
+	for _, i := range []int{0, 1, 2} {
+		require.NoError(t, resps[i].Err)
+	}
+
+	require.ErrorIs(t, resps[3].Err, ErrNotFound, "non-throttled failures are not retried")
+
+	var ge *GraphError
+	require.True(t, errors.As(resps[3].Err, &ge))
+	assert.Equal(t, "itemNotFound", ge.Code)
+	assert.Equal(t, "req-4", ge.RequestID)
+}
+
+// Validates: R-6.8.17
+func TestBatch_GivesUpOnThrottlingAfterPolicyAttempts(t *testing.T) {
+	var server batchTestServer
+	srv := server.start(t, func(int, batchRequestJSON) (int, map[string]string, string) {
+		return http.StatusServiceUnavailable, map[string]string{"Retry-After": "3"}, `{"error":{"code":"serviceNotAvailable"}}`
+	})
+
+	client := newTestClient(t, srv.URL)
+	resps, err := client.Batch(t.Context(), batchTestRequests(1))
+	require.NoError(t, err)
+
+	assert.Len(t, server.posts, testRetryPolicy().MaxAttempts)
+
+	var ge *GraphError
+	require.True(t, errors.As(resps[0].Err, &ge))
+	assert.ErrorIs(t, resps[0].Err, ErrServerError)
+	assert.Equal(t, 3, int(ge.RetryAfter.Seconds()))
+}
+
+func TestBatch_RejectsInvalidRequests(t *testing.T) {
+	client := newTestClient(t, "http://127.0.0.1:1")
+
+	_, err := client.Batch(t.Context(), []BatchRequest{{ID: "1"}, {ID: "1"}})
+	require.ErrorContains(t, err, `duplicate batch request id "1"`)
+
+	_, err = client.Batch(t.Context(), []BatchRequest{{ID: "1", DependsOn: []string{"2"}}, {ID: "2"}})
+	require.ErrorContains(t, err, `depends on unknown or later request "2"`)
+
+	chain := batchTestRequests(21)
+	for i := 1; i < len(chain); i++ {
+		chain[i].DependsOn = []string{chain[i-1].ID}
+	}
+
+	_, err = client.Batch(t.Context(), chain)
+	require.ErrorContains(t, err, "exceeds the limit of 20")
+}
+
+// Validates: R-6.8.17
+func TestItemBatchHelpers_DecodeTypedResults(t *testing.T) {
+	var server batchTestServer
+	srv := server.start(t, func(_ int, req batchRequestJSON) (int, map[string]string, string) {
+		switch req.URL {
+		case "/drives/000000000000000d/items/del-1/restore":
+			return http.StatusOK, nil, `{"id":"del-1","name":"a.txt","file":{}}`
+		case "/drives/000000000000000d/items/del-2/restore":
+			return http.StatusConflict, nil, `{"error":{"code":"nameAlreadyExists"}}`
+		case "/drives/000000000000000d/items/root:/Docs/My%20Notes:":
+			return http.StatusOK, nil, `{"id":"n","name":"my notes","folder":{}}`
+		case "/drives/000000000000000d/items/root:/Docs/Other:":
+			return http.StatusOK, nil, `{"id":"x","name":"Elsewhere","folder":{}}`
+		case "/drives/000000000000000d/items/p1/permissions":
+			return http.StatusOK, nil, `{"value":[{"id":"perm","roles":["read"]}]}`
+		default:
+			return http.StatusInternalServerError, nil, `{}`
+		}
+	})
+
+	client := newTestClient(t, srv.URL)
+	d := driveid.New("d")
+
+	restored, err := client.RestoreItemsBatch(t.Context(), d, []string{"del-1", "del-2"})
+	require.NoError(t, err)
+	require.NoError(t, restored[0].Err)
+	assert.Equal(t, "a.txt", restored[0].Item.Name)
+	require.ErrorIs(t, restored[1].Err, ErrConflict)
+	assert.JSONEq(t, `{}`, string(mustMarshalBatchBody(t, server.posts[0][0].Body)))
+
+	found, err := client.GetItemsByPathBatch(t.Context(), d, "root", []string{"Docs/My Notes", "Docs/Other"})
+	require.NoError(t, err)
+	require.NoError(t, found[0].Err)
+	assert.Equal(t, "n", found[0].Item.ID)
+	require.ErrorIs(t, found[1].Err, ErrNotFound, "a result with another leaf name is not the requested path")
+
+	perms, err := client.ListItemPermissionsBatch(t.Context(), d, []string{"p1"})
+	require.NoError(t, err)
+	require.NoError(t, perms[0].Err)
+	require.Len(t, perms[0].Permissions, 1)
+	assert.Equal(t, []string{"read"}, perms[0].Permissions[0].Roles)
+
+	errs, err := client.DeleteItemsBatch(t.Context(), d, []string{"gone"})
+	require.NoError(t, err)
+	require.ErrorIs(t, errs[0], ErrServerError)
+	assert.Equal(t, http.MethodDelete, server.posts[len(server.posts)-1][0].Method)
+}
+
+func mustMarshalBatchBody(t *testing.T, body any) []byte {
+	t.Helper()
+
+	out, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	return out
+}
@@ -63,6 +63,7 @@ type Client struct {
 	uploadSessionCreatePolicy  retry.Policy
 	copyDestinationPolicy      retry.Policy
 	simpleUploadCreatePolicy   retry.Policy
+	batchThrottlePolicy        retry.Policy
 	uploadURLValidator         func(*url.URL) error
 	copyMonitorValidator       func(*url.URL) error
 	socketIOValidator          func(*url.URL) error
@@ -55,6 +55,7 @@ func NewClient(
 		uploadSessionCreatePolicy:  retry.UploadSessionCreatePolicy(),
 		copyDestinationPolicy:      retry.CopyDestinationPolicy(),
 		simpleUploadCreatePolicy:   retry.SimpleUploadCreatePolicy(),
+		batchThrottlePolicy:        retry.BatchThrottlePolicy(),
 		uploadURLValidator:         validateUploadURL,
 		copyMonitorValidator:       validateCopyMonitorURL,
 		socketIOValidator:          validateSocketIONotificationURL,
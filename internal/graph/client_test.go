@@ -108,6 +108,7 @@ func newTestClient(t *testing.T, url string) *Client {
 	client.uploadSessionCreatePolicy = testRetryPolicy()
 	client.copyDestinationPolicy = testRetryPolicy()
 	client.simpleUploadCreatePolicy = testRetryPolicy()
+	client.batchThrottlePolicy = testRetryPolicy()
 	client.uploadURLValidator = allowTestUploadURL
 	client.copyMonitorValidator = allowTestCopyMonitorURL
 	client.socketIOValidator = allowTestSocketIONotificationURL
@@ -0,0 +1,37 @@
+package graph
+
+import "fmt"
+
+// ConflictBehavior tells Graph what to do when an upload, move, or copy
+// targets a name that already exists in the destination folder.
+type ConflictBehavior string
+
+// Conflict behaviors accepted by the @microsoft.graph.conflictBehavior
+// annotation. With rename the service picks a free name such as "name 1.ext"
+// and returns it on the resulting item.
+const (
+	ConflictFail    ConflictBehavior = "fail"
+	ConflictReplace ConflictBehavior = "replace"
+	ConflictRename  ConflictBehavior = "rename"
+)
+
+// uploadConflictBehavior returns behavior, or replace when the caller left
+// it empty: uploads always name a behavior instead of relying on the
+// undocumented service default.
+func uploadConflictBehavior(behavior ConflictBehavior) ConflictBehavior {
+	if behavior == "" {
+		return ConflictReplace
+	}
+
+	return behavior
+}
+
+// conflictBehaviorQuery returns the query string that carries behavior, or
+// "" when behavior is empty so the request keeps the service default.
+func conflictBehaviorQuery(behavior ConflictBehavior) string {
+	if behavior == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("?@microsoft.graph.conflictBehavior=%s", behavior)
+}
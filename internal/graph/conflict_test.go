@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+const conflictQueryKey = "@microsoft.graph.conflictBehavior"
+
+// Validates: R-1.3.10
+func TestSimpleUpload_ConflictBehaviorArgument(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "rename", r.URL.Query().Get(conflictQueryKey))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		writeTestResponse(t, w, `{"id":"renamed","name":"report 1.pdf","size":4,"parentReference":{"id":"p"}}`)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+
+	item, err := client.SimpleUpload(t.Context(), driveid.New("d"), "p", "report.pdf", strings.NewReader("data"), 4, ConflictRename)
+	require.NoError(t, err)
+	assert.Equal(t, "report 1.pdf", item.Name)
+}
+
+// Validates: R-1.3.10
+func TestCreateUploadSession_ConflictBehaviorArgument(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Contains(t, string(body), `"@microsoft.graph.conflictBehavior":"fail"`)
+
+		w.WriteHeader(http.StatusConflict)
+		writeTestResponse(t, w, `{"error":{"code":"nameAlreadyExists"}}`)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+
+	_, err := client.CreateUploadSession(t.Context(), driveid.New("d"), "p", "big.bin", 10485760, time.Time{}, ConflictFail)
+	require.ErrorIs(t, err, ErrConflict)
+}
+
+// Validates: R-1.7.3
+func TestMoveItem_ConflictBehaviorOnlyWhenGiven(t *testing.T) {
+	var queries []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get(conflictQueryKey))
+
+		w.Header().Set("Content-Type", "application/json")
+		writeTestResponse(t, w, `{"id":"item-1","name":"a 1.txt","parentReference":{"id":"p"}}`)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+
+	_, err := client.MoveItem(t.Context(), driveid.New("d"), "item-1", "p", "a.txt", "")
+	require.NoError(t, err)
+
+	item, err := client.MoveItem(t.Context(), driveid.New("d"), "item-1", "p", "a.txt", ConflictRename)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"", "rename"}, queries, "the service default applies unless a behavior is given")
+	assert.Equal(t, "a 1.txt", item.Name)
+}
+
+// Validates: R-1.8.2
+func TestCopyItem_ConflictBehaviorArgument(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/drives/000000000000000d/items/item-1/copy", r.URL.Path)
+		assert.Equal(t, "replace", r.URL.Query().Get(conflictQueryKey))
+
+		w.Header().Set("Location", "https://operations.contoso.sharepoint.com/status/abc")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+
+	_, err := client.CopyItem(t.Context(), driveid.New("d"), "item-1", "dest", "", ConflictReplace)
+	require.NoError(t, err)
+}
+
+func TestUploadConflictBehavior_EmptyMeansReplace(t *testing.T) {
+	assert.Equal(t, ConflictReplace, uploadConflictBehavior(""))
+	assert.Equal(t, ConflictFail, uploadConflictBehavior(ConflictFail))
+	assert.Empty(t, conflictBehaviorQuery(""))
+}
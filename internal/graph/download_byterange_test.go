@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+const byteRangeContent = "0123456789abcdefghij"
+
+func newByteRangeTestClient(t *testing.T, download http.HandlerFunc) *Client {
+	t.Helper()
+
+	downloadSrv := httptest.NewServer(download)
+	t.Cleanup(downloadSrv.Close)
+
+	graphSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/drives/000000000000000d/items/item-1", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		writeTestResponsef(t, w, `{"id":"item-1","name":"f.txt","size":%d,"file":{},
+			"@microsoft.graph.downloadUrl":%q}`, len(byteRangeContent), downloadSrv.URL+"/dl")
+	}))
+	t.Cleanup(graphSrv.Close)
+
+	return newTestClient(t, graphSrv.URL)
+}
+
+// Validates: R-1.15.1
+func TestDownloadByteRange_SendsClosedRange(t *testing.T) {
+	client := newByteRangeTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "bytes=5-9", r.Header.Get("Range"))
+		w.WriteHeader(http.StatusPartialContent)
+		writeDownloadTestBody(t, w, byteRangeContent[5:10])
+	})
+
+	var buf bytes.Buffer
+	n, err := client.DownloadByteRange(t.Context(), driveid.New("d"), "item-1", &buf, 5, 9)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+	assert.Equal(t, "56789", buf.String())
+}
+
+// Validates: R-1.15.1
+func TestDownloadByteRange_TrimsWhenServerIgnoresRange(t *testing.T) {
+	client := newByteRangeTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "bytes=5-9", r.Header.Get("Range"))
+		w.WriteHeader(http.StatusOK)
+		writeDownloadTestBody(t, w, byteRangeContent)
+	})
+
+	var buf bytes.Buffer
+	n, err := client.DownloadByteRange(t.Context(), driveid.New("d"), "item-1", &buf, 5, 9)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+	assert.Equal(t, "56789", buf.String())
+}
+
+// Validates: R-1.15.1
+func TestDownloadByteRange_OpenEndedAndWholeFile(t *testing.T) {
+	client := newByteRangeTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Range") {
+		case "bytes=15-":
+			w.WriteHeader(http.StatusPartialContent)
+			writeDownloadTestBody(t, w, byteRangeContent[15:])
+		case "":
+			writeDownloadTestBody(t, w, byteRangeContent)
+		default:
+			assert.Failf(t, "unexpected range", "%s", r.Header.Get("Range"))
+		}
+	})
+
+	var tail bytes.Buffer
+	_, err := client.DownloadByteRange(t.Context(), driveid.New("d"), "item-1", &tail, 15, -1)
+	require.NoError(t, err)
+	assert.Equal(t, "fghij", tail.String())
+
+	var whole bytes.Buffer
+	_, err = client.DownloadByteRange(t.Context(), driveid.New("d"), "item-1", &whole, 0, -1)
+	require.NoError(t, err)
+	assert.Equal(t, byteRangeContent, whole.String())
+}
+
+func TestDownloadByteRange_WriterErrorReportsBytesWritten(t *testing.T) {
+	client := newByteRangeTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		writeDownloadTestBody(t, w, byteRangeContent)
+	})
+
+	n, err := client.DownloadByteRange(t.Context(), driveid.New("d"), "item-1", errorWriter{}, 0, -1)
+	require.Error(t, err)
+	assert.Zero(t, n)
+}
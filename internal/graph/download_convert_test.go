@@ -0,0 +1,53 @@
+package graph
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// newConvertTestClient serves the content endpoint as a redirect to a
+// separate server that answers with body.
+func newConvertTestClient(t *testing.T, body string) *Client {
+	t.Helper()
+
+	convertedSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeDownloadTestBody(t, w, body)
+	}))
+	t.Cleanup(convertedSrv.Close)
+
+	graphSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/drives/000000000000000d/items/doc-1/content", r.URL.Path)
+		assert.Equal(t, "pdf", r.URL.Query().Get("format"))
+		http.Redirect(w, r, convertedSrv.URL+"/converted", http.StatusFound)
+	}))
+	t.Cleanup(graphSrv.Close)
+
+	return newTestClient(t, graphSrv.URL)
+}
+
+// Validates: R-1.24.1
+func TestDownloadConverted_FollowsRedirectToConvertedContent(t *testing.T) {
+	client := newConvertTestClient(t, "%PDF-1.7 converted")
+
+	var buf bytes.Buffer
+	n, err := client.DownloadConverted(t.Context(), driveid.New("d"), "doc-1", "pdf", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("%PDF-1.7 converted")), n)
+	assert.Equal(t, "%PDF-1.7 converted", buf.String())
+}
+
+// Validates: R-1.24.2
+func TestDownloadConverted_EmptyOutputFailsSizeSanity(t *testing.T) {
+	client := newConvertTestClient(t, "")
+
+	var buf bytes.Buffer
+	_, err := client.DownloadConverted(t.Context(), driveid.New("d"), "doc-1", "pdf", &buf)
+	require.ErrorIs(t, err, ErrEmptyConversion)
+}
@@ -51,9 +51,13 @@ type ownerFacet struct {
 }
 
 // quotaFacet represents the quota block in a Graph API drive response.
+// Deleted is the space held by the recycle bin.
 type quotaFacet struct {
-	Used  int64 `json:"used"`
-	Total int64 `json:"total"`
+	Used      int64  `json:"used"`
+	Total     int64  `json:"total"`
+	Deleted   int64  `json:"deleted"`
+	Remaining int64  `json:"remaining"`
+	State     string `json:"state"`
 }
 
 // drivesListResponse wraps the value array from GET /me/drives.
@@ -78,6 +82,9 @@ func (d *driveResponse) toDrive() Drive {
 	if d.Quota != nil {
 		drive.QuotaUsed = d.Quota.Used
 		drive.QuotaTotal = d.Quota.Total
+		drive.QuotaDeleted = d.Quota.Deleted
+		drive.QuotaRemaining = d.Quota.Remaining
+		drive.QuotaState = d.Quota.State
 	}
 
 	return drive
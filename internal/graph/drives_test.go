@@ -394,7 +394,10 @@ func TestDrive_Success(t *testing.T) {
 			},
 			"quota": {
 				"used": 2147483648,
-				"total": 10737418240
+				"total": 10737418240,
+				"deleted": 1073741824,
+				"remaining": 8589934592,
+				"state": "normal"
 			}
 		}`)
 	}))
@@ -410,6 +413,9 @@ func TestDrive_Success(t *testing.T) {
 	assert.Equal(t, "Business User", drive.OwnerName)
 	assert.Equal(t, int64(2147483648), drive.QuotaUsed)
 	assert.Equal(t, int64(10737418240), drive.QuotaTotal)
+	assert.Equal(t, int64(1073741824), drive.QuotaDeleted)
+	assert.Equal(t, int64(8589934592), drive.QuotaRemaining)
+	assert.Equal(t, "normal", drive.QuotaState)
 }
 
 func TestDrive_NotFound(t *testing.T) {
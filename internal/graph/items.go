@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"log/slog"
@@ -70,6 +71,7 @@ type driveItemResponse struct {
 	SpecialFolder        *specialFolderFacet `json:"specialFolder"`
 	RemoteItem           *remoteItemFacet    `json:"remoteItem"`
 	Shared               *sharedFacet        `json:"shared"`
+	Publication          *publicationFacet   `json:"publication"`
 }
 
 type specialFolderFacet struct {
@@ -113,6 +115,13 @@ type identitySetFacet struct {
 	User *sharedUserFacet `json:"user"`
 }
 
+// publicationFacet is the SharePoint publishing state. Level is "checkout"
+// while someone holds the item checked out.
+type publicationFacet struct {
+	Level        string            `json:"level"`
+	CheckedOutBy *identitySetFacet `json:"checkedOutBy"`
+}
+
 type sharedFacet struct {
 	Owner    *sharedOwnerFacet `json:"owner"`
 	SharedBy *sharedOwnerFacet `json:"sharedBy"`
@@ -209,6 +218,13 @@ func (d *driveItemResponse) toItem(logger *slog.Logger) Item {
 	// Shared owner identity — see resolveSharedOwner for fallback chain.
 	item.SharedOwnerName, item.SharedOwnerEmail = d.resolveSharedOwner()
 
+	if d.Publication != nil && d.Publication.Level == publicationLevelCheckout {
+		item.CheckedOut = true
+		if by := d.Publication.CheckedOutBy; by != nil && by.User != nil {
+			item.CheckedOutBy = cmp.Or(by.User.DisplayName, by.User.Email)
+		}
+	}
+
 	// Timestamps — validate and fallback to now if invalid.
 	// Deleted items routinely have empty timestamps (known OneDrive API behavior),
 	// so we log at DEBUG instead of WARN to avoid noise.
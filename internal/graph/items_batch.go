@@ -0,0 +1,215 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// BatchItemResult is the per-item outcome of a batched item call.
+type BatchItemResult struct {
+	Item *Item
+	Err  error
+}
+
+// BatchPermissionsResult is the per-item outcome of ListItemPermissionsBatch.
+type BatchPermissionsResult struct {
+	Permissions []Permission
+	Err         error
+}
+
+// DeleteItemsBatch deletes items (to the recycle bin) through JSON batching.
+// The returned slice holds one error per item ID, nil where the delete
+// succeeded.
+func (c *Client) DeleteItemsBatch(ctx context.Context, driveID driveid.ID, itemIDs []string) ([]error, error) {
+	c.logger.Info("deleting items in batch",
+		slog.String("drive_id", driveID.String()),
+		slog.Int("count", len(itemIDs)),
+	)
+
+	return c.batchItemErrors(ctx, itemIDs, func(itemID string) (string, string, any) {
+		return http.MethodDelete, fmt.Sprintf("/drives/%s/items/%s", driveID, itemID), nil
+	})
+}
+
+// PermanentDeleteItemsBatch mirrors DeleteItemsBatch for permanentDelete,
+// which only Business/SharePoint drives support.
+func (c *Client) PermanentDeleteItemsBatch(ctx context.Context, driveID driveid.ID, itemIDs []string) ([]error, error) {
+	c.logger.Info("permanently deleting items in batch",
+		slog.String("drive_id", driveID.String()),
+		slog.Int("count", len(itemIDs)),
+	)
+
+	return c.batchItemErrors(ctx, itemIDs, func(itemID string) (string, string, any) {
+		return http.MethodPost, fmt.Sprintf("/drives/%s/items/%s/permanentDelete", driveID, itemID), nil
+	})
+}
+
+// RestoreItemsBatch restores recycle-bin items to their original locations
+// through JSON batching, one result per item ID.
+func (c *Client) RestoreItemsBatch(ctx context.Context, driveID driveid.ID, itemIDs []string) ([]BatchItemResult, error) {
+	c.logger.Info("restoring items in batch",
+		slog.String("drive_id", driveID.String()),
+		slog.Int("count", len(itemIDs)),
+	)
+
+	reqs := make([]BatchRequest, 0, len(itemIDs))
+	for i, itemID := range itemIDs {
+		reqs = append(reqs, BatchRequest{
+			ID:     strconv.Itoa(i + 1),
+			Method: http.MethodPost,
+			URL:    fmt.Sprintf("/drives/%s/items/%s/restore", driveID, itemID),
+			Body:   struct{}{},
+		})
+	}
+
+	return c.batchItems(ctx, reqs, "restore", nil)
+}
+
+// GetItemsByPathBatch looks up paths relative to the folder baseItemID
+// ("root" for the drive root) through JSON batching, one result per path.
+// Like GetItemByPath, a result whose name does not match the requested leaf
+// is reported as ErrNotFound.
+func (c *Client) GetItemsByPathBatch(
+	ctx context.Context, driveID driveid.ID, baseItemID string, paths []string,
+) ([]BatchItemResult, error) {
+	reqs := make([]BatchRequest, 0, len(paths))
+	for i, p := range paths {
+		if err := validateRemotePath(p); err != nil {
+			return nil, err
+		}
+
+		reqs = append(reqs, BatchRequest{
+			ID:     strconv.Itoa(i + 1),
+			Method: http.MethodGet,
+			URL:    fmt.Sprintf("/drives/%s/items/%s:/%s:", driveID, baseItemID, encodePathSegments(p)),
+		})
+	}
+
+	c.logger.Debug("getting items by path in batch",
+		slog.String("drive_id", driveID.String()),
+		slog.String("base_item_id", baseItemID),
+		slog.Int("count", len(paths)),
+	)
+
+	return c.batchItems(ctx, reqs, "path lookup", func(i int, item *Item) error {
+		if !strings.EqualFold(item.Name, pathLeaf(paths[i])) {
+			return fmt.Errorf("graph: requested path %q resolved to %q: %w", paths[i], item.Name, ErrNotFound)
+		}
+
+		return nil
+	})
+}
+
+// ListItemPermissionsBatch lists the permissions of several items through
+// JSON batching, one result per item ID. The same caveats as
+// ListItemPermissions apply to each permission set.
+func (c *Client) ListItemPermissionsBatch(
+	ctx context.Context, driveID driveid.ID, itemIDs []string,
+) ([]BatchPermissionsResult, error) {
+	c.logger.Debug("listing item permissions in batch",
+		slog.String("drive_id", driveID.String()),
+		slog.Int("count", len(itemIDs)),
+	)
+
+	reqs := make([]BatchRequest, 0, len(itemIDs))
+	for i, itemID := range itemIDs {
+		reqs = append(reqs, BatchRequest{
+			ID:     strconv.Itoa(i + 1),
+			Method: http.MethodGet,
+			URL:    fmt.Sprintf("/drives/%s/items/%s/permissions", driveID, itemID),
+		})
+	}
+
+	resps, err := c.Batch(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]BatchPermissionsResult, len(resps))
+	for i := range resps {
+		if resps[i].Err != nil {
+			out[i].Err = resps[i].Err
+			continue
+		}
+
+		var lpr listPermissionsResponse
+		if err := json.Unmarshal(resps[i].Body, &lpr); err != nil {
+			out[i].Err = fmt.Errorf("graph: decoding permissions response: %w", err)
+			continue
+		}
+
+		out[i].Permissions = lpr.Value
+	}
+
+	return out, nil
+}
+
+// batchItemErrors sends one body-less request per item ID and reduces each
+// sub-response to its error.
+func (c *Client) batchItemErrors(
+	ctx context.Context, itemIDs []string, build func(itemID string) (method, url string, body any),
+) ([]error, error) {
+	reqs := make([]BatchRequest, 0, len(itemIDs))
+	for i, itemID := range itemIDs {
+		method, url, body := build(itemID)
+		reqs = append(reqs, BatchRequest{ID: strconv.Itoa(i + 1), Method: method, URL: url, Body: body})
+	}
+
+	resps, err := c.Batch(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, len(resps))
+	for i := range resps {
+		errs[i] = resps[i].Err
+	}
+
+	return errs, nil
+}
+
+// batchItems sends reqs and decodes each successful sub-response as a drive
+// item. check, when set, may reject a decoded item.
+func (c *Client) batchItems(
+	ctx context.Context, reqs []BatchRequest, what string, check func(i int, item *Item) error,
+) ([]BatchItemResult, error) {
+	resps, err := c.Batch(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]BatchItemResult, len(resps))
+	for i := range resps {
+		if resps[i].Err != nil {
+			out[i].Err = resps[i].Err
+			continue
+		}
+
+		var dir driveItemResponse
+		if err := json.Unmarshal(resps[i].Body, &dir); err != nil {
+			out[i].Err = fmt.Errorf("graph: decoding %s response: %w", what, err)
+			continue
+		}
+
+		item := dir.toItem(c.logger)
+		normalizeSingleItem(&item, c.logger)
+
+		if check != nil {
+			if err := check(i, &item); err != nil {
+				out[i].Err = err
+				continue
+			}
+		}
+
+		out[i].Item = &item
+	}
+
+	return out, nil
+}
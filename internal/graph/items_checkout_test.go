@@ -0,0 +1,71 @@
+package graph
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// Validates: R-1.22.1
+func TestCheckoutActions_PostToItemEndpoints(t *testing.T) {
+	var paths []string
+	var checkinBody map[string]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		paths = append(paths, r.URL.Path)
+
+		if r.URL.Path == "/drives/000000000000000d/items/f/checkin" {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(body, &checkinBody))
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+	d := driveid.New("d")
+
+	require.NoError(t, client.CheckoutItem(t.Context(), d, "f"))
+	require.NoError(t, client.CheckinItem(t.Context(), d, "f", "quarterly numbers"))
+	require.NoError(t, client.DiscardCheckout(t.Context(), d, "f"))
+
+	assert.Equal(t, []string{
+		"/drives/000000000000000d/items/f/checkout",
+		"/drives/000000000000000d/items/f/checkin",
+		"/drives/000000000000000d/items/f/discardCheckout",
+	}, paths)
+	assert.Equal(t, map[string]string{"comment": "quarterly numbers"}, checkinBody)
+}
+
+func TestCheckoutItem_HeldByAnotherUserIsLocked(t *testing.T) {
+	assertGraphCallError(t, http.StatusLocked, "req-checkout-423", "resourceLocked", func(client *Client) error {
+		return client.CheckoutItem(t.Context(), driveid.New("d"), "f")
+	}, ErrLocked)
+}
+
+// Validates: R-1.22.2
+func TestToItem_PublicationCheckout(t *testing.T) {
+	var dir driveItemResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"f","name":"plan.docx","file":{},
+		"publication":{"level":"checkout","versionId":"3.0",
+		"checkedOutBy":{"user":{"displayName":"Dana Lee","email":"dana@contoso.com"}}}}`), &dir))
+
+	item := dir.toItem(testNoopLogger())
+	assert.True(t, item.CheckedOut)
+	assert.Equal(t, "Dana Lee", item.CheckedOutBy)
+
+	dir.Publication.Level = "published"
+	item = dir.toItem(testNoopLogger())
+	assert.False(t, item.CheckedOut)
+	assert.Empty(t, item.CheckedOutBy)
+}
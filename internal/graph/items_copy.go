@@ -31,9 +31,10 @@ type copyItemRequest struct {
 
 // CopyItem starts an async copy of a drive item to a new location.
 // Returns a CopyResult with a monitor URL. The copy completes server-side;
-// poll PollCopyStatus to track progress.
+// poll PollCopyStatus to track progress. A non-empty conflict behavior is
+// sent as a query parameter; without one the service default (fail) applies.
 func (c *Client) CopyItem(
-	ctx context.Context, driveID driveid.ID, itemID, destParentID, newName string,
+	ctx context.Context, driveID driveid.ID, itemID, destParentID, newName string, conflict ConflictBehavior,
 ) (*CopyResult, error) {
 	c.logger.Info("copying item",
 		slog.String("drive_id", driveID.String()),
@@ -42,7 +43,7 @@ func (c *Client) CopyItem(
 		slog.String("new_name", newName),
 	)
 
-	apiPath := fmt.Sprintf("/drives/%s/items/%s/copy", driveID, itemID)
+	apiPath := fmt.Sprintf("/drives/%s/items/%s/copy", driveID, itemID) + conflictBehaviorQuery(conflict)
 
 	req := copyItemRequest{
 		ParentReference: &moveParentRef{ID: destParentID},
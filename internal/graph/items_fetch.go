@@ -78,6 +78,17 @@ func (c *Client) GetItem(ctx context.Context, driveID driveid.ID, itemID string)
 	return c.fetchItem(ctx, fmt.Sprintf("/drives/%s/items/%s", driveID, itemID))
 }
 
+// AppRoot returns the app folder (special/approot) of a drive, which Graph
+// creates on first access. A login limited to Files.ReadWrite.AppFolder can
+// reach only this folder and its descendants.
+func (c *Client) AppRoot(ctx context.Context, driveID driveid.ID) (*Item, error) {
+	c.logger.Info("getting app folder",
+		slog.String("drive_id", driveID.String()),
+	)
+
+	return c.fetchItem(ctx, fmt.Sprintf("/drives/%s/special/approot", driveID))
+}
+
 // GetItemByPath retrieves a drive item by its path relative to the drive root.
 // The path must NOT have a leading slash and must not be empty — these are caller
 // bugs that produce malformed API URLs. Returns ErrInvalidPath for both cases.
@@ -0,0 +1,196 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// Column types reported by ListDriveColumns. Columns with a facet not listed
+// here are reported as ColumnTypeOther.
+const (
+	ColumnTypeText     = "text"
+	ColumnTypeNumber   = "number"
+	ColumnTypeCurrency = "currency"
+	ColumnTypeBoolean  = "boolean"
+	ColumnTypeDateTime = "dateTime"
+	ColumnTypeChoice   = "choice"
+	ColumnTypeOther    = "other"
+)
+
+// ColumnDefinition describes one column of a document library.
+type ColumnDefinition struct {
+	Name        string // internal name, the key used in list item fields
+	DisplayName string
+	Type        string // one of the ColumnType constants
+	ReadOnly    bool
+	Choices     []string // allowed values of a choice column
+}
+
+// columnDefinitionResponse mirrors a Graph columnDefinition. The column type
+// is whichever type facet is present.
+type columnDefinitionResponse struct {
+	Name        string          `json:"name"`
+	DisplayName string          `json:"displayName"`
+	ReadOnly    bool            `json:"readOnly"`
+	Text        json.RawMessage `json:"text"`
+	Number      json.RawMessage `json:"number"`
+	Currency    json.RawMessage `json:"currency"`
+	Boolean     json.RawMessage `json:"boolean"`
+	DateTime    json.RawMessage `json:"dateTime"`
+	Choice      *struct {
+		Choices []string `json:"choices"`
+	} `json:"choice"`
+}
+
+func (r *columnDefinitionResponse) toColumnDefinition() ColumnDefinition {
+	col := ColumnDefinition{
+		Name:        r.Name,
+		DisplayName: r.DisplayName,
+		ReadOnly:    r.ReadOnly,
+		Type:        ColumnTypeOther,
+	}
+
+	switch {
+	case r.Text != nil:
+		col.Type = ColumnTypeText
+	case r.Number != nil:
+		col.Type = ColumnTypeNumber
+	case r.Currency != nil:
+		col.Type = ColumnTypeCurrency
+	case r.Boolean != nil:
+		col.Type = ColumnTypeBoolean
+	case r.DateTime != nil:
+		col.Type = ColumnTypeDateTime
+	case r.Choice != nil:
+		col.Type = ColumnTypeChoice
+		col.Choices = r.Choice.Choices
+	}
+
+	return col
+}
+
+// GetListItemFields returns the column values of the list item behind a
+// document library file, keyed by internal column name. OData annotations
+// are dropped; values keep their JSON types. Drives that are not document
+// libraries (OneDrive Personal) answer with an error.
+func (c *Client) GetListItemFields(ctx context.Context, driveID driveid.ID, itemID string) (map[string]any, error) {
+	c.logger.Info("getting list item fields",
+		slog.String("drive_id", driveID.String()),
+		slog.String("item_id", itemID),
+	)
+
+	return c.doListItemFields(ctx, http.MethodGet, driveID, itemID, nil)
+}
+
+// UpdateListItemFields PATCHes column values on the list item behind a
+// document library file and returns the updated fields. Only the given
+// columns change; a nil value clears a column.
+func (c *Client) UpdateListItemFields(
+	ctx context.Context, driveID driveid.ID, itemID string, fields map[string]any,
+) (map[string]any, error) {
+	c.logger.Info("updating list item fields",
+		slog.String("drive_id", driveID.String()),
+		slog.String("item_id", itemID),
+		slog.Int("count", len(fields)),
+	)
+
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("graph: marshaling list item fields: %w", err)
+	}
+
+	return c.doListItemFields(ctx, http.MethodPatch, driveID, itemID, body)
+}
+
+func (c *Client) doListItemFields(
+	ctx context.Context, method string, driveID driveid.ID, itemID string, body []byte,
+) (map[string]any, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	resp, err := c.do(ctx, method, fmt.Sprintf("/drives/%s/items/%s/listItem/fields", driveID, itemID), reader)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var fields map[string]any
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("graph: decoding list item fields response: %w", err)
+	}
+
+	for key := range fields {
+		if strings.HasPrefix(key, "@odata.") {
+			delete(fields, key)
+		}
+	}
+
+	return fields, nil
+}
+
+// ListDriveColumns returns the column definitions of the document library
+// behind a drive, following pagination.
+func (c *Client) ListDriveColumns(ctx context.Context, driveID driveid.ID) ([]ColumnDefinition, error) {
+	c.logger.Info("listing drive columns",
+		slog.String("drive_id", driveID.String()),
+	)
+
+	var columns []ColumnDefinition
+
+	apiPath := fmt.Sprintf("/drives/%s/list/columns", driveID)
+	for apiPath != "" {
+		page, nextPath, err := c.fetchColumnPage(ctx, apiPath)
+		if err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, page...)
+		apiPath = nextPath
+	}
+
+	return columns, nil
+}
+
+func (c *Client) fetchColumnPage(ctx context.Context, apiPath string) ([]ColumnDefinition, string, error) {
+	resp, err := c.do(ctx, http.MethodGet, apiPath, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	var page struct {
+		Value    []columnDefinitionResponse `json:"value"`
+		NextLink string                     `json:"@odata.nextLink"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, "", fmt.Errorf("graph: decoding columns response: %w", err)
+	}
+
+	columns := make([]ColumnDefinition, 0, len(page.Value))
+	for i := range page.Value {
+		columns = append(columns, page.Value[i].toColumnDefinition())
+	}
+
+	if page.NextLink == "" {
+		return columns, "", nil
+	}
+
+	nextPath, err := c.stripBaseURL(page.NextLink)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return columns, nextPath, nil
+}
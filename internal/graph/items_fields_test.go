@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// Validates: R-1.23.1, R-1.23.2
+func TestListItemFields_GetAndPatch(t *testing.T) {
+	var patched map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/drives/000000000000000d/items/f/listItem/fields", r.URL.Path)
+
+		if r.Method == http.MethodPatch {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(body, &patched))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"@odata.context":"ctx","@odata.etag":"\"1\"",
+			"ProjectCode":"PX-7","Budget":1250,"Approved":true}`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+
+	fields, err := client.GetListItemFields(t.Context(), driveid.New("d"), "f")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"ProjectCode": "PX-7",
+		"Budget":      json.Number("1250"),
+		"Approved":    true,
+	}, fields, "OData annotations are dropped and numbers keep their text")
+
+	_, err = client.UpdateListItemFields(t.Context(), driveid.New("d"), "f", map[string]any{"Budget": 1250.0, "Status": nil})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"Budget": 1250.0, "Status": nil}, patched)
+}
+
+// Validates: R-1.23.2
+func TestListDriveColumns_MapsTypeFacetsAndFollowsPages(t *testing.T) {
+	var srvURL string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var err error
+		if r.URL.Query().Get("page") == "2" {
+			_, err = w.Write([]byte(`{"value":[
+				{"name":"Created","displayName":"Created","readOnly":true,"dateTime":{}},
+				{"name":"Owner","displayName":"Owner","personOrGroup":{}}]}`))
+		} else {
+			assert.Equal(t, "/drives/000000000000000d/list/columns", r.URL.Path)
+			_, err = w.Write([]byte(`{"value":[
+				{"name":"ProjectCode","displayName":"Project code","text":{}},
+				{"name":"Budget","displayName":"Budget","currency":{"locale":"en-us"}},
+				{"name":"Status","displayName":"Status","choice":{"choices":["Draft","Final"]}}],
+				"@odata.nextLink":"` + srvURL + `/drives/000000000000000d/list/columns?page=2"}`))
+		}
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+	srvURL = srv.URL
+
+	client := newTestClient(t, srv.URL)
+
+	columns, err := client.ListDriveColumns(t.Context(), driveid.New("d"))
+	require.NoError(t, err)
+	assert.Equal(t, []ColumnDefinition{
+		{Name: "ProjectCode", DisplayName: "Project code", Type: ColumnTypeText},
+		{Name: "Budget", DisplayName: "Budget", Type: ColumnTypeCurrency},
+		{Name: "Status", DisplayName: "Status", Type: ColumnTypeChoice, Choices: []string{"Draft", "Final"}},
+		{Name: "Created", DisplayName: "Created", Type: ColumnTypeDateTime, ReadOnly: true},
+		{Name: "Owner", DisplayName: "Owner", Type: ColumnTypeOther},
+	}, columns)
+}
+
+func TestGetListItemFields_NotADocumentLibrary(t *testing.T) {
+	assertGraphCallError(t, http.StatusNotFound, "req-fields-404", "itemNotFound", func(client *Client) error {
+		_, err := client.GetListItemFields(t.Context(), driveid.New("d"), "f")
+		return err
+	}, ErrNotFound)
+}
@@ -148,13 +148,17 @@ func findCreatedFolder(children []Item, name string) (Item, bool) {
 var ErrMoveNoChanges = errors.New("graph: MoveItem requires at least one of newParentID or newName")
 
 // MoveItem moves and/or renames an item. At least one of newParentID or newName must be non-empty.
-func (c *Client) MoveItem(ctx context.Context, driveID driveid.ID, itemID, newParentID, newName string) (*Item, error) {
-	return c.MoveItemIfMatch(ctx, driveID, itemID, newParentID, newName, "")
+// A non-empty conflict behavior is sent as a query parameter; without one the
+// service default (fail) applies.
+func (c *Client) MoveItem(
+	ctx context.Context, driveID driveid.ID, itemID, newParentID, newName string, conflict ConflictBehavior,
+) (*Item, error) {
+	return c.moveItem(ctx, driveID, itemID, newParentID, newName, "", conflict)
 }
 
 // MoveItemIfMatch moves and/or renames an item, adding If-Match when ifMatch
 // is non-empty so Graph rejects the mutation if the item changed after the
-// caller's live preflight.
+// caller's live preflight. A name conflict fails.
 func (c *Client) MoveItemIfMatch(
 	ctx context.Context,
 	driveID driveid.ID,
@@ -162,6 +166,18 @@ func (c *Client) MoveItemIfMatch(
 	newParentID string,
 	newName string,
 	ifMatch string,
+) (*Item, error) {
+	return c.moveItem(ctx, driveID, itemID, newParentID, newName, ifMatch, "")
+}
+
+func (c *Client) moveItem(
+	ctx context.Context,
+	driveID driveid.ID,
+	itemID string,
+	newParentID string,
+	newName string,
+	ifMatch string,
+	conflict ConflictBehavior,
 ) (*Item, error) {
 	if newParentID == "" && newName == "" {
 		return nil, ErrMoveNoChanges
@@ -174,7 +190,7 @@ func (c *Client) MoveItemIfMatch(
 		slog.String("new_name", newName),
 	)
 
-	path := fmt.Sprintf("/drives/%s/items/%s", driveID, itemID)
+	path := fmt.Sprintf("/drives/%s/items/%s", driveID, itemID) + conflictBehaviorQuery(conflict)
 
 	req := moveItemRequest{}
 	if newParentID != "" {
@@ -218,22 +234,31 @@ type updateFileSystemInfoRequest struct {
 // preserve local mtime on the server. Returns the patched item.
 func (c *Client) UpdateFileSystemInfo(
 	ctx context.Context, driveID driveid.ID, itemID string, mtime time.Time,
+) (*Item, error) {
+	return c.UpdateFileSystemTimes(ctx, driveID, itemID, mtime, time.Time{})
+}
+
+// UpdateFileSystemTimes sets the lastModifiedDateTime and, unless ctime is
+// zero, the createdDateTime of a remote item via PATCH. Returns the patched
+// item.
+func (c *Client) UpdateFileSystemTimes(
+	ctx context.Context, driveID driveid.ID, itemID string, mtime, ctime time.Time,
 ) (*Item, error) {
 	c.logger.Debug("updating fileSystemInfo",
 		slog.String("drive_id", driveID.String()),
 		slog.String("item_id", itemID),
 		slog.Time("mtime", mtime),
+		slog.Time("ctime", ctime),
 	)
 
 	path := fmt.Sprintf("/drives/%s/items/%s", driveID, itemID)
 
-	reqBody := updateFileSystemInfoRequest{
-		FileSystemInfo: &fileSystemInfo{
-			LastModifiedDateTime: mtime.UTC().Format(time.RFC3339),
-		},
+	info := &fileSystemInfo{LastModifiedDateTime: mtime.UTC().Format(time.RFC3339)}
+	if !ctime.IsZero() {
+		info.CreatedDateTime = ctime.UTC().Format(time.RFC3339)
 	}
 
-	bodyBytes, err := json.Marshal(reqBody)
+	bodyBytes, err := json.Marshal(updateFileSystemInfoRequest{FileSystemInfo: info})
 	if err != nil {
 		return nil, fmt.Errorf("graph: marshaling fileSystemInfo request: %w", err)
 	}
@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// createShortcutRequest is the "Add to My files" body: a children POST whose
+// remoteItem points at the shared folder instead of carrying a folder facet.
+type createShortcutRequest struct {
+	Name             string                `json:"name"`
+	RemoteItem       shortcutRemoteItemRef `json:"remoteItem"`
+	ConflictBehavior string                `json:"@microsoft.graph.conflictBehavior"`
+}
+
+type shortcutRemoteItemRef struct {
+	ID              string             `json:"id"`
+	ParentReference shortcutRemoteRoot `json:"parentReference"`
+}
+
+type shortcutRemoteRoot struct {
+	DriveID string `json:"driveId"`
+}
+
+// CreateShortcut adds a OneDrive shortcut to a shared folder under the given
+// parent in driveID. The returned item is the shortcut placeholder; its
+// RemoteDriveID/RemoteItemID identify the shared target.
+// Uses conflictBehavior "fail" — returns ErrConflict (409) on name collision.
+func (c *Client) CreateShortcut(
+	ctx context.Context,
+	driveID driveid.ID,
+	parentID, name string,
+	remoteDriveID driveid.ID,
+	remoteItemID string,
+) (*Item, error) {
+	c.logger.Info("creating shortcut",
+		slog.String("drive_id", driveID.String()),
+		slog.String("parent_id", parentID),
+		slog.String("name", name),
+		slog.String("remote_drive_id", remoteDriveID.String()),
+		slog.String("remote_item_id", remoteItemID),
+	)
+
+	path := fmt.Sprintf("/drives/%s/items/%s/children", driveID, parentID)
+
+	reqBody := createShortcutRequest{
+		Name: name,
+		RemoteItem: shortcutRemoteItemRef{
+			ID:              remoteItemID,
+			ParentReference: shortcutRemoteRoot{DriveID: remoteDriveID.String()},
+		},
+		ConflictBehavior: "fail",
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("graph: marshaling create shortcut request: %w", err)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("graph: reading create shortcut response: %w", err)
+	}
+
+	var dir driveItemResponse
+	if err := json.Unmarshal(body, &dir); err != nil {
+		return nil, fmt.Errorf("graph: decoding create shortcut response: %w", err)
+	}
+
+	item := dir.toItem(c.logger)
+	normalizeSingleItem(&item, c.logger)
+
+	return &item, nil
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// Validates: R-1.10.1
+func TestCreateShortcut_Success(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/drives/000000000000000d/items/parent/children", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var req struct {
+			Name       string `json:"name"`
+			RemoteItem struct {
+				ID              string `json:"id"`
+				ParentReference struct {
+					DriveID string `json:"driveId"`
+				} `json:"parentReference"`
+			} `json:"remoteItem"`
+			ConflictBehavior string `json:"@microsoft.graph.conflictBehavior"`
+			Folder           any    `json:"folder"`
+		}
+		if !assert.NoError(t, json.Unmarshal(body, &req)) {
+			return
+		}
+		assert.Equal(t, "Team Docs", req.Name)
+		assert.Equal(t, "owner-folder", req.RemoteItem.ID)
+		assert.Equal(t, driveid.New("owner-drive").String(), req.RemoteItem.ParentReference.DriveID)
+		assert.Equal(t, "fail", req.ConflictBehavior)
+		assert.Nil(t, req.Folder)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		writeTestResponse(t, w, `{
+			"id": "shortcut-id",
+			"name": "Team Docs",
+			"createdDateTime": "2024-06-01T12:00:00Z",
+			"lastModifiedDateTime": "2024-06-01T12:00:00Z",
+			"parentReference": {"id": "parent", "driveId": "d"},
+			"remoteItem": {
+				"id": "owner-folder",
+				"parentReference": {"driveId": "owner-drive"},
+				"folder": {"childCount": 3}
+			}
+		}`)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+	item, err := client.CreateShortcut(
+		t.Context(), driveid.New("d"), "parent", "Team Docs", driveid.New("owner-drive"), "owner-folder",
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "shortcut-id", item.ID)
+	assert.Equal(t, "Team Docs", item.Name)
+	assert.Equal(t, "owner-folder", item.RemoteItemID)
+	assert.Equal(t, driveid.New("owner-drive").String(), item.RemoteDriveID)
+	assert.True(t, item.RemoteIsFolder)
+}
+
+// Validates: R-1.10.1
+func TestCreateShortcut_Conflict(t *testing.T) {
+	assertGraphCallError(t, http.StatusConflict, "req-conflict", "nameAlreadyExists", func(client *Client) error {
+		_, err := client.CreateShortcut(
+			t.Context(), driveid.New("d"), "parent", "Team Docs", driveid.New("owner-drive"), "owner-folder",
+		)
+		return err
+	}, ErrConflict)
+}
@@ -110,6 +110,30 @@ func TestGetItem_Folder(t *testing.T) {
 	assert.Empty(t, item.QuickXorHash)
 }
 
+// Validates: R-3.1.9
+func TestAppRoot_FetchesSpecialFolder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/drives/000000000drive-1/special/approot", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		writeTestResponse(t, w, `{
+			"id": "approot-1",
+			"name": "onedrive-go",
+			"createdDateTime": "2024-01-01T00:00:00Z",
+			"lastModifiedDateTime": "2024-01-01T00:00:00Z",
+			"parentReference": {"id": "apps", "driveId": "drive-1"},
+			"folder": {"childCount": 0}
+		}`)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+	item, err := client.AppRoot(t.Context(), driveid.New("drive-1"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "approot-1", item.ID)
+	assert.True(t, item.IsFolder)
+}
+
 func TestGetItem_NotFound(t *testing.T) {
 	assertGraphCallError(t, http.StatusNotFound, "req-404", "itemNotFound", func(client *Client) error {
 		_, err := client.GetItem(t.Context(), driveid.New("drive-1"), "nonexistent")
@@ -869,7 +893,7 @@ func TestMoveItem_MoveAndRename(t *testing.T) {
 	defer srv.Close()
 
 	client := newTestClient(t, srv.URL)
-	item, err := client.MoveItem(t.Context(), driveid.New("d"), "item-1", "new-parent", "renamed.txt")
+	item, err := client.MoveItem(t.Context(), driveid.New("d"), "item-1", "new-parent", "renamed.txt", "")
 	require.NoError(t, err)
 
 	assert.Equal(t, "renamed.txt", item.Name)
@@ -905,7 +929,7 @@ func TestMoveItem_RenameOnly(t *testing.T) {
 	defer srv.Close()
 
 	client := newTestClient(t, srv.URL)
-	item, err := client.MoveItem(t.Context(), driveid.New("d"), "item-1", "", "new-name.txt")
+	item, err := client.MoveItem(t.Context(), driveid.New("d"), "item-1", "", "new-name.txt", "")
 	require.NoError(t, err)
 
 	assert.Equal(t, "new-name.txt", item.Name)
@@ -945,7 +969,7 @@ func TestMoveItem_MoveOnly(t *testing.T) {
 	defer srv.Close()
 
 	client := newTestClient(t, srv.URL)
-	item, err := client.MoveItem(t.Context(), driveid.New("d"), "item-1", "new-parent", "")
+	item, err := client.MoveItem(t.Context(), driveid.New("d"), "item-1", "new-parent", "", "")
 	require.NoError(t, err)
 
 	assert.Equal(t, "new-parent", item.ParentID)
@@ -954,14 +978,14 @@ func TestMoveItem_MoveOnly(t *testing.T) {
 
 func TestMoveItem_BothEmpty(t *testing.T) {
 	client := newTestClient(t, "http://localhost")
-	_, err := client.MoveItem(t.Context(), driveid.New("d"), "item-1", "", "")
+	_, err := client.MoveItem(t.Context(), driveid.New("d"), "item-1", "", "", "")
 	require.Error(t, err)
 	assert.ErrorIs(t, err, ErrMoveNoChanges)
 }
 
 func TestMoveItem_NotFound(t *testing.T) {
 	assertGraphCallError(t, http.StatusNotFound, "req-move-404", "itemNotFound", func(client *Client) error {
-		_, err := client.MoveItem(t.Context(), driveid.New("d"), "nonexistent", "new-parent", "")
+		_, err := client.MoveItem(t.Context(), driveid.New("d"), "nonexistent", "new-parent", "", "")
 		return err
 	}, ErrNotFound)
 }
@@ -1221,6 +1245,40 @@ func TestUpdateFileSystemInfo_Success(t *testing.T) {
 	assert.Equal(t, time.August, item.ModifiedAt.Month())
 }
 
+// Validates: R-1.20.2
+func TestUpdateFileSystemTimes_SendsCreatedOnlyWhenSet(t *testing.T) {
+	var bodies []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		bodies = append(bodies, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		writeTestResponse(t, w, `{"id":"item-fsi","name":"a.txt","createdDateTime":"2020-02-03T04:05:06Z"}`)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+	mtime := time.Date(2024, 8, 15, 14, 30, 0, 0, time.UTC)
+	ctime := time.Date(2020, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	item, err := client.UpdateFileSystemTimes(t.Context(), driveid.New("d"), "item-fsi", mtime, ctime)
+	require.NoError(t, err)
+	assert.Equal(t, ctime, item.CreatedAt)
+
+	_, err = client.UpdateFileSystemInfo(t.Context(), driveid.New("d"), "item-fsi", mtime)
+	require.NoError(t, err)
+
+	require.Len(t, bodies, 2)
+	assert.JSONEq(t,
+		`{"fileSystemInfo":{"createdDateTime":"2020-02-03T04:05:06Z","lastModifiedDateTime":"2024-08-15T14:30:00Z"}}`, bodies[0])
+	assert.JSONEq(t, `{"fileSystemInfo":{"lastModifiedDateTime":"2024-08-15T14:30:00Z"}}`, bodies[1])
+}
+
 func TestUpdateFileSystemInfo_DecodeError(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -1893,7 +1951,7 @@ func TestCopyItem_Success(t *testing.T) {
 	defer srv.Close()
 
 	client := newTestClient(t, srv.URL)
-	result, err := client.CopyItem(t.Context(), driveid.New("d"), "item-to-copy", "dest-folder-id", "copy-of-file.txt")
+	result, err := client.CopyItem(t.Context(), driveid.New("d"), "item-to-copy", "dest-folder-id", "copy-of-file.txt", "")
 	require.NoError(t, err)
 
 	assert.Equal(t, "https://operations.contoso.sharepoint.com/status/abc", result.MonitorURL)
@@ -1919,7 +1977,7 @@ func TestCopyItem_NoName(t *testing.T) {
 	defer srv.Close()
 
 	client := newTestClient(t, srv.URL)
-	result, err := client.CopyItem(t.Context(), driveid.New("d"), "item-1", "dest-id", "")
+	result, err := client.CopyItem(t.Context(), driveid.New("d"), "item-1", "dest-id", "", "")
 	require.NoError(t, err)
 
 	assert.Equal(t, "https://operations.contoso.sharepoint.com/status/def", result.MonitorURL)
@@ -1933,7 +1991,7 @@ func TestCopyItem_PersonalMonitorHost(t *testing.T) {
 	defer srv.Close()
 
 	client := newTestClient(t, srv.URL)
-	result, err := client.CopyItem(t.Context(), driveid.New("d"), "item-1", "dest-id", "name.txt")
+	result, err := client.CopyItem(t.Context(), driveid.New("d"), "item-1", "dest-id", "name.txt", "")
 	require.NoError(t, err)
 
 	assert.Equal(t, "https://my.microsoftpersonalcontent.com/personal/status/ghi", result.MonitorURL)
@@ -1946,14 +2004,14 @@ func TestCopyItem_MissingLocationHeader(t *testing.T) {
 	defer srv.Close()
 
 	client := newTestClient(t, srv.URL)
-	_, err := client.CopyItem(t.Context(), driveid.New("d"), "item-1", "dest-id", "name.txt")
+	_, err := client.CopyItem(t.Context(), driveid.New("d"), "item-1", "dest-id", "name.txt", "")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "missing Location header")
 }
 
 func TestCopyItem_NotFound(t *testing.T) {
 	assertGraphCallError(t, http.StatusNotFound, "req-copy-404", "itemNotFound", func(client *Client) error {
-		_, err := client.CopyItem(t.Context(), driveid.New("d"), "nonexistent", "dest-id", "name.txt")
+		_, err := client.CopyItem(t.Context(), driveid.New("d"), "nonexistent", "dest-id", "name.txt", "")
 		return err
 	}, ErrNotFound)
 }
@@ -1984,7 +2042,7 @@ func TestCopyItem_TransientDestinationVisibilityRetry(t *testing.T) {
 	client := newNoRetryTestClient(t, srv.URL)
 	client.copyDestinationPolicy = testRetryPolicy()
 
-	result, err := client.CopyItem(t.Context(), driveid.New("d"), "item-to-copy", "dest-folder-id", "copy-of-file.txt")
+	result, err := client.CopyItem(t.Context(), driveid.New("d"), "item-to-copy", "dest-folder-id", "copy-of-file.txt", "")
 	require.NoError(t, err)
 
 	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// ItemVersion is one entry of a file's version history. The newest entry is
+// the current content; older entries hold storage until they are trimmed.
+type ItemVersion struct {
+	ID         string
+	Size       int64
+	ModifiedAt time.Time
+}
+
+type itemVersionResponse struct {
+	ID                   string `json:"id"`
+	Size                 int64  `json:"size"`
+	LastModifiedDateTime string `json:"lastModifiedDateTime"`
+}
+
+type listVersionsResponse struct {
+	Value    []itemVersionResponse `json:"value"`
+	NextLink string                `json:"@odata.nextLink"`
+}
+
+// ListItemVersions returns the version history of a file, newest first,
+// following pagination. Folders have no versions; Graph rejects them.
+func (c *Client) ListItemVersions(ctx context.Context, driveID driveid.ID, itemID string) ([]ItemVersion, error) {
+	c.logger.Debug("listing item versions",
+		slog.String("drive_id", driveID.String()),
+		slog.String("item_id", itemID),
+	)
+
+	path := fmt.Sprintf("/drives/%s/items/%s/versions", driveID, itemID)
+
+	var versions []ItemVersion
+
+	for path != "" {
+		page, nextPath, err := c.listVersionsPage(ctx, path, itemID)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, page...)
+		path = nextPath
+	}
+
+	return versions, nil
+}
+
+func (c *Client) listVersionsPage(ctx context.Context, path, itemID string) ([]ItemVersion, string, error) {
+	resp, err := c.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	var lvr listVersionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&lvr); err != nil {
+		return nil, "", fmt.Errorf("graph: decoding versions response: %w", err)
+	}
+
+	versions := make([]ItemVersion, 0, len(lvr.Value))
+	for i := range lvr.Value {
+		versions = append(versions, ItemVersion{
+			ID:         lvr.Value[i].ID,
+			Size:       lvr.Value[i].Size,
+			ModifiedAt: parseTimestamp(lvr.Value[i].LastModifiedDateTime, "lastModifiedDateTime", itemID, false, c.logger),
+		})
+	}
+
+	if lvr.NextLink == "" {
+		return versions, "", nil
+	}
+
+	nextPath, err := c.stripBaseURL(lvr.NextLink)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return versions, nextPath, nil
+}
@@ -0,0 +1,50 @@
+package graph
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// Validates: R-1.13.2
+func TestListItemVersions_FollowsNextLink(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/drives/000000000000000d/items/file-id/versions", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "" {
+			writeTestResponse(t, w, `{"value":[
+				{"id":"3.0","size":300,"lastModifiedDateTime":"2024-03-01T00:00:00Z"},
+				{"id":"2.0","size":200,"lastModifiedDateTime":"2024-02-01T00:00:00Z"}
+			],"@odata.nextLink":"`+srv.URL+`/drives/000000000000000d/items/file-id/versions?page=2"}`)
+
+			return
+		}
+
+		writeTestResponse(t, w, `{"value":[{"id":"1.0","size":100,"lastModifiedDateTime":"2024-01-01T00:00:00Z"}]}`)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+	versions, err := client.ListItemVersions(t.Context(), driveid.New("d"), "file-id")
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, "3.0", versions[0].ID)
+	assert.Equal(t, int64(300), versions[0].Size)
+	assert.Equal(t, 2024, versions[0].ModifiedAt.Year())
+	assert.Equal(t, "1.0", versions[2].ID)
+}
+
+func TestListItemVersions_NotFound(t *testing.T) {
+	assertGraphCallError(t, http.StatusNotFound, "req-versions-404", "itemNotFound", func(client *Client) error {
+		_, err := client.ListItemVersions(t.Context(), driveid.New("d"), "missing")
+		return err
+	}, ErrNotFound)
+}
@@ -280,7 +280,7 @@ func TestSimpleUpload_OctetStreamContentType(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	content := bytes.NewReader([]byte("hello"))
 	item, err := client.SimpleUpload(
-		t.Context(), driveid.New("drive1"), "parent1", "test.txt", content, 5,
+		t.Context(), driveid.New("drive1"), "parent1", "test.txt", content, 5, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "item-1", item.ID)
@@ -527,7 +527,7 @@ func TestUpload_SessionCanceledOnChunkError(t *testing.T) {
 	_, err := client.chunkedUploadEncapsulated(
 		t.Context(),
 		driveid.New("drive1"), "parent1", "big.txt",
-		content, int64(content.Len()), time.Time{}, nil,
+		content, int64(content.Len()), time.Time{}, nil, "",
 	)
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrForbidden)
@@ -583,7 +583,7 @@ func TestUpload_SimpleUploadFollowedByMtimePatch(t *testing.T) {
 	item, err := client.Upload(
 		t.Context(),
 		driveid.New("drive1"), "parent1", "small.txt",
-		content, int64(content.Len()), mtime, nil,
+		content, int64(content.Len()), mtime, nil, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "new-item", item.ID)
@@ -617,7 +617,7 @@ func TestUpload_SimpleUploadNoMtime(t *testing.T) {
 	_, err := client.Upload(
 		t.Context(),
 		driveid.New("drive1"), "parent1", "test.txt",
-		content, int64(content.Len()), time.Time{}, nil,
+		content, int64(content.Len()), time.Time{}, nil, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, int32(0), patchCalled.Load(),
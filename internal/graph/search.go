@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// SearchDrive runs Graph drive search for query below folderID in driveID.
+// An empty folderID or "root" searches the whole drive. The service matches
+// names, metadata and — where the drive supports it — file content.
+//
+// keep reports whether a hit should be returned; nil keeps every hit. When
+// limit > 0, pagination stops as soon as limit kept hits are collected.
+func (c *Client) SearchDrive(
+	ctx context.Context,
+	driveID driveid.ID,
+	folderID, query string,
+	limit int,
+	keep func(*Item) bool,
+) ([]Item, error) {
+	c.logger.Info("searching drive",
+		slog.String("drive_id", driveID.String()),
+		slog.String("folder_id", folderID),
+		slog.String("query", query),
+		slog.Int("limit", limit),
+	)
+
+	path := buildSearchPath(driveID, folderID, query)
+
+	var items []Item
+
+	for path != "" {
+		page, nextPath, err := c.fetchItemPage(ctx, path, "search")
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range page {
+			if keep != nil && !keep(&page[i]) {
+				continue
+			}
+
+			items = append(items, page[i])
+			if limit > 0 && len(items) >= limit {
+				c.logger.Info("search reached limit", slog.Int("count", len(items)))
+				return items, nil
+			}
+		}
+
+		path = nextPath
+	}
+
+	c.logger.Info("search returned items", slog.Int("count", len(items)))
+
+	return items, nil
+}
+
+// buildSearchPath builds the search(q='...') function path. OData string
+// literals escape a single quote by doubling it; the result is then
+// path-escaped so spaces survive as %20 rather than '+'.
+func buildSearchPath(driveID driveid.ID, folderID, query string) string {
+	q := url.PathEscape(strings.ReplaceAll(query, "'", "''"))
+
+	if folderID == "" || folderID == "root" {
+		return fmt.Sprintf("/drives/%s/root/search(q='%s')", driveID, q)
+	}
+
+	return fmt.Sprintf("/drives/%s/items/%s/search(q='%s')", driveID, folderID, q)
+}
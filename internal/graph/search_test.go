@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// Validates: R-1.11
+func TestBuildSearchPath(t *testing.T) {
+	t.Parallel()
+
+	d := driveid.New("d")
+
+	assert.Equal(t, "/drives/000000000000000d/root/search(q='budget%202024')", buildSearchPath(d, "", "budget 2024"))
+	assert.Equal(t, "/drives/000000000000000d/root/search(q='x')", buildSearchPath(d, "root", "x"))
+	assert.Equal(t, "/drives/000000000000000d/items/f1/search(q='it%27%27s')", buildSearchPath(d, "f1", "it's"))
+}
+
+// Validates: R-1.11
+func TestSearchDrive_PaginatesAndStopsAtLimit(t *testing.T) {
+	var calls int
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Query().Get("page") == "2" {
+			writeTestResponse(t, w, `{"value":[
+				{"id":"c","name":"c.txt","file":{}},
+				{"id":"d","name":"d.txt","file":{}}
+			],"@odata.nextLink":"`+srv.URL+`/drives/000000000000000d/root/search?page=3"}`)
+
+			return
+		}
+
+		assert.Equal(t, "/drives/000000000000000d/root/search(q='report')", r.URL.Path)
+		writeTestResponse(t, w, `{"value":[
+			{"id":"a","name":"a.txt","file":{}},
+			{"id":"dir","name":"Reports","folder":{"childCount":0}}
+		],"@odata.nextLink":"`+srv.URL+`/drives/000000000000000d/root/search?page=2"}`)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+	items, err := client.SearchDrive(t.Context(), driveid.New("d"), "", "report", 2, func(item *Item) bool {
+		return !item.IsFolder
+	})
+	require.NoError(t, err)
+
+	require.Len(t, items, 2)
+	assert.Equal(t, "a", items[0].ID)
+	assert.Equal(t, "c", items[1].ID)
+	assert.Equal(t, 2, calls, "search must stop paginating once the limit is reached")
+}
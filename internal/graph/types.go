@@ -73,6 +73,8 @@ type Item struct {
 	RemoteIsFolder    bool        // true when remoteItem.folder is present, even if the local placeholder is not a folder
 	SharedOwnerName   string      // sharer identity: remoteItem.shared.sharedBy → .owner → .createdBy → shared.owner
 	SharedOwnerEmail  string      // sharer email via same fallback chain as SharedOwnerName
+	CheckedOut        bool        // publication.level is "checkout" (SharePoint document libraries)
+	CheckedOutBy      string      // publication.checkedOutBy display name, falling back to email
 }
 
 // DeltaPage holds one page of delta query results.
@@ -102,6 +104,11 @@ type Drive struct {
 	OwnerEmail string // from owner.user.email; needed for shared drive display names (B-279)
 	QuotaUsed  int64
 	QuotaTotal int64
+	// QuotaDeleted is the space held by the recycle bin; it counts toward
+	// QuotaUsed until the bin is emptied.
+	QuotaDeleted   int64
+	QuotaRemaining int64
+	QuotaState     string // "normal", "nearing", "critical", "exceeded"
 }
 
 // Site represents a SharePoint site.
@@ -27,7 +27,9 @@ type uploadSessionItem struct {
 
 // fileSystemInfo preserves local timestamps on upload, preventing OneDrive
 // from overwriting them with server-side receipt time (double-versioning).
+// CreatedDateTime is only sent when a caller sets it explicitly.
 type fileSystemInfo struct {
+	CreatedDateTime      string `json:"createdDateTime,omitempty"`
 	LastModifiedDateTime string `json:"lastModifiedDateTime"`
 }
 
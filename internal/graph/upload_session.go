@@ -20,9 +20,10 @@ import (
 // CreateUploadSession creates a resumable upload session for a file.
 // The returned UploadSession contains a pre-authenticated upload URL.
 // When mtime is non-zero, fileSystemInfo is included in the request to
-// preserve the local modification timestamp on the server.
+// preserve the local modification timestamp on the server. An empty conflict
+// behavior means replace.
 func (c *Client) CreateUploadSession(
-	ctx context.Context, driveID driveid.ID, parentID, name string, size int64, mtime time.Time,
+	ctx context.Context, driveID driveid.ID, parentID, name string, size int64, mtime time.Time, conflict ConflictBehavior,
 ) (*UploadSession, error) {
 	c.logger.Info("creating upload session",
 		slog.String("drive_id", driveID.String()),
@@ -33,7 +34,7 @@ func (c *Client) CreateUploadSession(
 
 	path := fmt.Sprintf("/drives/%s/items/%s:/%s:/createUploadSession", driveID, parentID, url.PathEscape(name))
 
-	item := uploadSessionItem{ConflictBehavior: "replace"}
+	item := uploadSessionItem{ConflictBehavior: string(uploadConflictBehavior(conflict))}
 	if !mtime.IsZero() {
 		item.FileSystemInfo = &fileSystemInfo{
 			LastModifiedDateTime: mtime.UTC().Format(time.RFC3339),
@@ -75,7 +76,7 @@ func (c *Client) CreateUploadSessionForItem(
 
 	path := fmt.Sprintf("/drives/%s/items/%s/createUploadSession", driveID, itemID)
 
-	item := uploadSessionItem{ConflictBehavior: "replace"}
+	item := uploadSessionItem{ConflictBehavior: string(ConflictReplace)}
 	if !mtime.IsZero() {
 		item.FileSystemInfo = &fileSystemInfo{
 			LastModifiedDateTime: mtime.UTC().Format(time.RFC3339),
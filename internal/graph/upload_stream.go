@@ -0,0 +1,157 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// unknownUploadTotal is the Content-Range complete length sent for streamed
+// fragments before the end of the stream has been seen.
+const unknownUploadTotal = "*"
+
+// UploadStream uploads content of unknown length read sequentially from r,
+// such as stdin. Content that ends within SimpleUploadMaxSize is sent with a
+// simple upload. Longer content goes through an upload session one
+// ChunkedUploadChunkSize fragment at a time, reading one fragment ahead so
+// that only the final fragment has to carry the total size; earlier fragments
+// use "*" as the Content-Range complete length. At most two fragments are
+// buffered in memory. Returns the created item and the number of bytes read.
+// progress receives a total of 0 until the final fragment, and an empty
+// conflict behavior means replace.
+func (c *Client) UploadStream(
+	ctx context.Context, driveID driveid.ID, parentID, name string,
+	r io.Reader, mtime time.Time, progress ProgressFunc, conflict ConflictBehavior,
+) (*Item, int64, error) {
+	// Small streams (many tar entries, short pipes) should not pay for a full
+	// fragment buffer, so the simple-upload prefix is read first and only
+	// grown to a fragment when the stream turns out to be longer.
+	small, err := io.ReadAll(io.LimitReader(r, SimpleUploadMaxSize+1))
+	if err != nil {
+		return nil, 0, fmt.Errorf("graph: reading upload stream: %w", err)
+	}
+
+	if len(small) <= SimpleUploadMaxSize {
+		item, uploadErr := c.Upload(
+			ctx, driveID, parentID, name, bytes.NewReader(small), int64(len(small)), mtime, progress, conflict,
+		)
+		if uploadErr != nil {
+			return nil, 0, uploadErr
+		}
+
+		return item, int64(len(small)), nil
+	}
+
+	head := make([]byte, ChunkedUploadChunkSize)
+	copy(head, small)
+
+	n, err := io.ReadFull(r, head[len(small):])
+	n += len(small)
+
+	eof := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	if err != nil && !eof {
+		return nil, 0, fmt.Errorf("graph: reading upload stream: %w", err)
+	}
+
+	session, err := c.CreateUploadSession(ctx, driveID, parentID, name, -1, mtime, conflict)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	item, total, err := c.uploadStreamFragments(ctx, session, r, head[:n], eof, progress)
+	if err != nil {
+		// Best-effort cancel — detach from cancellation so the upload session is
+		// cleaned up even when the request context has already been canceled.
+		cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+		defer cancel()
+
+		cancelErr := c.CancelUploadSession(cancelCtx, session)
+		if cancelErr != nil {
+			c.logger.Warn("failed to cancel upload session after error",
+				slog.String("error", cancelErr.Error()),
+			)
+		}
+
+		return nil, 0, err
+	}
+
+	return item, total, nil
+}
+
+// uploadStreamFragments sends cur and every following fragment of r. A
+// fragment is known to be final only once the next read hits end of stream,
+// which is why reading runs one fragment ahead of uploading.
+func (c *Client) uploadStreamFragments(
+	ctx context.Context, session *UploadSession, r io.Reader,
+	cur []byte, eof bool, progress ProgressFunc,
+) (*Item, int64, error) {
+	next := make([]byte, ChunkedUploadChunkSize)
+
+	var offset int64
+
+	for {
+		nextN := 0
+		if !eof {
+			var err error
+
+			nextN, err = io.ReadFull(r, next)
+			eof = errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+			if err != nil && !eof {
+				return nil, 0, fmt.Errorf("graph: reading upload stream at offset %d: %w", offset+int64(len(cur)), err)
+			}
+		}
+
+		final := eof && nextN == 0
+		length := int64(len(cur))
+
+		total := unknownUploadTotal
+		if final {
+			total = strconv.FormatInt(offset+length, 10)
+		}
+
+		item, complete, err := c.uploadFragment(ctx, session, bytes.NewReader(cur), offset, length, total)
+		if err != nil {
+			if !errors.Is(err, ErrRangeNotSatisfiable) || final {
+				return nil, 0, fmt.Errorf("graph: uploading stream fragment at offset %d: %w", offset, err)
+			}
+
+			// Only a fragment the server already holds can be skipped; the
+			// stream cannot be rewound to resend anything earlier.
+			recovered, recoverErr := c.recoverUploadChunkOffset(ctx, session, offset)
+			if recoverErr != nil {
+				return nil, 0, recoverErr
+			}
+
+			if recovered != offset+length {
+				return nil, 0, fmt.Errorf("graph: upload session expects offset %d, stream is at %d", recovered, offset+length)
+			}
+		}
+
+		offset += length
+
+		if final {
+			if !complete || item == nil {
+				return nil, 0, fmt.Errorf("graph: upload stream ended at %d bytes but received no final item", offset)
+			}
+
+			if progress != nil {
+				progress(offset, offset)
+			}
+
+			return item, offset, nil
+		}
+
+		if progress != nil {
+			progress(offset, 0)
+		}
+
+		cur, next = next[:nextN], cur[:ChunkedUploadChunkSize]
+	}
+}
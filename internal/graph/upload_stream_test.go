@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// newUploadStreamTestClient serves createUploadSession from the Graph server
+// and records each fragment's Content-Range and size on the upload server.
+func newUploadStreamTestClient(t *testing.T, ranges *[]string, canceled *bool) *Client {
+	t.Helper()
+
+	chunkSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			*canceled = true
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		n, err := io.Copy(io.Discard, r.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		contentRange := r.Header.Get("Content-Range")
+		*ranges = append(*ranges, contentRange)
+		assert.Equal(t, r.ContentLength, n)
+
+		if strings.HasSuffix(contentRange, "/*") {
+			w.WriteHeader(http.StatusAccepted)
+			writeTestResponse(t, w, `{"nextExpectedRanges":[]}`)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		writeTestResponse(t, w, `{"id":"streamed","name":"db.sql.gz","file":{"hashes":{"quickXorHash":"aGFzaA=="}}}`)
+	}))
+	t.Cleanup(chunkSrv.Close)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if strings.HasSuffix(r.URL.Path, ":/content") {
+			*ranges = append(*ranges, "simple")
+			w.WriteHeader(http.StatusCreated)
+			writeTestResponse(t, w, `{"id":"simple","name":"small.txt"}`)
+
+			return
+		}
+
+		assert.Contains(t, r.URL.Path, "createUploadSession")
+		writeTestResponsef(t, w, `{"uploadUrl":"%s/upload","expirationDateTime":"2024-12-31T23:59:59Z"}`, chunkSrv.URL)
+	}))
+	t.Cleanup(srv.Close)
+
+	return newTestClient(t, srv.URL)
+}
+
+// Validates: R-1.3.7
+func TestUploadStream_SmallStreamUsesSimpleUpload(t *testing.T) {
+	var ranges []string
+	var canceled bool
+	client := newUploadStreamTestClient(t, &ranges, &canceled)
+
+	item, n, err := client.UploadStream(t.Context(), driveid.New("d"), "parent", "small.txt",
+		strings.NewReader("hello"), time.Time{}, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, "simple", item.ID)
+	assert.Equal(t, int64(5), n)
+	assert.Equal(t, []string{"simple"}, ranges)
+}
+
+// Validates: R-1.3.7
+func TestUploadStream_OnlyFinalFragmentCarriesTotal(t *testing.T) {
+	var ranges []string
+	var canceled bool
+	client := newUploadStreamTestClient(t, &ranges, &canceled)
+
+	size := 2*ChunkedUploadChunkSize + 7
+	var progressCalls [][2]int64
+
+	item, n, err := client.UploadStream(t.Context(), driveid.New("d"), "parent", "db.sql.gz",
+		bytes.NewReader(make([]byte, size)), time.Time{},
+		func(uploaded, total int64) { progressCalls = append(progressCalls, [2]int64{uploaded, total}) }, "")
+	require.NoError(t, err)
+	assert.Equal(t, "streamed", item.ID)
+	assert.Equal(t, int64(size), n)
+	assert.Equal(t, []string{
+		"bytes 0-10485759/*",
+		"bytes 10485760-20971519/*",
+		"bytes 20971520-20971526/20971527",
+	}, ranges)
+	assert.Equal(t, [][2]int64{
+		{ChunkedUploadChunkSize, 0},
+		{2 * ChunkedUploadChunkSize, 0},
+		{int64(size), int64(size)},
+	}, progressCalls)
+	assert.False(t, canceled)
+}
+
+// Validates: R-1.3.7
+func TestUploadStream_ExactFragmentMultiple(t *testing.T) {
+	var ranges []string
+	var canceled bool
+	client := newUploadStreamTestClient(t, &ranges, &canceled)
+
+	_, n, err := client.UploadStream(t.Context(), driveid.New("d"), "parent", "db.sql.gz",
+		bytes.NewReader(make([]byte, ChunkedUploadChunkSize)), time.Time{}, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(ChunkedUploadChunkSize), n)
+	assert.Equal(t, []string{"bytes 0-10485759/10485760"}, ranges)
+}
+
+func TestUploadStream_ReadErrorCancelsSession(t *testing.T) {
+	var ranges []string
+	var canceled bool
+	client := newUploadStreamTestClient(t, &ranges, &canceled)
+
+	src := io.MultiReader(
+		bytes.NewReader(make([]byte, ChunkedUploadChunkSize+1)),
+		&errorAfterReader{err: errors.New("pipe closed")},
+	)
+
+	_, _, err := client.UploadStream(t.Context(), driveid.New("d"), "parent", "db.sql.gz",
+		src, time.Time{}, nil, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pipe closed")
+	assert.True(t, canceled, "session should be canceled when the source fails")
+}
+
+type errorAfterReader struct {
+	err error
+}
+
+func (r *errorAfterReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -96,7 +96,7 @@ func TestSimpleUpload_Success(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	item, err := client.SimpleUpload(
 		t.Context(), driveid.New("d"), "parent", "upload.txt",
-		strings.NewReader(content), int64(len(content)),
+		strings.NewReader(content), int64(len(content)), "",
 	)
 	require.NoError(t, err)
 
@@ -131,7 +131,7 @@ func TestSimpleUpload_ConflictBehaviorReplace(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	item, err := client.SimpleUpload(
 		t.Context(), driveid.New("d"), "p", "conflict.txt",
-		strings.NewReader("data"), 4,
+		strings.NewReader("data"), 4, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "cb-item", item.ID)
@@ -160,7 +160,7 @@ func TestSimpleUpload_ContentType(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	_, err := client.SimpleUpload(
 		t.Context(), driveid.New("d"), "p", "binary.dat",
-		strings.NewReader("data"), 4,
+		strings.NewReader("data"), 4, "",
 	)
 	require.NoError(t, err)
 }
@@ -216,7 +216,7 @@ func TestSimpleUpload_Error(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	_, err := client.SimpleUpload(
 		t.Context(), driveid.New("d"), "p", "forbidden.txt",
-		strings.NewReader("data"), 4,
+		strings.NewReader("data"), 4, "",
 	)
 	require.Error(t, err)
 	assert.ErrorIs(t, err, ErrForbidden)
@@ -227,7 +227,7 @@ func TestSimpleUpload_TokenError(t *testing.T) {
 
 	_, err := client.SimpleUpload(
 		t.Context(), driveid.New("d"), "p", "file.txt",
-		strings.NewReader("data"), 4,
+		strings.NewReader("data"), 4, "",
 	)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "token")
@@ -238,7 +238,7 @@ func TestSimpleUpload_NetworkError(t *testing.T) {
 
 	_, err := client.SimpleUpload(
 		t.Context(), driveid.New("d"), "p", "file.txt",
-		strings.NewReader("data"), 4,
+		strings.NewReader("data"), 4, "",
 	)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "raw upload request failed")
@@ -254,7 +254,7 @@ func TestSimpleUpload_DecodeError(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	_, err := client.SimpleUpload(
 		t.Context(), driveid.New("d"), "p", "file.txt",
-		strings.NewReader("data"), 4,
+		strings.NewReader("data"), 4, "",
 	)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "decoding simple upload response")
@@ -283,7 +283,7 @@ func TestCreateUploadSession_Success(t *testing.T) {
 
 	client := newTestClient(t, srv.URL)
 	session, err := client.CreateUploadSession(
-		t.Context(), driveid.New("d"), "parent", "large-file.bin", 10485760, time.Time{},
+		t.Context(), driveid.New("d"), "parent", "large-file.bin", 10485760, time.Time{}, "",
 	)
 	require.NoError(t, err)
 
@@ -321,7 +321,7 @@ func TestCreateUploadSession_RetriesTransientNotFound(t *testing.T) {
 	client.uploadSessionCreatePolicy = testRetryPolicy()
 
 	session, err := client.CreateUploadSession(
-		t.Context(), driveid.New("d"), "parent", "retry-file.bin", 10485760, time.Time{},
+		t.Context(), driveid.New("d"), "parent", "retry-file.bin", 10485760, time.Time{}, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, UploadURL("https://uploads.contoso.sharepoint.com/session/retry123"), session.UploadURL)
@@ -374,7 +374,7 @@ func TestCreateUploadSession_PersonalUploadHost(t *testing.T) {
 
 	client := newTestClient(t, srv.URL)
 	session, err := client.CreateUploadSession(
-		t.Context(), driveid.New("d"), "parent", "large-file.bin", 10485760, time.Time{},
+		t.Context(), driveid.New("d"), "parent", "large-file.bin", 10485760, time.Time{}, "",
 	)
 	require.NoError(t, err)
 
@@ -398,7 +398,7 @@ func TestCreateUploadSession_InvalidExpiration(t *testing.T) {
 
 	client := newTestClient(t, srv.URL)
 	session, err := client.CreateUploadSession(
-		t.Context(), driveid.New("d"), "parent", "file.bin", 1024, time.Time{},
+		t.Context(), driveid.New("d"), "parent", "file.bin", 1024, time.Time{}, "",
 	)
 	require.NoError(t, err)
 
@@ -416,7 +416,7 @@ func TestCreateUploadSession_APIError(t *testing.T) {
 
 	client := newTestClient(t, srv.URL)
 	_, err := client.CreateUploadSession(
-		t.Context(), driveid.New("d"), "parent", "file.bin", 1024, time.Time{},
+		t.Context(), driveid.New("d"), "parent", "file.bin", 1024, time.Time{}, "",
 	)
 	require.Error(t, err)
 	assert.ErrorIs(t, err, ErrForbidden)
@@ -432,7 +432,7 @@ func TestCreateUploadSession_DecodeError(t *testing.T) {
 
 	client := newTestClient(t, srv.URL)
 	_, err := client.CreateUploadSession(
-		t.Context(), driveid.New("d"), "parent", "file.bin", 1024, time.Time{},
+		t.Context(), driveid.New("d"), "parent", "file.bin", 1024, time.Time{}, "",
 	)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "decoding upload session response")
@@ -697,7 +697,7 @@ func TestCreateUploadSession_WithFileSystemInfo(t *testing.T) {
 
 	client := newTestClient(t, srv.URL)
 	session, err := client.CreateUploadSession(
-		t.Context(), driveid.New("d"), "parent", "timestamped.bin", 5242880, mtime,
+		t.Context(), driveid.New("d"), "parent", "timestamped.bin", 5242880, mtime, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, UploadURL("https://uploads.contoso.sharepoint.com/session/fsi"), session.UploadURL)
@@ -727,7 +727,7 @@ func TestCreateUploadSession_WithoutFileSystemInfo(t *testing.T) {
 
 	client := newTestClient(t, srv.URL)
 	session, err := client.CreateUploadSession(
-		t.Context(), driveid.New("d"), "parent", "no-timestamp.bin", 5242880, time.Time{},
+		t.Context(), driveid.New("d"), "parent", "no-timestamp.bin", 5242880, time.Time{}, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, UploadURL("https://uploads.contoso.sharepoint.com/session/nofsi"), session.UploadURL)
@@ -905,7 +905,7 @@ func TestUpload_SimpleForSmallFile(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "parent", "small.txt",
-		bytes.NewReader(content), int64(len(content)), time.Time{}, nil,
+		bytes.NewReader(content), int64(len(content)), time.Time{}, nil, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "simple-item-id", item.ID)
@@ -972,7 +972,7 @@ func TestUpload_SimplePreservesMtime(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "parent", "mtime.txt",
-		bytes.NewReader(content), int64(len(content)), mtime, nil,
+		bytes.NewReader(content), int64(len(content)), mtime, nil, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "mtime-item", item.ID)
@@ -1007,7 +1007,7 @@ func TestUpload_SimpleSkipsPatchForZeroMtime(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "parent", "no-mtime.txt",
-		bytes.NewReader(content), int64(len(content)), time.Time{}, nil,
+		bytes.NewReader(content), int64(len(content)), time.Time{}, nil, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "no-mtime-item", item.ID)
@@ -1043,7 +1043,7 @@ func TestUpload_SimpleNotFoundFallsBackToUploadSessionPermissionError(t *testing
 	client := newTestClient(t, srv.URL)
 	_, err := client.Upload(
 		t.Context(), driveid.New("d"), "shared-folder", "blocked.txt",
-		bytes.NewReader(content), int64(len(content)), time.Time{}, nil,
+		bytes.NewReader(content), int64(len(content)), time.Time{}, nil, "",
 	)
 	require.ErrorIs(t, err, ErrForbidden)
 	assert.Equal(t, 1, simpleCalls)
@@ -1114,7 +1114,7 @@ func TestUpload_SimpleNotFoundFallsBackToUploadSessionSuccess(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "shared-folder", "shared.txt",
-		bytes.NewReader(content), int64(len(content)), time.Time{}, nil,
+		bytes.NewReader(content), int64(len(content)), time.Time{}, nil, "",
 	)
 	require.NoError(t, err)
 	require.NotNil(t, item)
@@ -1183,7 +1183,7 @@ func TestUpload_SimpleNotFoundFallbackSessionSuccessSkipsSimpleFinalizePatch(t *
 	client := newTestClient(t, srv.URL)
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "shared-folder", "shared.txt",
-		bytes.NewReader(content), int64(len(content)), mtime, nil,
+		bytes.NewReader(content), int64(len(content)), mtime, nil, "",
 	)
 	require.NoError(t, err)
 	require.NotNil(t, item)
@@ -1238,7 +1238,7 @@ func TestUpload_SimpleNotFoundThenSessionNotFoundRetriesSimpleUpload(t *testing.
 
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "fresh-parent", "fresh.txt",
-		bytes.NewReader(content), int64(len(content)), time.Time{}, nil,
+		bytes.NewReader(content), int64(len(content)), time.Time{}, nil, "",
 	)
 	require.NoError(t, err)
 	require.NotNil(t, item)
@@ -1309,7 +1309,7 @@ func TestUpload_SimpleRetryUsesLongerPostSessionBudget(t *testing.T) {
 
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "fresh-parent", "later.txt",
-		bytes.NewReader(content), int64(len(content)), time.Time{}, nil,
+		bytes.NewReader(content), int64(len(content)), time.Time{}, nil, "",
 	)
 	require.NoError(t, err)
 	require.NotNil(t, item)
@@ -1354,7 +1354,7 @@ func TestUpload_SimpleMtimePatchFailure(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	_, err := client.Upload(
 		t.Context(), driveid.New("d"), "parent", "fail.txt",
-		bytes.NewReader(content), int64(len(content)), mtime, nil,
+		bytes.NewReader(content), int64(len(content)), mtime, nil, "",
 	)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "setting mtime after simple upload")
@@ -1448,7 +1448,7 @@ func TestUpload_SimpleMtimePatchTransientRetries(t *testing.T) {
 
 			item, err := client.Upload(
 				t.Context(), driveid.New("d"), "parent", tt.fileName,
-				bytes.NewReader(tt.content), int64(len(tt.content)), mtime, nil,
+				bytes.NewReader(tt.content), int64(len(tt.content)), mtime, nil, "",
 			)
 			require.NoError(t, err)
 			require.NotNil(t, item)
@@ -1520,7 +1520,7 @@ func TestUpload_SimpleMtimePatchTransientNotFoundRetriesAcrossLongerLag(t *testi
 
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "parent", "retry-longer.txt",
-		bytes.NewReader(content), int64(len(content)), mtime, nil,
+		bytes.NewReader(content), int64(len(content)), mtime, nil, "",
 	)
 	require.NoError(t, err)
 	require.NotNil(t, item)
@@ -1594,7 +1594,7 @@ func TestUpload_ChunkedForLargeFile(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "parent", "large.bin",
-		bytes.NewReader(content), fileSize, time.Time{}, nil,
+		bytes.NewReader(content), fileSize, time.Time{}, nil, "",
 	)
 	require.NoError(t, err)
 	require.NotNil(t, item)
@@ -1638,7 +1638,7 @@ func TestUpload_ChunkedCancelsSessionOnError(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	_, err := client.Upload(
 		t.Context(), driveid.New("d"), "parent", "fail.bin",
-		bytes.NewReader(content), fileSize, time.Time{}, nil,
+		bytes.NewReader(content), fileSize, time.Time{}, nil, "",
 	)
 	require.Error(t, err)
 	assert.True(t, sessionCanceled, "session should be canceled on chunk failure")
@@ -1711,7 +1711,7 @@ func TestUpload_ProgressCallback(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "parent", "progress.bin",
-		bytes.NewReader(content), fileSize, time.Time{}, progress,
+		bytes.NewReader(content), fileSize, time.Time{}, progress, "",
 	)
 	require.NoError(t, err)
 	require.NotNil(t, item)
@@ -1747,7 +1747,7 @@ func TestUpload_NilProgress(t *testing.T) {
 	// Should not panic with nil progress.
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "parent", "nilprog.txt",
-		bytes.NewReader(content), int64(len(content)), time.Time{}, nil,
+		bytes.NewReader(content), int64(len(content)), time.Time{}, nil, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "nil-prog-item", item.ID)
@@ -2036,7 +2036,7 @@ func TestCreateUploadSession_NoIfMatchHeader(t *testing.T) {
 
 	client := newTestClient(t, srv.URL)
 	_, err := client.CreateUploadSession(
-		t.Context(), driveid.New("d"), "parent", "file.bin", 10485760, time.Time{},
+		t.Context(), driveid.New("d"), "parent", "file.bin", 10485760, time.Time{}, "",
 	)
 	require.NoError(t, err)
 }
@@ -2083,7 +2083,7 @@ func TestUpload_ChunkedCancelsSession_CanceledContext(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	_, err := client.Upload(
 		ctx, driveid.New("d"), "parent", "cancel-test.bin",
-		bytes.NewReader(content), fileSize, time.Time{}, nil,
+		bytes.NewReader(content), fileSize, time.Time{}, nil, "",
 	)
 	require.Error(t, err)
 	assert.Equal(t, int32(1), sessionCanceled.Load(),
@@ -2125,7 +2125,7 @@ func TestUpload_ZeroByte_UsesSimple(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "parent", "empty.txt",
-		bytes.NewReader(nil), 0, time.Time{}, nil,
+		bytes.NewReader(nil), 0, time.Time{}, nil, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "zero-byte-item", item.ID)
@@ -2166,7 +2166,7 @@ func TestUpload_NoPostUploadMetadataQuery(t *testing.T) {
 	client := newTestClient(t, srv.URL)
 	item, err := client.Upload(
 		t.Context(), driveid.New("d"), "parent", "norequery.txt",
-		bytes.NewReader(content), int64(len(content)), time.Time{}, nil,
+		bytes.NewReader(content), int64(len(content)), time.Time{}, nil, "",
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "no-requery-item", item.ID)
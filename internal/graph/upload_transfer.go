@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/tonimelisma/onedrive-go/internal/driveid"
@@ -16,9 +17,10 @@ import (
 
 // SimpleUpload uploads a file up to 4 MB using a single PUT request.
 // For larger files, use CreateUploadSession + UploadChunk.
-// The content is sent with application/octet-stream content type.
+// The content is sent with application/octet-stream content type. An empty
+// conflict behavior means replace.
 func (c *Client) SimpleUpload(
-	ctx context.Context, driveID driveid.ID, parentID, name string, r io.Reader, size int64,
+	ctx context.Context, driveID driveid.ID, parentID, name string, r io.Reader, size int64, conflict ConflictBehavior,
 ) (*Item, error) {
 	c.logger.Info("simple upload",
 		slog.String("drive_id", driveID.String()),
@@ -27,10 +29,12 @@ func (c *Client) SimpleUpload(
 		slog.Int64("size", size),
 	)
 
-	// Explicit conflictBehavior=replace prevents reliance on undocumented API
-	// defaults. CreateUploadSession passes this in the JSON body; SimpleUpload
-	// must use a query parameter since the body is the raw file content.
-	path := fmt.Sprintf("/drives/%s/items/%s:/%s:/content?@microsoft.graph.conflictBehavior=replace", driveID, parentID, url.PathEscape(name))
+	// Explicit conflictBehavior (replace unless the caller chose another) prevents
+	// reliance on undocumented API defaults. CreateUploadSession passes this in
+	// the JSON body; SimpleUpload must use a query parameter since the body is
+	// the raw file content.
+	path := fmt.Sprintf("/drives/%s/items/%s:/%s:/content", driveID, parentID, url.PathEscape(name)) +
+		conflictBehaviorQuery(uploadConflictBehavior(conflict))
 
 	resp, err := c.doRawUpload(ctx, http.MethodPut, path, "application/octet-stream", r)
 	if err != nil {
@@ -90,14 +94,24 @@ func (c *Client) SimpleUploadToItem(
 func (c *Client) UploadChunk(
 	ctx context.Context, session *UploadSession, chunk io.ReaderAt,
 	offset, length, total int64,
+) (*Item, bool, error) {
+	return c.uploadFragment(ctx, session, chunk, offset, length, strconv.FormatInt(total, 10))
+}
+
+// uploadFragment sends one upload-session fragment. total is the
+// Content-Range complete length: the decimal file size, or "*" while the
+// size of a streamed upload is still unknown.
+func (c *Client) uploadFragment(
+	ctx context.Context, session *UploadSession, chunk io.ReaderAt,
+	offset, length int64, total string,
 ) (*Item, bool, error) {
 	c.logger.Debug("uploading chunk",
 		slog.Int64("offset", offset),
 		slog.Int64("length", length),
-		slog.Int64("total", total),
+		slog.String("total", total),
 	)
 
-	contentRange := fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, total)
+	contentRange := fmt.Sprintf("bytes %d-%d/%s", offset, offset+length-1, total)
 
 	resp, err := c.doPreAuth(ctx, "upload chunk", func() (*http.Request, error) {
 		// Fresh SectionReader per attempt — io.ReaderAt.ReadAt is goroutine-safe,
@@ -250,13 +264,16 @@ func (c *Client) doRawUpload(
 // files up to 4 MiB or chunked (resumable) upload for larger files. The session
 // lifecycle (create, chunk loop, cancel-on-error) is fully encapsulated.
 // content must be an io.ReaderAt so that retries can re-read from arbitrary offsets.
-// progress may be nil if no progress reporting is needed.
+// progress may be nil if no progress reporting is needed, and an empty
+// conflict behavior means replace.
 func (c *Client) Upload(
 	ctx context.Context, driveID driveid.ID, parentID, name string,
-	content io.ReaderAt, size int64, mtime time.Time, progress ProgressFunc,
+	content io.ReaderAt, size int64, mtime time.Time, progress ProgressFunc, conflict ConflictBehavior,
 ) (*Item, error) {
 	if size <= SimpleUploadMaxSize {
-		item, needsFinalize, err := c.simpleUploadCreateByParent(ctx, driveID, parentID, name, content, size, mtime, progress)
+		item, needsFinalize, err := c.simpleUploadCreateByParent(
+			ctx, driveID, parentID, name, content, size, mtime, progress, conflict,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -267,7 +284,7 @@ func (c *Client) Upload(
 		return c.finalizeSimpleUpload(ctx, driveID, item, mtime)
 	}
 
-	return c.chunkedUploadEncapsulated(ctx, driveID, parentID, name, content, size, mtime, progress)
+	return c.chunkedUploadEncapsulated(ctx, driveID, parentID, name, content, size, mtime, progress, conflict)
 }
 
 // UploadToItem overwrites an existing file by item ID, automatically choosing
@@ -326,8 +343,9 @@ func (c *Client) simpleUploadCreateByParent(
 	size int64,
 	mtime time.Time,
 	progress ProgressFunc,
+	conflict ConflictBehavior,
 ) (*Item, bool, error) {
-	item, err := c.SimpleUpload(ctx, driveID, parentID, name, io.NewSectionReader(content, 0, size), size)
+	item, err := c.SimpleUpload(ctx, driveID, parentID, name, io.NewSectionReader(content, 0, size), size, conflict)
 	if err == nil {
 		return item, true, nil
 	}
@@ -347,7 +365,7 @@ func (c *Client) simpleUploadCreateByParent(
 		slog.Int64("size", size),
 	)
 
-	item, sessionErr := c.chunkedUploadEncapsulated(ctx, driveID, parentID, name, content, size, mtime, progress)
+	item, sessionErr := c.chunkedUploadEncapsulated(ctx, driveID, parentID, name, content, size, mtime, progress, conflict)
 	if sessionErr == nil || !errors.Is(sessionErr, ErrNotFound) {
 		return item, false, sessionErr
 	}
@@ -371,7 +389,7 @@ func (c *Client) simpleUploadCreateByParent(
 		policy: c.simpleUploadCreatePolicy,
 		match:  isTransientSimpleUploadCreateError,
 	}, func() (*Item, error) {
-		return c.SimpleUpload(ctx, driveID, parentID, name, io.NewSectionReader(content, 0, size), size)
+		return c.SimpleUpload(ctx, driveID, parentID, name, io.NewSectionReader(content, 0, size), size, conflict)
 	})
 	if retryErr != nil {
 		return nil, false, retryErr
@@ -384,9 +402,9 @@ func (c *Client) simpleUploadCreateByParent(
 // and cancels the session on any error. Fully encapsulates session lifecycle.
 func (c *Client) chunkedUploadEncapsulated(
 	ctx context.Context, driveID driveid.ID, parentID, name string,
-	content io.ReaderAt, size int64, mtime time.Time, progress ProgressFunc,
+	content io.ReaderAt, size int64, mtime time.Time, progress ProgressFunc, conflict ConflictBehavior,
 ) (*Item, error) {
-	session, err := c.CreateUploadSession(ctx, driveID, parentID, name, size, mtime)
+	session, err := c.CreateUploadSession(ctx, driveID, parentID, name, size, mtime, conflict)
 	if err != nil {
 		return nil, err
 	}
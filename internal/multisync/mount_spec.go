@@ -33,6 +33,7 @@ type StandaloneMountConfig struct {
 	Paused                 bool
 	EnableWebsocket        bool
 	RemoteRootDeltaCapable bool
+	DownloadOnly           bool
 	TransferWorkers        int
 	CheckWorkers           int
 	MinFreeSpaceBytes      int64
@@ -69,6 +70,7 @@ type mountRuntimeCommon struct {
 	paused                 bool
 	enableWebsocket        bool
 	remoteRootDeltaCapable bool
+	downloadOnly           bool
 	transferWorkers        int
 	checkWorkers           int
 	minFreeSpace           int64
@@ -91,6 +93,7 @@ type parentMountSpec struct {
 	paused                    bool
 	enableWebsocket           bool
 	remoteRootDeltaCapable    bool
+	downloadOnly              bool
 	transferWorkers           int
 	checkWorkers              int
 	minFreeSpace              int64
@@ -110,6 +113,7 @@ type childMountSpec struct {
 	paused                 bool
 	enableWebsocket        bool
 	remoteRootDeltaCapable bool
+	downloadOnly           bool
 	transferWorkers        int
 	checkWorkers           int
 	minFreeSpace           int64
@@ -386,6 +390,7 @@ func newParentMountSpec(cfg *StandaloneMountConfig) (parentMountSpec, error) {
 		paused:                    cfg.Paused,
 		enableWebsocket:           cfg.EnableWebsocket,
 		remoteRootDeltaCapable:    cfg.RemoteRootDeltaCapable,
+		downloadOnly:              cfg.DownloadOnly,
 		transferWorkers:           cfg.TransferWorkers,
 		checkWorkers:              cfg.CheckWorkers,
 		minFreeSpace:              cfg.MinFreeSpaceBytes,
@@ -418,6 +423,7 @@ func (spec *parentMountSpec) runtimeCommon() mountRuntimeCommon {
 		paused:                 spec.paused,
 		enableWebsocket:        spec.enableWebsocket,
 		remoteRootDeltaCapable: spec.remoteRootDeltaCapable,
+		downloadOnly:           spec.downloadOnly,
 		transferWorkers:        spec.transferWorkers,
 		checkWorkers:           spec.checkWorkers,
 		minFreeSpace:           spec.minFreeSpace,
@@ -478,6 +484,7 @@ func newChildMountSpec(
 		paused:                 parent.paused(),
 		enableWebsocket:        parent.enableWebsocket(),
 		remoteRootDeltaCapable: config.RemoteRootDeltaCapableForTokenOwner(tokenOwner),
+		downloadOnly:           parent.downloadOnly(),
 		transferWorkers:        parent.transferWorkers(),
 		checkWorkers:           parent.checkWorkers(),
 		minFreeSpace:           parent.minFreeSpace(),
@@ -513,6 +520,7 @@ func (spec *childMountSpec) runtimeCommon() mountRuntimeCommon {
 		paused:                 spec.paused,
 		enableWebsocket:        spec.enableWebsocket,
 		remoteRootDeltaCapable: spec.remoteRootDeltaCapable,
+		downloadOnly:           spec.downloadOnly,
 		transferWorkers:        spec.transferWorkers,
 		checkWorkers:           spec.checkWorkers,
 		minFreeSpace:           spec.minFreeSpace,
@@ -652,6 +660,21 @@ func (m *mountSpec) remoteRootDeltaCapable() bool {
 	return common != nil && common.remoteRootDeltaCapable
 }
 
+func (m *mountSpec) downloadOnly() bool {
+	common := m.common()
+	return common != nil && common.downloadOnly
+}
+
+// runMode narrows the requested sync mode for this mount. A mount whose
+// account signed in read-only cannot upload, so it always runs download-only,
+// including for a final-drain child.
+func (m *mountSpec) runMode(mode syncengine.SyncMode) syncengine.SyncMode {
+	if m.downloadOnly() {
+		return syncengine.SyncDownloadOnly
+	}
+	return mode
+}
+
 func (m *mountSpec) transferWorkers() int {
 	common := m.common()
 	if common == nil {
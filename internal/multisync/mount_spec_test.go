@@ -291,6 +291,31 @@ func TestBuildRuntimeWork_ParentPausePausesChildWithoutParentReservationSynthesi
 	assert.True(t, childMount.paused())
 }
 
+// Validates: R-3.1.11
+func TestBuildRuntimeWork_ReadOnlyParentRunsChildDownloadOnly(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	parent := testStandaloneMount(t, "personal:owner@example.com", "Parent")
+	parent.DownloadOnly = true
+
+	decisions, err := buildRuntimeWork(
+		[]StandaloneMountConfig{parent},
+		testParentSnapshots(&parent, testPublishedShortcutChild(t)),
+		t.TempDir(),
+	)
+	require.NoError(t, err)
+	require.Len(t, decisions.Mounts, 2)
+
+	for _, mount := range decisions.Mounts {
+		assert.Equal(t, syncengine.SyncDownloadOnly, mount.runMode(syncengine.SyncBidirectional), mount.label())
+		assert.Equal(t, syncengine.SyncDownloadOnly, mount.runMode(syncengine.SyncUploadOnly), mount.label())
+	}
+
+	parent.DownloadOnly = false
+	mounts, err := buildStandaloneMountSpecs([]StandaloneMountConfig{parent})
+	require.NoError(t, err)
+	assert.Equal(t, syncengine.SyncUploadOnly, mounts[0].runMode(syncengine.SyncUploadOnly))
+}
+
 // Validates: R-2.8.1
 func TestBuildRuntimeWork_ParentBlockedSnapshotHasNoChildWork(t *testing.T) {
 	t.Setenv("XDG_DATA_HOME", t.TempDir())
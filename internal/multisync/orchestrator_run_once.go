@@ -259,7 +259,7 @@ func (o *Orchestrator) buildEngineWork(
 				runMode = syncengine.SyncBidirectional
 				runOpts.FullReconcile = true
 			}
-			return engine.RunOnce(c, runMode, runOpts)
+			return engine.RunOnce(c, mount.runMode(runMode), runOpts)
 		},
 	}, nil
 }
@@ -269,7 +269,9 @@ func (o *Orchestrator) startWatchRunner(
 		defer o.removeMountPerfCollector(mount.id().String())
 
 		if mount.isFinalDrainChild() {
-			report, drainErr := engine.RunOnce(mountCtx, syncengine.SyncBidirectional, syncengine.RunOptions{FullReconcile: true})
+			report, drainErr := engine.RunOnce(
+				mountCtx, mount.runMode(syncengine.SyncBidirectional), syncengine.RunOptions{FullReconcile: true},
+			)
 			if drainErr != nil && mountCtx.Err() == nil {
 				o.logger.Error("final-drain watch runner exited with error",
 					slog.String("mount_id", mount.id().String()),
@@ -285,7 +287,7 @@ func (o *Orchestrator) startWatchRunner(
 			return
 		}
 
-		if watchErr := engine.RunWatch(mountCtx, mode, opts); watchErr != nil {
+		if watchErr := engine.RunWatch(mountCtx, mount.runMode(mode), opts); watchErr != nil {
 			if mountCtx.Err() == nil {
 				o.logger.Error("watch runner exited with error",
 					slog.String("mount_id", mount.id().String()),
@@ -14,6 +14,8 @@ const (
 	copyDestinationAttempts      = 6
 	simpleUploadCreateAttempts   = 7
 	pathVisibilityAttempts       = 10
+	batchThrottleAttempts        = 5
+	streamResumeAttempts         = 5
 	infiniteAttempts             = 0
 	standardMultiplier           = 2.0
 	standardJitter               = 0.25
@@ -33,6 +35,7 @@ const (
 	simpleUploadCreateMaxDelay   = 8 * time.Second
 	pathVisibilityBaseDelay      = 250 * time.Millisecond
 	pathVisibilityMaxDelay       = 32 * time.Second
+	streamResumeMaxDelay         = 16 * time.Second
 	transportMaxDelay            = 60 * time.Second
 	watchLocalMaxDelay           = 30 * time.Second
 	watchRemoteBaseDelay         = 5 * time.Second
@@ -165,6 +168,34 @@ func PathVisibilityPolicy() Policy {
 	}
 }
 
+// BatchThrottlePolicy bounds how often graph.Client.Batch resends the
+// sub-requests of a JSON batch that came back throttled (429/503). The
+// transport only sees the outer POST succeed, so these retries live with the
+// batch caller; a sub-response's Retry-After stretches the wait up to Max.
+func BatchThrottlePolicy() Policy {
+	return Policy{
+		MaxAttempts: batchThrottleAttempts,
+		Base:        defaultBaseDelay,
+		Max:         transportMaxDelay,
+		Multiplier:  standardMultiplier,
+		Jitter:      standardJitter,
+	}
+}
+
+// StreamResumePolicy bounds how often a content stream that already wrote
+// bytes (e.g. `cat` to stdout) resumes with a Range request after a
+// transient mid-stream failure. The stream cannot be rewound, so each attempt
+// continues from the last byte written rather than starting over.
+func StreamResumePolicy() Policy {
+	return Policy{
+		MaxAttempts: streamResumeAttempts,
+		Base:        defaultBaseDelay,
+		Max:         streamResumeMaxDelay,
+		Multiplier:  standardMultiplier,
+		Jitter:      standardJitter,
+	}
+}
+
 // WatchLocalPolicy is the local observer error backoff policy
 // (observer_local.go). Infinite attempts (watch loop), 1s base, 30s max,
 // 2x multiplier, no jitter.
@@ -110,4 +110,5 @@ type ActionOutcome struct {
 	LocalMtime            int64 // local mtime at sync time
 	RemoteMtime           int64 // remote mtime at sync time; zero means unknown
 	ETag                  string
+	CTag                  string // content tag; unchanged by metadata-only edits
 }
@@ -105,7 +105,9 @@ type ItemClient interface {
 	GetItemByPath(ctx context.Context, driveID driveid.ID, remotePath string) (*graph.Item, error)
 	ListChildren(ctx context.Context, driveID driveid.ID, parentID string) ([]graph.Item, error)
 	CreateFolder(ctx context.Context, driveID driveid.ID, parentID, name string) (*graph.Item, error)
-	MoveItem(ctx context.Context, driveID driveid.ID, itemID, newParentID, newName string) (*graph.Item, error)
+	MoveItem(
+		ctx context.Context, driveID driveid.ID, itemID, newParentID, newName string, conflict graph.ConflictBehavior,
+	) (*graph.Item, error)
 	MoveItemIfMatch(ctx context.Context, driveID driveid.ID, itemID, newParentID, newName, ifMatch string) (*graph.Item, error)
 	DeleteItem(ctx context.Context, driveID driveid.ID, itemID string) error
 	DeleteItemIfMatch(ctx context.Context, driveID driveid.ID, itemID, ifMatch string) error
@@ -130,9 +132,28 @@ type RecursiveLister interface {
 	ListChildrenRecursive(ctx context.Context, driveID driveid.ID, folderID string) ([]graph.Item, error)
 }
 
-// PermissionChecker provides permission queries on drive items.
+// PermissionChecker provides permission queries on drive items. The batch
+// form answers a whole ancestor chain with one JSON batch request.
 type PermissionChecker interface {
 	ListItemPermissions(ctx context.Context, driveID driveid.ID, itemID string) ([]graph.Permission, error)
+	ListItemPermissionsBatch(ctx context.Context, driveID driveid.ID, itemIDs []string) ([]graph.BatchPermissionsResult, error)
+}
+
+// CheckoutClient checks files in SharePoint document libraries out and
+// back in. Libraries that require check-out reject overwrites of files that
+// are not checked out with 423.
+type CheckoutClient interface {
+	CheckoutItem(ctx context.Context, driveID driveid.ID, itemID string) error
+	CheckinItem(ctx context.Context, driveID driveid.ID, itemID, comment string) error
+	DiscardCheckout(ctx context.Context, driveID driveid.ID, itemID string) error
+}
+
+// CheckoutMarkers records the items sync itself checked out, so only those
+// are ever checked in on the user's behalf.
+type CheckoutMarkers interface {
+	MarkSyncCheckout(ctx context.Context, driveID driveid.ID, itemID string) error
+	ClearSyncCheckout(ctx context.Context, driveID driveid.ID, itemID string) error
+	HasSyncCheckout(ctx context.Context, driveID driveid.ID, itemID string) (bool, error)
 }
 
 type engineInputs struct {
@@ -156,6 +177,7 @@ type engineInputs struct {
 	FolderDelta              FolderDeltaFetcher
 	RecursiveLister          RecursiveLister
 	PermChecker              PermissionChecker
+	Checkouts                CheckoutClient
 	Logger                   *slog.Logger
 	ContentFilter            ContentFilterConfig
 	LocalRules               LocalObservationRules
@@ -1639,7 +1639,7 @@ func TestConsolidatedSchema_AllTablesCreated(t *testing.T) {
 	expectedTables := []string{
 		"baseline", "local_state", "observation_state",
 		"observation_issues", "retry_work", "remote_state", "block_scopes",
-		"shortcut_roots",
+		"shortcut_roots", "sync_checkouts",
 	}
 
 	for _, table := range expectedTables {
@@ -85,6 +85,7 @@ type BaselineEntry struct {
 	LocalInode       uint64
 	LocalHasIdentity bool
 	ETag             string
+	CTag             string
 }
 
 // DirLowerKey groups baseline entries by (directory, lowercase name) for
@@ -122,6 +122,11 @@ func newEngine(ctx context.Context, cfg *engineInputs) (*Engine, error) {
 	)
 	execCfg.SetRemoteRootItemID(cfg.RemoteRootItemID)
 	execCfg.SetContentFilter(cfg.ContentFilter)
+	if cfg.DriveType != driveid.DriveTypePersonal {
+		// Check-out is a SharePoint document library feature.
+		execCfg.SetCheckoutClient(cfg.Checkouts)
+		execCfg.SetCheckoutMarkers(bm)
+	}
 
 	// Construct sessionStore and TransferManager together so the TM is
 	// immutable after creation (no post-hoc field mutation). Disk space
@@ -84,6 +84,7 @@ func NewMountEngine(
 		FolderDelta:              session.Meta,
 		RecursiveLister:          session.Meta,
 		PermChecker:              session.Meta,
+		Checkouts:                session.Meta,
 		Logger:                   logger,
 		EnableWebsocket:          mountCfg.EnableWebsocket,
 		ContentFilter:            mountCfg.ContentFilter,
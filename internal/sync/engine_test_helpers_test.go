@@ -131,6 +131,18 @@ func (m *engineMockClient) ListItemPermissions(ctx context.Context, driveID driv
 	return nil, nil
 }
 
+func (m *engineMockClient) ListItemPermissionsBatch(
+	ctx context.Context, driveID driveid.ID, itemIDs []string,
+) ([]graph.BatchPermissionsResult, error) {
+	out := make([]graph.BatchPermissionsResult, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		perms, err := m.ListItemPermissions(ctx, driveID, itemID)
+		out = append(out, graph.BatchPermissionsResult{Permissions: perms, Err: err})
+	}
+
+	return out, nil
+}
+
 func (m *engineMockClient) GetItem(ctx context.Context, driveID driveid.ID, itemID string) (*graph.Item, error) {
 	if m.getItemFn != nil {
 		return m.getItemFn(ctx, driveID, itemID)
@@ -163,7 +175,7 @@ func (m *engineMockClient) CreateFolder(ctx context.Context, driveID driveid.ID,
 	return &graph.Item{ID: "new-folder-id"}, nil
 }
 
-func (m *engineMockClient) MoveItem(ctx context.Context, driveID driveid.ID, itemID, newParentID, newName string) (*graph.Item, error) {
+func (m *engineMockClient) MoveItem(ctx context.Context, driveID driveid.ID, itemID, newParentID, newName string, _ graph.ConflictBehavior) (*graph.Item, error) {
 	if m.moveItemIfMatchFn != nil {
 		return m.moveItemIfMatchFn(ctx, driveID, itemID, newParentID, newName, "")
 	}
@@ -233,7 +245,7 @@ func (m *engineMockClient) Download(ctx context.Context, driveID driveid.ID, ite
 	return int64(n), err
 }
 
-func (m *engineMockClient) Upload(ctx context.Context, driveID driveid.ID, parentID, name string, content io.ReaderAt, size int64, mtime time.Time, progress graph.ProgressFunc) (*graph.Item, error) {
+func (m *engineMockClient) Upload(ctx context.Context, driveID driveid.ID, parentID, name string, content io.ReaderAt, size int64, mtime time.Time, progress graph.ProgressFunc, _ graph.ConflictBehavior) (*graph.Item, error) {
 	if m.uploadFn != nil {
 		return m.uploadFn(ctx, driveID, parentID, name, content, size, mtime, progress)
 	}
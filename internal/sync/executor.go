@@ -44,6 +44,14 @@ type ExecutorConfig struct {
 	// driveops.WithDiskCheck when constructing the TransferManager.
 	transferMgr *driveops.TransferManager
 
+	// checkouts, when set, lets an overwrite that a check-out-enforcing
+	// library rejects with 423 check the file out and retry.
+	checkouts CheckoutClient
+
+	// checkoutMarkers records the check-outs sync made itself. Without it a
+	// check-in that failed is left for the user to finish.
+	checkoutMarkers CheckoutMarkers
+
 	// Injectable for testing.
 	nowFunc         func() time.Time
 	hashFunc        func(filePath string) (string, error)
@@ -92,6 +100,17 @@ func (cfg *ExecutorConfig) SetRemoteRootItemID(itemID string) {
 	cfg.remoteRootItemID = itemID
 }
 
+// SetCheckoutClient enables automatic check-out before overwrites in
+// SharePoint libraries that require it. Nil disables it.
+func (cfg *ExecutorConfig) SetCheckoutClient(client CheckoutClient) {
+	cfg.checkouts = client
+}
+
+// SetCheckoutMarkers sets where the check-outs sync makes are recorded.
+func (cfg *ExecutorConfig) SetCheckoutMarkers(markers CheckoutMarkers) {
+	cfg.checkoutMarkers = markers
+}
+
 // SetContentFilter installs executor-relevant cleanup policy from the
 // sync-owned content filter. The executor does not decide visibility; it only
 // needs to know whether ignored junk may be removed when blocking a folder
@@ -433,6 +452,7 @@ func (e *Executor) ExecuteRemoteMove(ctx context.Context, action *Action) Action
 	o := e.moveOutcome(action)
 	o.ItemID = item.ID
 	o.ETag = item.ETag
+	o.CTag = item.CTag
 
 	return o
 }
@@ -641,6 +661,7 @@ func (e *Executor) moveOutcome(action *Action) ActionOutcome {
 			o.RemoteSizeKnown = true
 			o.RemoteMtime = action.View.Remote.Mtime
 			o.ETag = action.View.Remote.ETag
+			o.CTag = action.View.Remote.CTag
 			o.ItemType = action.View.Remote.ItemType
 		}
 
@@ -694,4 +715,7 @@ func fillOutcomeFromBaseline(o *ActionOutcome, baseline *BaselineEntry) {
 	if o.ETag == "" {
 		o.ETag = baseline.ETag
 	}
+	if o.CTag == "" {
+		o.CTag = baseline.CTag
+	}
 }
@@ -181,6 +181,7 @@ func remoteStateRowFromGraphItem(item *graph.Item) *RemoteStateRow {
 		Size:     item.Size,
 		Mtime:    mtime,
 		ETag:     item.ETag,
+		CTag:     item.CTag,
 	}
 }
 
@@ -204,6 +205,7 @@ func plannedRemoteStateForPrecondition(action *Action) *RemoteState {
 		Size:     baseline.RemoteSize,
 		Mtime:    baseline.RemoteMtime,
 		ETag:     baseline.ETag,
+		CTag:     baseline.CTag,
 	}
 }
 
@@ -2,8 +2,10 @@ package sync
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -49,7 +51,7 @@ type executorMockUploader struct {
 	uploadToItemFn func(ctx context.Context, driveID driveid.ID, itemID string, content io.ReaderAt, size int64, mtime time.Time, progress graph.ProgressFunc) (*graph.Item, error)
 }
 
-func (m *executorMockUploader) Upload(ctx context.Context, driveID driveid.ID, parentID, name string, content io.ReaderAt, size int64, mtime time.Time, progress graph.ProgressFunc) (*graph.Item, error) {
+func (m *executorMockUploader) Upload(ctx context.Context, driveID driveid.ID, parentID, name string, content io.ReaderAt, size int64, mtime time.Time, progress graph.ProgressFunc, _ graph.ConflictBehavior) (*graph.Item, error) {
 	if m.uploadFn != nil {
 		return m.uploadFn(ctx, driveID, parentID, name, content, size, mtime, progress)
 	}
@@ -1485,6 +1487,205 @@ func TestExecutor_Upload_KnownItemUsesItemOverwrite(t *testing.T) {
 	assert.Equal(t, "parent-from-item", o.ParentID)
 }
 
+// recordingCheckoutClient records check-out calls made by the executor.
+type recordingCheckoutClient struct {
+	calls       []string
+	checkoutErr error
+	checkinErrs []error // returned by successive check-ins, then nil
+}
+
+func (c *recordingCheckoutClient) CheckoutItem(_ context.Context, _ driveid.ID, itemID string) error {
+	c.calls = append(c.calls, "checkout "+itemID)
+	return c.checkoutErr
+}
+
+func (c *recordingCheckoutClient) CheckinItem(_ context.Context, _ driveid.ID, itemID, comment string) error {
+	c.calls = append(c.calls, "checkin "+itemID+" "+comment)
+	if len(c.checkinErrs) == 0 {
+		return nil
+	}
+
+	err := c.checkinErrs[0]
+	c.checkinErrs = c.checkinErrs[1:]
+
+	return err
+}
+
+func (c *recordingCheckoutClient) DiscardCheckout(_ context.Context, _ driveid.ID, itemID string) error {
+	c.calls = append(c.calls, "discard "+itemID)
+	return nil
+}
+
+func newLockedOverwriteExecution(t *testing.T, checkouts CheckoutClient) (*Executor, *Action, *int) {
+	t.Helper()
+
+	uploads := 0
+	ul := &executorMockUploader{
+		uploadToItemFn: func(_ context.Context, _ driveid.ID, itemID string, _ io.ReaderAt, _ int64, _ time.Time, _ graph.ProgressFunc) (*graph.Item, error) {
+			uploads++
+			if uploads == 1 {
+				return nil, &graph.GraphError{StatusCode: http.StatusLocked, Err: graph.ErrLocked}
+			}
+
+			return &graph.Item{ID: itemID, ParentID: "parent", ETag: "etag-upload", QuickXorHash: "hash"}, nil
+		},
+	}
+	items := &executorMockItemClient{
+		getItemFn: func(_ context.Context, _ driveid.ID, itemID string) (*graph.Item, error) {
+			return &graph.Item{ID: itemID, ParentID: "parent", ETag: "etag-checked-in", QuickXorHash: "hash"}, nil
+		},
+	}
+
+	cfg, syncRoot := newTestExecutorConfig(t, items, &executorMockDownloader{}, ul)
+	cfg.SetCheckoutClient(checkouts)
+	e := NewExecution(cfg, emptyBaseline())
+	writeExecTestFile(t, syncRoot, "locked.docx", "new content")
+
+	action := &Action{
+		Type:    ActionUpload,
+		Path:    "locked.docx",
+		ItemID:  "locked-id",
+		DriveID: driveid.New(synctest.TestDriveID),
+		View:    &PathView{Path: "locked.docx", Baseline: &BaselineEntry{ParentID: "parent"}},
+	}
+
+	return e, action, &uploads
+}
+
+// Validates: R-1.22.3
+func TestExecutor_Upload_LockedOverwriteChecksOutAndIn(t *testing.T) {
+	t.Parallel()
+
+	checkouts := &recordingCheckoutClient{}
+	e, action, uploads := newLockedOverwriteExecution(t, checkouts)
+
+	o := e.ExecuteUpload(t.Context(), action)
+	requireOutcomeSuccess(t, &o)
+
+	assert.Equal(t, 2, *uploads)
+	assert.Equal(t, []string{"checkout locked-id", "checkin locked-id " + syncCheckinComment}, checkouts.calls)
+	assert.Equal(t, "etag-checked-in", o.ETag, "the outcome carries the eTag published by check-in")
+}
+
+// Validates: R-1.22.3
+func TestExecutor_Upload_LockedOverwriteHeldElsewhereKeepsLockError(t *testing.T) {
+	t.Parallel()
+
+	checkouts := &recordingCheckoutClient{checkoutErr: graph.ErrLocked}
+	e, action, uploads := newLockedOverwriteExecution(t, checkouts)
+
+	o := e.ExecuteUpload(t.Context(), action)
+	assert.False(t, o.Success)
+	require.ErrorIs(t, o.Error, graph.ErrLocked)
+	assert.Equal(t, 1, *uploads)
+	assert.Equal(t, []string{"checkout locked-id"}, checkouts.calls)
+}
+
+// Validates: R-1.22.3
+func TestExecutor_Upload_LockedOverwriteRetriesFailedCheckin(t *testing.T) {
+	t.Parallel()
+
+	checkin := "checkin locked-id " + syncCheckinComment
+	checkouts := &recordingCheckoutClient{checkinErrs: []error{errors.New("service unavailable")}}
+	e, action, _ := newLockedOverwriteExecution(t, checkouts)
+
+	o := e.ExecuteUpload(t.Context(), action)
+	requireOutcomeSuccess(t, &o)
+	assert.Equal(t, []string{"checkout locked-id", checkin, checkin}, checkouts.calls)
+
+	checkouts = &recordingCheckoutClient{checkinErrs: []error{errors.New("down"), errors.New("still down")}}
+	e, action, _ = newLockedOverwriteExecution(t, checkouts)
+
+	o = e.ExecuteUpload(t.Context(), action)
+	assert.False(t, o.Success)
+	require.ErrorContains(t, o.Error, "checking in locked.docx after upload: still down")
+	assert.Equal(t, []string{"checkout locked-id", checkin, checkin}, checkouts.calls, "no discard: the upload stands")
+}
+
+// memoryCheckoutMarkers is an in-memory CheckoutMarkers.
+type memoryCheckoutMarkers struct {
+	marked map[string]bool
+}
+
+func (m *memoryCheckoutMarkers) MarkSyncCheckout(_ context.Context, _ driveid.ID, itemID string) error {
+	m.marked[itemID] = true
+
+	return nil
+}
+
+func (m *memoryCheckoutMarkers) ClearSyncCheckout(_ context.Context, _ driveid.ID, itemID string) error {
+	delete(m.marked, itemID)
+
+	return nil
+}
+
+func (m *memoryCheckoutMarkers) HasSyncCheckout(_ context.Context, _ driveid.ID, itemID string) (bool, error) {
+	return m.marked[itemID], nil
+}
+
+// newCheckedOutOverwriteExecution uploads over locked-id without a 423, as
+// only the holder of a check-out can, answering that the item is still
+// checked out.
+func newCheckedOutOverwriteExecution(
+	t *testing.T, checkouts CheckoutClient, markers CheckoutMarkers,
+) (*Executor, *Action) {
+	t.Helper()
+
+	ul := &executorMockUploader{
+		uploadToItemFn: func(_ context.Context, _ driveid.ID, itemID string, _ io.ReaderAt, _ int64, _ time.Time, _ graph.ProgressFunc) (*graph.Item, error) {
+			return &graph.Item{ID: itemID, ParentID: "parent", ETag: "etag-upload", QuickXorHash: "hash", CheckedOut: true}, nil
+		},
+	}
+	items := &executorMockItemClient{
+		getItemFn: func(_ context.Context, _ driveid.ID, itemID string) (*graph.Item, error) {
+			return &graph.Item{ID: itemID, ParentID: "parent", ETag: "etag-checked-in", QuickXorHash: "hash"}, nil
+		},
+	}
+
+	cfg, syncRoot := newTestExecutorConfig(t, items, &executorMockDownloader{}, ul)
+	cfg.SetCheckoutClient(checkouts)
+	cfg.SetCheckoutMarkers(markers)
+	e := NewExecution(cfg, emptyBaseline())
+	writeExecTestFile(t, syncRoot, "locked.docx", "new content")
+
+	return e, &Action{
+		Type:    ActionUpload,
+		Path:    "locked.docx",
+		ItemID:  "locked-id",
+		DriveID: driveid.New(synctest.TestDriveID),
+		View:    &PathView{Path: "locked.docx", Baseline: &BaselineEntry{ParentID: "parent"}},
+	}
+}
+
+// Validates: R-1.22.3
+func TestExecutor_Upload_UserHeldCheckoutStaysCheckedOut(t *testing.T) {
+	t.Parallel()
+
+	checkouts := &recordingCheckoutClient{}
+	e, action := newCheckedOutOverwriteExecution(t, checkouts, &memoryCheckoutMarkers{marked: map[string]bool{}})
+
+	o := e.ExecuteUpload(t.Context(), action)
+	requireOutcomeSuccess(t, &o)
+	assert.Empty(t, checkouts.calls, "a check-out the user made is never checked in by sync")
+	assert.Equal(t, "etag-upload", o.ETag)
+}
+
+// Validates: R-1.22.3
+func TestExecutor_Upload_FinishesCheckinOfSyncCheckout(t *testing.T) {
+	t.Parallel()
+
+	checkouts := &recordingCheckoutClient{}
+	markers := &memoryCheckoutMarkers{marked: map[string]bool{"locked-id": true}}
+	e, action := newCheckedOutOverwriteExecution(t, checkouts, markers)
+
+	o := e.ExecuteUpload(t.Context(), action)
+	requireOutcomeSuccess(t, &o)
+	assert.Equal(t, []string{"checkin locked-id " + syncCheckinComment}, checkouts.calls,
+		"a check-out sync made on an earlier run is finished")
+	assert.Equal(t, "etag-checked-in", o.ETag)
+	assert.Empty(t, markers.marked)
+}
+
 func TestExecutor_Upload_B068_ZeroDriveIDFilled(t *testing.T) {
 	t.Parallel()
 
@@ -3,10 +3,13 @@ package sync
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"path/filepath"
 
 	"github.com/tonimelisma/onedrive-go/internal/driveid"
 	"github.com/tonimelisma/onedrive-go/internal/driveops"
+	"github.com/tonimelisma/onedrive-go/internal/graph"
 )
 
 // maxHashRetries is the number of additional download attempts when the
@@ -17,6 +20,10 @@ import (
 // an infinite re-download loop (B-132).
 const maxHashRetries = 2
 
+// syncCheckinComment is the version comment recorded when sync checks a file
+// in after overwriting it in a library that enforces check-out.
+const syncCheckinComment = "Uploaded by onedrive-go sync"
+
 // ExecuteDownload downloads a remote file via TransferManager with .partial
 // safety, hash verification with retry, and atomic rename.
 func (e *Executor) ExecuteDownload(ctx context.Context, action *Action) ActionOutcome {
@@ -103,6 +110,7 @@ func (e *Executor) downloadOutcome(
 
 	if action.View != nil && action.View.Remote != nil {
 		o.ETag = action.View.Remote.ETag
+		o.CTag = action.View.Remote.CTag
 		o.LocalMtime = action.View.Remote.Mtime
 		o.RemoteMtime = action.View.Remote.Mtime
 		o.RemoteSize = action.View.Remote.Size
@@ -163,7 +171,7 @@ func (e *Executor) ExecuteUpload(ctx context.Context, action *Action) ActionOutc
 	)
 
 	if shouldOverwriteKnownRemoteItem(action) {
-		result, err = e.transferMgr.UploadFileToItem(ctx, driveID, action.ItemID, localPath, e.uploadOpts(action))
+		result, err = e.uploadToKnownItem(ctx, driveID, action, localPath)
 		if err != nil {
 			return e.failedOutcomeWithFailure(
 				action,
@@ -258,6 +266,7 @@ func (e *Executor) uploadOutcome(
 		LocalMtime:      result.Mtime.UnixNano(),
 		RemoteMtime:     remoteMtime,
 		ETag:            result.Item.ETag,
+		CTag:            result.Item.CTag,
 	}
 	if action.View != nil && action.View.Local != nil {
 		outcome.LocalDevice = action.View.Local.LocalDevice
@@ -292,6 +301,138 @@ func (e *Executor) validateRemoteUploadPrecondition(ctx context.Context, driveID
 	return e.validateRemoteSourcePrecondition(ctx, driveID, action, "upload overwrite")
 }
 
+// uploadToKnownItem overwrites an existing remote file. When a library that
+// enforces check-out rejects the overwrite with 423, the file is checked out,
+// uploaded again and checked back in so the new content is published. The
+// check-out is recorded until it is checked in or discarded, so a check-in
+// an earlier run could not complete is finished by the next overwrite while
+// check-outs the user made are left alone.
+func (e *Executor) uploadToKnownItem(
+	ctx context.Context, driveID driveid.ID, action *Action, localPath string,
+) (*driveops.UploadResult, error) {
+	result, err := e.transferMgr.UploadFileToItem(ctx, driveID, action.ItemID, localPath, e.uploadOpts(action))
+	if err == nil {
+		return e.finishSyncCheckout(ctx, driveID, action, result)
+	}
+
+	if e.checkouts == nil || !errors.Is(err, graph.ErrLocked) {
+		return nil, err
+	}
+
+	if checkoutErr := e.checkouts.CheckoutItem(ctx, driveID, action.ItemID); checkoutErr != nil {
+		// Locked by someone else (or check-out unsupported): keep the
+		// original 423 so the failure is classified as a lock.
+		e.logger.Debug("check-out before overwrite failed",
+			slog.String("path", action.Path), slog.String("error", checkoutErr.Error()))
+		return nil, err
+	}
+
+	e.markSyncCheckout(ctx, driveID, action)
+
+	result, err = e.transferMgr.UploadFileToItem(ctx, driveID, action.ItemID, localPath, e.uploadOpts(action))
+	if err != nil {
+		if discardErr := e.checkouts.DiscardCheckout(context.WithoutCancel(ctx), driveID, action.ItemID); discardErr != nil {
+			e.logger.Warn("failed to discard check-out after failed upload",
+				slog.String("path", action.Path), slog.String("error", discardErr.Error()))
+		} else {
+			e.clearSyncCheckout(ctx, driveID, action)
+		}
+
+		return nil, err
+	}
+
+	return e.checkinAfterUpload(ctx, driveID, action, result)
+}
+
+// finishSyncCheckout completes an overwrite that went through without a
+// 423. Only an item sync itself checked out on an earlier run, and could not
+// check back in, is checked in here; a check-out the user holds stays.
+func (e *Executor) finishSyncCheckout(
+	ctx context.Context, driveID driveid.ID, action *Action, result *driveops.UploadResult,
+) (*driveops.UploadResult, error) {
+	if e.checkouts == nil || e.checkoutMarkers == nil || result.Item == nil {
+		return result, nil
+	}
+
+	marked, err := e.checkoutMarkers.HasSyncCheckout(ctx, driveID, action.ItemID)
+	if err != nil {
+		e.logger.Warn("failed to read check-out marker",
+			slog.String("path", action.Path), slog.String("error", err.Error()))
+		return result, nil
+	}
+
+	if !marked {
+		return result, nil
+	}
+
+	if !result.Item.CheckedOut {
+		// Checked in some other way since; forget the marker so a later
+		// check-out by the user is not taken for one of ours.
+		e.clearSyncCheckout(ctx, driveID, action)
+		return result, nil
+	}
+
+	return e.checkinAfterUpload(ctx, driveID, action, result)
+}
+
+// markSyncCheckout records a check-out sync made. A failure only costs the
+// ability to finish a failed check-in on a later run, so it is logged.
+func (e *Executor) markSyncCheckout(ctx context.Context, driveID driveid.ID, action *Action) {
+	if e.checkoutMarkers == nil {
+		return
+	}
+
+	if err := e.checkoutMarkers.MarkSyncCheckout(context.WithoutCancel(ctx), driveID, action.ItemID); err != nil {
+		e.logger.Warn("failed to record check-out",
+			slog.String("path", action.Path), slog.String("error", err.Error()))
+	}
+}
+
+func (e *Executor) clearSyncCheckout(ctx context.Context, driveID driveid.ID, action *Action) {
+	if e.checkoutMarkers == nil {
+		return
+	}
+
+	if err := e.checkoutMarkers.ClearSyncCheckout(context.WithoutCancel(ctx), driveID, action.ItemID); err != nil {
+		e.logger.Warn("failed to clear check-out record",
+			slog.String("path", action.Path), slog.String("error", err.Error()))
+	}
+}
+
+// checkinAfterUpload checks the uploaded item back in, trying once more on
+// its own when the first check-in fails: the new content is already on the
+// server, and a failure leaves the file checked out until the next overwrite
+// of it checks it in.
+func (e *Executor) checkinAfterUpload(
+	ctx context.Context, driveID driveid.ID, action *Action, result *driveops.UploadResult,
+) (*driveops.UploadResult, error) {
+	checkinCtx := context.WithoutCancel(ctx)
+
+	err := e.checkouts.CheckinItem(checkinCtx, driveID, action.ItemID, syncCheckinComment)
+	if err != nil {
+		e.logger.Warn("check-in after upload failed, retrying",
+			slog.String("path", action.Path), slog.String("error", err.Error()))
+
+		err = e.checkouts.CheckinItem(checkinCtx, driveID, action.ItemID, syncCheckinComment)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("checking in %s after upload: %w", action.Path, err)
+	}
+
+	e.clearSyncCheckout(ctx, driveID, action)
+
+	// Check-in publishes a new version, so the eTag from the upload is stale.
+	if item, getErr := e.items.GetItem(ctx, driveID, action.ItemID); getErr == nil {
+		result.Item = item
+	} else {
+		e.logger.Warn("failed to refresh item after check-in",
+			slog.String("path", action.Path), slog.String("error", getErr.Error()))
+	}
+
+	return result, nil
+}
+
 func shouldOverwriteKnownRemoteItem(action *Action) bool {
 	if action == nil || action.ItemID == "" {
 		return false
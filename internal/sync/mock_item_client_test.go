@@ -65,7 +65,7 @@ func (m *testMockItemClient) CreateFolder(ctx context.Context, driveID driveid.I
 	return nil, fmt.Errorf("CreateFolder not mocked")
 }
 
-func (m *testMockItemClient) MoveItem(ctx context.Context, driveID driveid.ID, itemID, newParentID, newName string) (*graph.Item, error) {
+func (m *testMockItemClient) MoveItem(ctx context.Context, driveID driveid.ID, itemID, newParentID, newName string, _ graph.ConflictBehavior) (*graph.Item, error) {
 	if m.moveItemIfMatchFn != nil {
 		return m.moveItemIfMatchFn(ctx, driveID, itemID, newParentID, newName, "")
 	}
@@ -63,6 +63,7 @@ func appendObservedEvent(
 		Size:      ev.Size,
 		Mtime:     ev.Mtime,
 		ETag:      ev.ETag,
+		CTag:      ev.CTag,
 		IsDeleted: ev.IsDeleted,
 	})
 }
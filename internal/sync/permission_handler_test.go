@@ -53,6 +53,29 @@ func TestPermHandler_Handle403_NoPermissionRoot(t *testing.T) {
 	assert.Equal(t, permissionEvidenceNone, result.Kind)
 }
 
+// Validates: R-2.14.1, R-6.8.17
+func TestPermHandler_WalkPermissionBoundary_BatchesAncestorPermissions(t *testing.T) {
+	t.Parallel()
+
+	driveID := driveid.New("test-drive")
+	checker := &mockPermChecker{perms: map[string][]graph.Permission{
+		driveID.String() + ":team":   {{Roles: []string{"read"}}},
+		driveID.String() + ":shared": {{Roles: []string{"write"}}},
+	}}
+	ph, _ := newTestPermHandler(t, checker)
+
+	bl := baselineWith(
+		&BaselineEntry{Path: "Shared", DriveID: driveID, ItemID: "shared", ItemType: ItemTypeFolder},
+		&BaselineEntry{Path: "Shared/Team", DriveID: driveID, ItemID: "team", ItemType: ItemTypeFolder},
+		&BaselineEntry{Path: "Shared/Team/Docs", DriveID: driveID, ItemID: "docs", ItemType: ItemTypeFolder},
+	)
+
+	boundary := ph.walkPermissionBoundary(t.Context(), bl, "Shared/Team/Docs", "", driveID)
+
+	assert.Equal(t, "Shared/Team", boundary, "the walk stops below the first writable ancestor")
+	assert.Equal(t, [][]string{{"team", "shared"}}, checker.batchCalls, "every ancestor goes out in one batch")
+}
+
 func TestPermHandler_HandlePermissionCheckError_NotFound(t *testing.T) {
 	t.Parallel()
 
@@ -188,6 +188,9 @@ func (ph *PermissionHandler) remoteBoundaryEvidence(
 	}
 }
 
+// walkPermissionBoundary climbs from startFolder while the parent is still
+// read-only. Every resolvable ancestor is collected first so their
+// permissions come back in one batch instead of one request per level.
 func (ph *PermissionHandler) walkPermissionBoundary(
 	ctx context.Context,
 	bl *Baseline,
@@ -195,9 +198,9 @@ func (ph *PermissionHandler) walkPermissionBoundary(
 	remoteRootItemID string,
 	remoteDriveID driveid.ID,
 ) string {
-	boundary := startFolder
+	var parents, parentIDs []string
 
-	for {
+	for boundary := startFolder; ; {
 		parent, ok := remoteBoundaryParent(boundary)
 		if !ok {
 			break
@@ -208,17 +211,33 @@ func (ph *PermissionHandler) walkPermissionBoundary(
 			break
 		}
 
-		parentPerms, parentErr := ph.permChecker.ListItemPermissions(ctx, remoteDriveID, parentID)
-		if parentErr != nil {
+		parents = append(parents, parent)
+		parentIDs = append(parentIDs, parentID)
+		boundary = parent
+	}
+
+	if len(parentIDs) == 0 {
+		return startFolder
+	}
+
+	results, err := ph.permChecker.ListItemPermissionsBatch(ctx, remoteDriveID, parentIDs)
+	if err != nil {
+		return startFolder
+	}
+
+	boundary := startFolder
+
+	for i := range results {
+		if results[i].Err != nil {
 			break
 		}
 
-		access := graph.EvaluateWriteAccess(parentPerms, ph.accountEmail)
+		access := graph.EvaluateWriteAccess(results[i].Permissions, ph.accountEmail)
 		if access == graph.PermissionWriteAccessWritable || access == graph.PermissionWriteAccessInconclusive {
 			break
 		}
 
-		boundary = parent
+		boundary = parents[i]
 	}
 
 	return boundary
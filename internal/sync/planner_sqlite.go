@@ -425,6 +425,7 @@ func remoteStateFromSnapshotRow(row *RemoteStateRow) *RemoteState {
 		Hash:      row.Hash,
 		Mtime:     row.Mtime,
 		ETag:      row.ETag,
+		CTag:      row.CTag,
 		IsDeleted: false,
 	}
 }
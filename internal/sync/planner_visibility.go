@@ -29,7 +29,8 @@ const (
 		hash          TEXT,
 		size          INTEGER,
 		mtime         INTEGER,
-		etag          TEXT
+		etag          TEXT,
+		ctag          TEXT
 	)`
 	sqlDeletePlannerVisibleLocalState  = `DELETE FROM planner_visible_local_state`
 	sqlDeletePlannerVisibleRemoteState = `DELETE FROM planner_visible_remote_state`
@@ -37,8 +38,8 @@ const (
 		(path, item_type, hash, size, mtime, local_device, local_inode, local_has_identity)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
 	sqlInsertPlannerVisibleRemoteState = `INSERT INTO planner_visible_remote_state
-		(drive_id, item_id, path, item_type, hash, size, mtime, etag)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		(drive_id, item_id, path, item_type, hash, size, mtime, etag, ctag)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	sqlListPlannerVisibleLocalState = `SELECT
 		path, item_type, hash, size, mtime, local_device, local_inode, local_has_identity
 		FROM planner_visible_local_state
@@ -160,6 +161,7 @@ func replacePlannerVisibleStateTx(
 			nullKnownInt64(row.Size, true),
 			nullOptionalInt64(row.Mtime),
 			nullString(row.ETag),
+			nullString(row.CTag),
 		); err != nil {
 			return plannerVisibleRows{}, fmt.Errorf("sync: inserting planner-visible remote_state row for %s: %w", row.Path, err)
 		}
@@ -21,6 +21,7 @@ func readRemoteStateRow(t *testing.T, db *sql.DB, itemID string) *RemoteStateRow
 		size       sql.NullInt64
 		mtime      sql.NullInt64
 		etag       sql.NullString
+		ctag       sql.NullString
 	)
 
 	err := db.QueryRowContext(t.Context(),
@@ -29,7 +30,7 @@ func readRemoteStateRow(t *testing.T, db *sql.DB, itemID string) *RemoteStateRow
 		itemID,
 	).Scan(
 		&rawDriveID, &row.ItemID, &row.Path, &row.ItemType,
-		&hash, &size, &mtime, &etag,
+		&hash, &size, &mtime, &etag, &ctag,
 	)
 	if err == sql.ErrNoRows {
 		return nil
@@ -40,6 +41,7 @@ func readRemoteStateRow(t *testing.T, db *sql.DB, itemID string) *RemoteStateRow
 	row.DriveID = remoteStateDriveID(rawDriveID, driveid.ID{})
 	row.Hash = hash.String
 	row.ETag = etag.String
+	row.CTag = ctag.String
 	if size.Valid {
 		row.Size = size.Int64
 	}
@@ -16,7 +16,14 @@ const (
 	// Generation 17 adds local truth confidence fields to observation_state so
 	// incremental watch commits can distinguish complete local truth from a
 	// suspect snapshot that needs a full local refresh.
-	currentSyncStoreGeneration = 17
+	//
+	// Generation 18 stores the remote cTag in baseline and remote_state so
+	// hashless files can tell content changes from metadata-only eTag churn.
+	//
+	// Generation 19 adds sync_checkouts, the items sync itself checked out
+	// before an overwrite, so an unfinished check-in is never confused with a
+	// check-out the user holds.
+	currentSyncStoreGeneration = 19
 	sqlEnsureStoreMetadataRow  = `INSERT INTO store_metadata (schema_generation)
 		SELECT ?
 		WHERE NOT EXISTS (SELECT 1 FROM store_metadata)`
@@ -39,7 +46,8 @@ CREATE TABLE IF NOT EXISTS baseline (
     local_device    INTEGER NOT NULL DEFAULT 0,
     local_inode     INTEGER NOT NULL DEFAULT 0,
     local_has_identity INTEGER NOT NULL DEFAULT 0 CHECK(local_has_identity IN (0, 1)),
-    etag            TEXT
+    etag            TEXT,
+    ctag            TEXT
 );
 
 CREATE INDEX IF NOT EXISTS idx_baseline_parent ON baseline(parent_id);
@@ -60,7 +68,8 @@ CREATE TABLE IF NOT EXISTS remote_state (
     hash          TEXT,
     size          INTEGER,
     mtime         INTEGER,
-    etag          TEXT
+    etag          TEXT,
+    ctag          TEXT
 );
 
 CREATE UNIQUE INDEX IF NOT EXISTS idx_remote_state_path ON remote_state(path);
@@ -129,6 +138,12 @@ CREATE TABLE IF NOT EXISTS shortcut_roots (
     waiting_remote_drive_id          TEXT    NOT NULL DEFAULT '',
     waiting_remote_item_id           TEXT    NOT NULL DEFAULT '',
     waiting_remote_is_folder         INTEGER NOT NULL DEFAULT 0 CHECK(waiting_remote_is_folder IN (0, 1))
+);
+
+CREATE TABLE IF NOT EXISTS sync_checkouts (
+    drive_id  TEXT NOT NULL,
+    item_id   TEXT NOT NULL,
+    PRIMARY KEY (drive_id, item_id)
 );`
 )
 
@@ -149,14 +164,14 @@ func canonicalSyncStoreColumns() map[string][]string {
 		"baseline": {
 			"item_id", "path", "parent_id", "item_type", "local_hash", "remote_hash",
 			"local_size", "remote_size", "local_mtime", "remote_mtime",
-			"local_device", "local_inode", "local_has_identity", "etag",
+			"local_device", "local_inode", "local_has_identity", "etag", "ctag",
 		},
 		"observation_state": {
 			"content_drive_id", "cursor", "next_full_remote_refresh_at",
 			"local_truth_complete", "local_truth_recovery_reason",
 		},
 		"remote_state": {
-			"drive_id", "item_id", "path", "item_type", "hash", "size", "mtime", "etag",
+			"drive_id", "item_id", "path", "item_type", "hash", "size", "mtime", "etag", "ctag",
 		},
 		"local_state": {
 			"path", "item_type", "hash", "size", "mtime",
@@ -179,6 +194,9 @@ func canonicalSyncStoreColumns() map[string][]string {
 			"waiting_local_alias", "waiting_remote_drive_id", "waiting_remote_item_id",
 			"waiting_remote_is_folder",
 		},
+		"sync_checkouts": {
+			"drive_id", "item_id",
+		},
 	}
 }
 
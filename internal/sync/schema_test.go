@@ -30,6 +30,7 @@ func TestNewSyncStore_CreatesCanonicalSchema(t *testing.T) {
 		"retry_work",
 		"shortcut_roots",
 		"store_metadata",
+		"sync_checkouts",
 	}, tables)
 
 	columns, err := listTableColumns(ctx, store.rawDB(), "observation_state")
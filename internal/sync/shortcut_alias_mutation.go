@@ -46,7 +46,7 @@ func (e *Engine) applyShortcutAliasMutation(ctx context.Context, mutation shortc
 		if mutation.LocalAlias == "" {
 			return fmt.Errorf("sync: shortcut alias rename requires local alias")
 		}
-		if _, err := e.itemsClient.MoveItem(ctx, e.driveID, mutation.BindingItemID, "", mutation.LocalAlias); err != nil {
+		if _, err := e.itemsClient.MoveItem(ctx, e.driveID, mutation.BindingItemID, "", mutation.LocalAlias, ""); err != nil {
 			return fmt.Errorf("sync: rename shortcut alias: %w", err)
 		}
 		return e.recordShortcutAliasRename(ctx, mutation)
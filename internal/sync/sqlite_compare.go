@@ -104,6 +104,10 @@ comparison_state AS (
 			WHEN COALESCE(b.remote_hash, '') <> '' OR COALESCE(r.hash, '') <> '' THEN
 				CASE WHEN COALESCE(b.remote_hash, '') <> COALESCE(r.hash, '') THEN 1 ELSE 0 END
 			WHEN COALESCE(b.remote_size, 0) <> COALESCE(r.size, 0) THEN 1
+			-- cTag only moves with content, so list-field edits that bump
+			-- eTag and mtime do not count as a remote change.
+			WHEN COALESCE(b.ctag, '') <> '' AND COALESCE(r.ctag, '') <> '' THEN
+				CASE WHEN b.ctag <> r.ctag THEN 1 ELSE 0 END
 			WHEN COALESCE(b.remote_mtime, 0) <> COALESCE(r.mtime, 0) THEN 1
 			WHEN COALESCE(b.etag, '') <> COALESCE(r.etag, '') THEN 1
 			ELSE 0
@@ -321,6 +325,10 @@ comparison_flags AS (
 			WHEN COALESCE(b.remote_hash, '') <> '' OR COALESCE(r.hash, '') <> '' THEN
 				CASE WHEN COALESCE(b.remote_hash, '') <> COALESCE(r.hash, '') THEN 1 ELSE 0 END
 			WHEN COALESCE(b.remote_size, 0) <> COALESCE(r.size, 0) THEN 1
+			-- cTag only moves with content, so list-field edits that bump
+			-- eTag and mtime do not count as a remote change.
+			WHEN COALESCE(b.ctag, '') <> '' AND COALESCE(r.ctag, '') <> '' THEN
+				CASE WHEN b.ctag <> r.ctag THEN 1 ELSE 0 END
 			WHEN COALESCE(b.remote_mtime, 0) <> COALESCE(r.mtime, 0) THEN 1
 			WHEN COALESCE(b.etag, '') <> COALESCE(r.etag, '') THEN 1
 			ELSE 0
@@ -165,6 +165,43 @@ func TestQueryReconciliationState_FolderMetadataChurnIsNoop(t *testing.T) {
 	assert.Equal(t, "noop", reconciliationKindsByPath(reconciliationRows)["stable-folder"])
 }
 
+// Validates: R-1.23.3
+func TestQueryReconciliationState_HashlessFileFieldChurnIsNoop(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+	ctx := t.Context()
+
+	_, err := store.rawDB().ExecContext(ctx, `
+		INSERT INTO baseline (
+			item_id, path, item_type, local_hash, local_size, remote_size, local_mtime, remote_mtime, etag, ctag
+		)
+		VALUES
+			('item-fields', 'fields.docx', 'file', 'local', 5, 5, 100, 100, 'etag-old', 'ctag-old'),
+			('item-content', 'content.docx', 'file', 'local', 5, 5, 100, 100, 'etag-old', 'ctag-old')`)
+	require.NoError(t, err)
+
+	_, err = store.rawDB().ExecContext(ctx, `
+		INSERT INTO local_state (path, item_type, hash, size, mtime)
+		VALUES ('fields.docx', 'file', 'local', 5, 100), ('content.docx', 'file', 'local', 5, 100)`)
+	require.NoError(t, err)
+
+	// A list-field edit bumps eTag and mtime but keeps cTag; a content edit
+	// of the same size moves cTag.
+	_, err = store.rawDB().ExecContext(ctx, `
+		INSERT INTO remote_state (item_id, path, item_type, size, mtime, etag, ctag)
+		VALUES
+			('item-fields', 'fields.docx', 'file', 5, 300, 'etag-new', 'ctag-old'),
+			('item-content', 'content.docx', 'file', 5, 300, 'etag-new', 'ctag-new')`)
+	require.NoError(t, err)
+
+	reconciliationRows, err := store.QueryReconciliationState(ctx)
+	require.NoError(t, err)
+	got := reconciliationKindsByPath(reconciliationRows)
+	assert.Equal(t, "noop", got["fields.docx"])
+	assert.Equal(t, "download", got["content.docx"])
+}
+
 // Validates: R-2.1.3, R-2.1.4
 func TestQueryReconciliationState_LocalFolderMoveUsesFilesystemIdentity(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,55 @@
+package sync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+)
+
+// MarkSyncCheckout records that sync checked the item out to overwrite it.
+func (m *SyncStore) MarkSyncCheckout(ctx context.Context, driveID driveid.ID, itemID string) error {
+	if _, err := m.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO sync_checkouts (drive_id, item_id) VALUES (?, ?)`,
+		driveID.String(), itemID,
+	); err != nil {
+		return fmt.Errorf("sync: marking check-out of %s: %w", itemID, err)
+	}
+
+	return nil
+}
+
+// ClearSyncCheckout forgets a check-out sync made, once it is checked in or
+// discarded.
+func (m *SyncStore) ClearSyncCheckout(ctx context.Context, driveID driveid.ID, itemID string) error {
+	if _, err := m.db.ExecContext(ctx,
+		`DELETE FROM sync_checkouts WHERE drive_id = ? AND item_id = ?`,
+		driveID.String(), itemID,
+	); err != nil {
+		return fmt.Errorf("sync: clearing check-out of %s: %w", itemID, err)
+	}
+
+	return nil
+}
+
+// HasSyncCheckout reports whether sync checked the item out and has not
+// yet checked it in.
+func (m *SyncStore) HasSyncCheckout(ctx context.Context, driveID driveid.ID, itemID string) (bool, error) {
+	var one int
+
+	err := m.db.QueryRowContext(ctx,
+		`SELECT 1 FROM sync_checkouts WHERE drive_id = ? AND item_id = ?`,
+		driveID.String(), itemID,
+	).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("sync: reading check-out of %s: %w", itemID, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,42 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonimelisma/onedrive-go/internal/driveid"
+	"github.com/tonimelisma/onedrive-go/internal/synctest"
+)
+
+// Validates: R-1.22.3
+func TestSyncStore_CheckoutMarkers(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+	ctx := t.Context()
+	drive := driveid.New(synctest.TestDriveID)
+	other := driveid.New("0000000000000002")
+
+	marked, err := store.HasSyncCheckout(ctx, drive, "item")
+	require.NoError(t, err)
+	assert.False(t, marked)
+
+	require.NoError(t, store.MarkSyncCheckout(ctx, drive, "item"))
+	require.NoError(t, store.MarkSyncCheckout(ctx, drive, "item"), "marking twice is harmless")
+
+	marked, err = store.HasSyncCheckout(ctx, drive, "item")
+	require.NoError(t, err)
+	assert.True(t, marked)
+
+	marked, err = store.HasSyncCheckout(ctx, other, "item")
+	require.NoError(t, err)
+	assert.False(t, marked, "markers are per drive")
+
+	require.NoError(t, store.ClearSyncCheckout(ctx, drive, "item"))
+
+	marked, err = store.HasSyncCheckout(ctx, drive, "item")
+	require.NoError(t, err)
+	assert.False(t, marked)
+}
@@ -19,7 +19,7 @@ import (
 
 const (
 	sqlSelectRemoteStateCols = `drive_id, item_id, path, item_type,
-		hash, size, mtime, etag`
+		hash, size, mtime, etag, ctag`
 	sqlGetRemoteStateByPath = `SELECT ` + sqlSelectRemoteStateCols + `
 		FROM remote_state
 		WHERE path = ?`
@@ -64,11 +64,12 @@ func queryRemoteStateRowsWithRunner(
 			size       sql.NullInt64
 			mtime      sql.NullInt64
 			etag       sql.NullString
+			ctag       sql.NullString
 		)
 
 		if err := rows.Scan(
 			&rawDriveID, &row.ItemID, &row.Path, &row.ItemType,
-			&hash, &size, &mtime, &etag,
+			&hash, &size, &mtime, &etag, &ctag,
 		); err != nil {
 			return nil, fmt.Errorf("sync: scanning remote_state row: %w", err)
 		}
@@ -76,6 +77,7 @@ func queryRemoteStateRowsWithRunner(
 		row.DriveID = remoteStateDriveID(rawDriveID, contentDriveID)
 		row.Hash = hash.String
 		row.ETag = etag.String
+		row.CTag = ctag.String
 
 		if size.Valid {
 			row.Size = size.Int64
@@ -154,11 +156,12 @@ func scanRemoteStateRowWithQuerier(
 		size       sql.NullInt64
 		mtime      sql.NullInt64
 		etag       sql.NullString
+		ctag       sql.NullString
 	)
 
 	if err := scan(
 		&rawDriveID, &row.ItemID, &row.Path, &row.ItemType,
-		&hash, &size, &mtime, &etag,
+		&hash, &size, &mtime, &etag, &ctag,
 	); err != nil {
 		return nil, err
 	}
@@ -166,6 +169,7 @@ func scanRemoteStateRowWithQuerier(
 	row.DriveID = remoteStateDriveID(rawDriveID, fallbackDriveID)
 	row.Hash = hash.String
 	row.ETag = etag.String
+	row.CTag = ctag.String
 
 	if size.Valid {
 		row.Size = size.Int64
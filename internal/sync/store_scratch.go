@@ -16,14 +16,14 @@ const (
 	sqlInsertScratchBaseline = `INSERT INTO baseline
 		(item_id, path, parent_id, item_type, local_hash, remote_hash,
 		 local_size, remote_size, local_mtime, remote_mtime,
-		 local_device, local_inode, local_has_identity, etag)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		 local_device, local_inode, local_has_identity, etag, ctag)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	sqlListScratchRemoteState = `SELECT ` + sqlSelectRemoteStateCols + `
 		FROM remote_state
 		ORDER BY path`
 	sqlInsertScratchRemoteState = `INSERT INTO remote_state
-		(drive_id, item_id, path, item_type, hash, size, mtime, etag)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		(drive_id, item_id, path, item_type, hash, size, mtime, etag, ctag)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 )
 
 type scratchPlanningSeed struct {
@@ -190,6 +190,7 @@ func insertScratchBaselineEntries(
 			int64(entry.LocalInode),
 			boolInt(entry.LocalHasIdentity),
 			nullString(entry.ETag),
+			nullString(entry.CTag),
 		); err != nil {
 			return fmt.Errorf("sync: inserting scratch baseline row for %s: %w", entry.Path, err)
 		}
@@ -214,6 +215,7 @@ func listScratchRemoteStateRows(ctx context.Context, runner sqlTxRunner) ([]Remo
 			size       sql.NullInt64
 			mtime      sql.NullInt64
 			etag       sql.NullString
+			ctag       sql.NullString
 		)
 
 		if err := rows.Scan(
@@ -225,6 +227,7 @@ func listScratchRemoteStateRows(ctx context.Context, runner sqlTxRunner) ([]Remo
 			&size,
 			&mtime,
 			&etag,
+			&ctag,
 		); err != nil {
 			return nil, fmt.Errorf("sync: scanning scratch remote_state seed row: %w", err)
 		}
@@ -232,6 +235,7 @@ func listScratchRemoteStateRows(ctx context.Context, runner sqlTxRunner) ([]Remo
 		row.DriveID = remoteStateDriveID(rawDriveID, driveid.ID{})
 		row.Hash = hash.String
 		row.ETag = etag.String
+		row.CTag = ctag.String
 		if size.Valid {
 			row.Size = size.Int64
 		}
@@ -265,6 +269,7 @@ func insertScratchRemoteStateRows(
 			nullKnownInt64(row.Size, true),
 			nullOptionalInt64(row.Mtime),
 			nullString(row.ETag),
+			nullString(row.CTag),
 		); err != nil {
 			return fmt.Errorf("sync: inserting scratch remote_state row for %s: %w", row.Path, err)
 		}
@@ -55,6 +55,7 @@ type ObservedItem struct {
 	Size      int64
 	Mtime     int64
 	ETag      string
+	CTag      string
 	IsDeleted bool
 }
 
@@ -68,6 +69,7 @@ type RemoteStateRow struct {
 	Size     int64
 	Mtime    int64
 	ETag     string
+	CTag     string
 }
 
 // LocalStateRow represents a row from the local_state table.
@@ -33,14 +33,14 @@ import (
 const (
 	sqlLoadBaseline = `SELECT item_id, path, parent_id, item_type,
 		local_hash, remote_hash, local_size, remote_size, local_mtime, remote_mtime,
-		local_device, local_inode, local_has_identity, etag
+		local_device, local_inode, local_has_identity, etag, ctag
 		FROM baseline`
 
 	sqlUpsertBaseline = `INSERT INTO baseline
 		(item_id, path, parent_id, item_type, local_hash, remote_hash,
 		 local_size, remote_size, local_mtime, remote_mtime,
-		 local_device, local_inode, local_has_identity, etag)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 local_device, local_inode, local_has_identity, etag, ctag)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(item_id) DO UPDATE SET
 		 path = excluded.path,
 		 parent_id = excluded.parent_id,
@@ -54,7 +54,8 @@ const (
 		 local_device = excluded.local_device,
 		 local_inode = excluded.local_inode,
 		 local_has_identity = excluded.local_has_identity,
-		 etag = excluded.etag`
+		 etag = excluded.etag,
+		 ctag = excluded.ctag`
 
 	sqlDeleteBaseline = `DELETE FROM baseline WHERE path = ?`
 )
@@ -155,12 +156,13 @@ func scanBaselineRow(rows *sql.Rows, contentDriveID driveid.ID) (*BaselineEntry,
 		localInode       int64
 		localHasIdentity int
 		etag             sql.NullString
+		ctag             sql.NullString
 	)
 
 	err := rows.Scan(
 		&e.ItemID, &e.Path, &parentID, &e.ItemType,
 		&localHash, &remoteHash, &localSize, &remoteSize, &localMtime, &remoteMtime,
-		&localDevice, &localInode, &localHasIdentity, &etag,
+		&localDevice, &localInode, &localHasIdentity, &etag, &ctag,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("sync: scanning baseline row: %w", err)
@@ -171,6 +173,7 @@ func scanBaselineRow(rows *sql.Rows, contentDriveID driveid.ID) (*BaselineEntry,
 	e.LocalHash = localHash.String
 	e.RemoteHash = remoteHash.String
 	e.ETag = etag.String
+	e.CTag = ctag.String
 
 	if localSize.Valid {
 		e.LocalSize = localSize.Int64
@@ -273,6 +276,9 @@ func fillMutationFromBaselineDefaults(mutation *BaselineMutation, baseline *Base
 	if mutation.ETag == "" {
 		mutation.ETag = baseline.ETag
 	}
+	if mutation.CTag == "" {
+		mutation.CTag = baseline.CTag
+	}
 }
 
 func publicationMutationFromAction(action *Action, defaultDriveID driveid.ID) (*BaselineMutation, error) {
@@ -301,6 +307,7 @@ func publicationMutationFromAction(action *Action, defaultDriveID driveid.ID) (*
 			mutation.RemoteSizeKnown = true
 			mutation.RemoteMtime = action.View.Remote.Mtime
 			mutation.ETag = action.View.Remote.ETag
+			mutation.CTag = action.View.Remote.CTag
 			mutation.ItemType = action.View.Remote.ItemType
 			if !action.View.Remote.DriveID.IsZero() {
 				mutation.DriveID = action.View.Remote.DriveID
@@ -418,6 +425,7 @@ func (m *SyncStore) RefreshLocalBaseline(ctx context.Context, refresh LocalBasel
 		entry.RemoteSizeKnown = existing.RemoteSizeKnown
 		entry.RemoteMtime = existing.RemoteMtime
 		entry.ETag = existing.ETag
+		entry.CTag = existing.CTag
 	}
 
 	tx, err := beginPerfTx(ctx, m.db)
@@ -454,6 +462,7 @@ func (m *SyncStore) RefreshLocalBaseline(ctx context.Context, refresh LocalBasel
 		int64(entry.LocalInode),
 		boolInt(entry.LocalHasIdentity),
 		nullString(entry.ETag),
+		nullString(entry.CTag),
 	)
 	if err != nil {
 		return fmt.Errorf("sync: refreshing baseline for %s: %w", refresh.Path, err)
@@ -574,6 +583,7 @@ func mutationToEntry(o *BaselineMutation) *BaselineEntry {
 		LocalInode:       o.LocalInode,
 		LocalHasIdentity: o.LocalHasIdentity,
 		ETag:             o.ETag,
+		CTag:             o.CTag,
 	}
 }
 
@@ -607,6 +617,7 @@ func mutationFromActionOutcome(o *ActionOutcome) *BaselineMutation {
 		LocalHasIdentity:      o.LocalHasIdentity,
 		LocalIdentityObserved: o.LocalIdentityObserved,
 		ETag:                  o.ETag,
+		CTag:                  o.CTag,
 	}
 }
 
@@ -640,6 +651,7 @@ func commitUpsert(ctx context.Context, tx sqlTxRunner, o *BaselineMutation) erro
 		int64(o.LocalInode),
 		boolInt(o.LocalHasIdentity),
 		nullString(o.ETag),
+		nullString(o.CTag),
 	)
 	if err != nil {
 		return fmt.Errorf("sync: upserting baseline for %s: %w", o.Path, err)
@@ -679,8 +691,8 @@ func updateRemoteStateOnOutcome(ctx context.Context, tx sqlTxRunner, o *Baseline
 	case ActionUpload, ActionFolderCreate:
 		_, err := tx.ExecContext(ctx,
 			`INSERT INTO remote_state (
-				drive_id, item_id, path, item_type, hash, size, mtime, etag
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+				drive_id, item_id, path, item_type, hash, size, mtime, etag, ctag
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 			ON CONFLICT(item_id) DO UPDATE SET
 				drive_id = excluded.drive_id,
 				path = excluded.path,
@@ -688,10 +700,11 @@ func updateRemoteStateOnOutcome(ctx context.Context, tx sqlTxRunner, o *Baseline
 				hash = excluded.hash,
 				size = excluded.size,
 				mtime = excluded.mtime,
-				etag = excluded.etag`,
+				etag = excluded.etag,
+				ctag = excluded.ctag`,
 			o.DriveID.String(), o.ItemID, o.Path, o.ItemType,
 			nullString(o.RemoteHash), nullKnownInt64(o.RemoteSize, o.RemoteSizeKnown), nullOptionalInt64(o.RemoteMtime),
-			nullString(o.ETag),
+			nullString(o.ETag), nullString(o.CTag),
 		)
 		if err != nil {
 			return fmt.Errorf("sync: updating remote_state for upload %s: %w", o.Path, err)
@@ -25,11 +25,11 @@ const (
 		FROM remote_state WHERE item_id = ?`
 
 	sqlInsertRemoteState = `INSERT INTO remote_state
-		(drive_id, item_id, path, item_type, hash, size, mtime, etag)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		(drive_id, item_id, path, item_type, hash, size, mtime, etag, ctag)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	sqlUpdateRemoteState = `UPDATE remote_state SET
-		drive_id = ?, path = ?, item_type = ?, hash = ?, size = ?, mtime = ?, etag = ?
+		drive_id = ?, path = ?, item_type = ?, hash = ?, size = ?, mtime = ?, etag = ?, ctag = ?
 		WHERE item_id = ?`
 )
 
@@ -156,7 +156,8 @@ func observedRemoteStateUpdate(
 		item.Hash != existing.Hash ||
 		item.Size != existing.Size ||
 		item.Mtime != existing.Mtime ||
-		item.ETag != existing.ETag
+		item.ETag != existing.ETag ||
+		item.CTag != existing.CTag
 }
 
 func (m *SyncStore) scanRemoteStateRow(ctx context.Context, tx sqlTxRunner, driveID, itemID string) *RemoteStateRow {
@@ -181,6 +182,7 @@ func (m *SyncStore) insertRemoteState(ctx context.Context, tx sqlTxRunner, item
 		item.ItemType,
 		nullString(item.Hash), nullKnownInt64(item.Size, true), nullOptionalInt64(item.Mtime),
 		nullString(item.ETag),
+		nullString(item.CTag),
 	)
 	if err != nil {
 		return fmt.Errorf("sync: inserting remote_state for %s: %w", item.Path, err)
@@ -199,6 +201,7 @@ func (m *SyncStore) updateRemoteStateFromObs(
 		item.Path, item.ItemType,
 		nullString(item.Hash), nullKnownInt64(item.Size, true), nullOptionalInt64(item.Mtime),
 		nullString(item.ETag),
+		nullString(item.CTag),
 		item.ItemID,
 	)
 	if err != nil {
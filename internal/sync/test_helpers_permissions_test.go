@@ -14,8 +14,9 @@ const (
 )
 
 type mockPermChecker struct {
-	perms map[string][]graph.Permission
-	errs  map[string]error
+	perms      map[string][]graph.Permission
+	errs       map[string]error
+	batchCalls [][]string
 }
 
 func (m *mockPermChecker) ListItemPermissions(
@@ -30,3 +31,19 @@ func (m *mockPermChecker) ListItemPermissions(
 
 	return m.perms[key], nil
 }
+
+func (m *mockPermChecker) ListItemPermissionsBatch(
+	ctx context.Context,
+	driveID driveid.ID,
+	itemIDs []string,
+) ([]graph.BatchPermissionsResult, error) {
+	m.batchCalls = append(m.batchCalls, itemIDs)
+
+	out := make([]graph.BatchPermissionsResult, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		perms, err := m.ListItemPermissions(ctx, driveID, itemID)
+		out = append(out, graph.BatchPermissionsResult{Permissions: perms, Err: err})
+	}
+
+	return out, nil
+}
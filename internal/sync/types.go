@@ -29,4 +29,5 @@ type BaselineMutation struct {
 	LocalMtime            int64
 	RemoteMtime           int64
 	ETag                  string
+	CTag                  string
 }
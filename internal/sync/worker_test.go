@@ -44,7 +44,7 @@ type workerMockUploader struct {
 	uploadFn func(ctx context.Context, driveID driveid.ID, parentID, name string, content io.ReaderAt, size int64, mtime time.Time, progress graph.ProgressFunc) (*graph.Item, error)
 }
 
-func (m *workerMockUploader) Upload(ctx context.Context, driveID driveid.ID, parentID, name string, content io.ReaderAt, size int64, mtime time.Time, progress graph.ProgressFunc) (*graph.Item, error) {
+func (m *workerMockUploader) Upload(ctx context.Context, driveID driveid.ID, parentID, name string, content io.ReaderAt, size int64, mtime time.Time, progress graph.ProgressFunc, _ graph.ConflictBehavior) (*graph.Item, error) {
 	if m.uploadFn != nil {
 		return m.uploadFn(ctx, driveID, parentID, name, content, size, mtime, progress)
 	}